@@ -0,0 +1,141 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/x"
+)
+
+func TestRecorder(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ctx := context.Background()
+
+	var newEvent = func(err error) *audit.Event {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.Header.Set("User-Agent", "Mozilla/5.0")
+		return audit.NewSelfServiceEvent(r, audit.EventTypeLogin, "password", x.NewUUID(), err)
+	}
+
+	t.Run("case=should persist the event", func(t *testing.T) {
+		e := newEvent(errors.New("the provided credentials are invalid"))
+		reg.AuditRecorder().Record(ctx, e)
+
+		actual, err := reg.AuditEventPersister().ListAuditEvents(ctx, audit.Filter{IdentityID: e.IdentityID.UUID}, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		assert.Equal(t, e.ID, actual[0].ID)
+		assert.Equal(t, audit.ActorIdentity, actual[0].Actor)
+		assert.Equal(t, audit.OutcomeFailure, actual[0].Outcome)
+		assert.Equal(t, "the provided credentials are invalid", string(actual[0].Error))
+		assert.Equal(t, "Mozilla/5.0", actual[0].UserAgent)
+	})
+
+	t.Run("case=should not record anything if disabled", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyAuditLogEnabled, false)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyAuditLogEnabled, true)
+		})
+
+		e := newEvent(nil)
+		reg.AuditRecorder().Record(ctx, e)
+
+		count, err := reg.AuditEventPersister().CountAuditEvents(ctx, audit.Filter{IdentityID: e.IdentityID.UUID})
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+
+	t.Run("case=should send the event to the sink", func(t *testing.T) {
+		received := make(chan []byte, 1)
+		sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusNoContent)
+			received <- body
+		}))
+		t.Cleanup(sink.Close)
+
+		conf.MustSet(config.ViperKeyAuditLogSinkURL, sink.URL)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyAuditLogSinkURL, "")
+		})
+
+		e := newEvent(nil)
+		reg.AuditRecorder().Record(ctx, e)
+
+		select {
+		case body := <-received:
+			var actual audit.Event
+			require.NoError(t, json.Unmarshal(body, &actual))
+			assert.Equal(t, e.ID, actual.ID)
+			assert.Equal(t, audit.OutcomeSuccess, actual.Outcome)
+			assert.False(t, gjson.GetBytes(body, "error").Exists(), "%s", body)
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the event to be sent to the sink")
+		}
+	})
+}
+
+func TestHandler(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	router := x.NewRouterAdmin()
+	reg.AuditHandler().RegisterAdminRoutes(router)
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+	conf.MustSet(config.ViperKeyAdminBaseURL, ts.URL)
+
+	identityID := x.NewUUID()
+	r := httptest.NewRequest("DELETE", "/", nil)
+	for _, e := range []*audit.Event{
+		audit.NewAdminEvent(r, audit.EventTypeIdentityCreated, identityID, nil),
+		audit.NewSelfServiceEvent(r, audit.EventTypeLogin, "password", identityID, nil),
+		audit.NewAdminEvent(r, audit.EventTypeIdentityDeleted, x.NewUUID(), nil),
+	} {
+		require.NoError(t, reg.AuditEventPersister().CreateAuditEvent(context.Background(), e))
+	}
+
+	var list = func(t *testing.T, query string) (gjson.Result, *http.Response) {
+		res, err := ts.Client().Get(ts.URL + audit.RouteCollection + query)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body), res
+	}
+
+	t.Run("case=should list all events", func(t *testing.T) {
+		actual, res := list(t, "")
+		assert.Len(t, actual.Array(), 3, "%s", actual.Raw)
+		assert.NotEmpty(t, res.Header.Get("Link"))
+	})
+
+	t.Run("case=should filter by identity", func(t *testing.T) {
+		actual, _ := list(t, "?identity_id="+identityID.String())
+		assert.Len(t, actual.Array(), 2, "%s", actual.Raw)
+		for _, e := range actual.Array() {
+			assert.Equal(t, identityID.String(), e.Get("identity_id").String(), "%s", actual.Raw)
+		}
+	})
+
+	t.Run("case=should filter by identity and type", func(t *testing.T) {
+		actual, _ := list(t, "?identity_id="+identityID.String()+"&type=login")
+		require.Len(t, actual.Array(), 1, "%s", actual.Raw)
+		assert.Equal(t, "identity", actual.Get("0.actor").String(), "%s", actual.Raw)
+		assert.Equal(t, "password", actual.Get("0.method").String(), "%s", actual.Raw)
+	})
+}
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/x"
+)
+
+// EventType is the type of a security relevant event.
+//
+// swagger:model auditEventType
+type EventType string
+
+// Actor is who caused the event.
+//
+// swagger:model auditEventActor
+type Actor string
+
+// Outcome is whether the action which caused the event succeeded.
+//
+// swagger:model auditEventOutcome
+type Outcome string
+
+const (
+	EventTypeLogin           EventType = "login"
+	EventTypeRegistration    EventType = "registration"
+	EventTypeSettings        EventType = "settings"
+	EventTypeRecovery        EventType = "recovery"
+	EventTypeVerification    EventType = "verification"
+	EventTypeIdentityCreated EventType = "identity_created"
+	EventTypeIdentityUpdated EventType = "identity_updated"
+	EventTypeIdentityDeleted EventType = "identity_deleted"
+
+	// ActorIdentity is the identity itself, using a self-service flow.
+	ActorIdentity Actor = "identity"
+
+	// ActorAdmin is a caller of the admin API.
+	ActorAdmin Actor = "admin"
+
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event is a security relevant event, for example a login or an update of an identity using the admin API.
+//
+// swagger:model auditEvent
+type Event struct {
+	// ID is the event's unique ID.
+	//
+	// required: true
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// Type is the event's type.
+	//
+	// required: true
+	Type EventType `json:"type" db:"type"`
+
+	// Actor is who caused the event.
+	//
+	// required: true
+	Actor Actor `json:"actor" db:"actor"`
+
+	// IdentityID is the ID of the identity which is affected by the event, if known.
+	IdentityID uuid.NullUUID `json:"identity_id" faker:"-" db:"identity_id"`
+
+	// Method is the self-service method (e.g. `password`) which caused the event.
+	Method string `json:"method" db:"method"`
+
+	// IPAddress is the IP address of the client which caused the event.
+	IPAddress string `json:"ip_address" db:"ip_address"`
+
+	// UserAgent is the user agent of the client which caused the event.
+	UserAgent string `json:"user_agent" db:"user_agent"`
+
+	// Outcome is whether the action which caused the event succeeded.
+	//
+	// required: true
+	Outcome Outcome `json:"outcome" db:"outcome"`
+
+	// Error is the reason why the action failed.
+	Error sqlxx.NullString `json:"error,omitempty" faker:"-" db:"error"`
+
+	// CreatedAt is the time (UTC) when the event occurred.
+	//
+	// required: true
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+}
+
+func (Event) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "audit_events")
+}
+
+// NewEvent creates an event which was caused by the request. If err is not nil, the event's
+// outcome is a failure.
+func NewEvent(r *http.Request, t EventType, actor Actor, method string, identityID uuid.UUID, err error) *Event {
+	e := &Event{
+		ID:        x.NewUUID(),
+		Type:      t,
+		Actor:     actor,
+		Method:    method,
+		IPAddress: x.ClientIP(r),
+		UserAgent: r.UserAgent(),
+		Outcome:   OutcomeSuccess,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if identityID != uuid.Nil {
+		e.IdentityID = uuid.NullUUID{UUID: identityID, Valid: true}
+	}
+
+	if err != nil {
+		e.Outcome = OutcomeFailure
+		e.Error = sqlxx.NullString(err.Error())
+	}
+
+	return e
+}
+
+// NewSelfServiceEvent creates an event which the identity caused using the given self-service method.
+func NewSelfServiceEvent(r *http.Request, t EventType, method string, identityID uuid.UUID, err error) *Event {
+	return NewEvent(r, t, ActorIdentity, method, identityID, err)
+}
+
+// NewAdminEvent creates an event which was caused by a call of the admin API.
+func NewAdminEvent(r *http.Request, t EventType, identityID uuid.UUID, err error) *Event {
+	return NewEvent(r, t, ActorAdmin, "", identityID, err)
+}
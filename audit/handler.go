@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+const RouteCollection = "/audit/events"
+
+type (
+	handlerDependencies interface {
+		config.Provider
+		x.WriterProvider
+		EventPersistenceProvider
+	}
+	HandlerProvider interface {
+		AuditHandler() *Handler
+	}
+	Handler struct {
+		r handlerDependencies
+	}
+)
+
+func NewHandler(r handlerDependencies) *Handler {
+	return &Handler{r: r}
+}
+
+func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteCollection, h.list)
+}
+
+// A list of audit events.
+// swagger:response auditEventList
+// nolint:deadcode,unused
+type auditEventListResponse struct {
+	// in: body
+	// required: true
+	// type: array
+	Body []Event
+}
+
+// swagger:parameters listAuditEvents
+// nolint:deadcode,unused
+type listAuditEventsParameters struct {
+	// Identity ID
+	//
+	// Only lists events of this identity.
+	//
+	// required: false
+	// in: query
+	IdentityID string `json:"identity_id"`
+
+	// Event Type
+	//
+	// Only lists events of this type (e.g. `login`).
+	//
+	// required: false
+	// in: query
+	Type string `json:"type"`
+
+	// Items per Page
+	//
+	// This is the number of items per page.
+	//
+	// required: false
+	// in: query
+	// default: 100
+	// min: 1
+	// max: 500
+	PerPage int `json:"per_page"`
+
+	// Pagination Page
+	//
+	// required: false
+	// in: query
+	// default: 0
+	// min: 0
+	Page int `json:"page"`
+}
+
+// swagger:route GET /audit/events admin listAuditEvents
+//
+// List Audit Events
+//
+// Lists security relevant events such as logins, password changes, account recoveries, and changes
+// made using the admin API, newest first.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: auditEventList
+//       500: genericError
+func (h *Handler) list(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	page, itemsPerPage := x.ParsePagination(r)
+	f := Filter{
+		IdentityID: x.ParseUUID(r.URL.Query().Get("identity_id")),
+		Type:       EventType(r.URL.Query().Get("type")),
+	}
+
+	events, err := h.r.AuditEventPersister().ListAuditEvents(r.Context(), f, page, itemsPerPage)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	total, err := h.r.AuditEventPersister().CountAuditEvents(r.Context(), f)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	x.PaginationHeader(w, urlx.CopyWithQuery(urlx.AppendPaths(h.r.Config(r.Context()).SelfAdminURL(), RouteCollection), r.URL.Query()), total, page, itemsPerPage)
+	h.r.Writer().Write(w, r, events)
+}
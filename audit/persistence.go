@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/x"
+)
+
+type (
+	// Filter restricts the listed events. Empty fields match all events.
+	Filter struct {
+		IdentityID uuid.UUID
+		Type       EventType
+	}
+
+	// EventPersister persists audit events. Events are append-only and can not be updated or deleted.
+	EventPersister interface {
+		CreateAuditEvent(ctx context.Context, e *Event) error
+
+		// ListAuditEvents returns the events matching the filter, newest first.
+		ListAuditEvents(ctx context.Context, f Filter, page, perPage int) ([]Event, error)
+		CountAuditEvents(ctx context.Context, f Filter) (int64, error)
+	}
+
+	EventPersistenceProvider interface {
+		AuditEventPersister() EventPersister
+	}
+)
+
+func TestPersister(ctx context.Context, p EventPersister) func(t *testing.T) {
+	var createEvent = func(t *testing.T, et EventType, identityID uuid.UUID, ago time.Duration) *Event {
+		e := NewSelfServiceEvent(httptest.NewRequest("POST", "/", nil), et, "password", identityID, nil)
+		e.CreatedAt = e.CreatedAt.Add(-ago).Truncate(time.Second)
+		require.NoError(t, p.CreateAuditEvent(ctx, e))
+		return e
+	}
+
+	var ids = func(events []Event) []uuid.UUID {
+		result := make([]uuid.UUID, len(events))
+		for k := range events {
+			result[k] = events[k].ID
+		}
+		return result
+	}
+
+	return func(t *testing.T) {
+		identityID := x.NewUUID()
+		first := createEvent(t, EventTypeRegistration, identityID, time.Hour)
+		second := createEvent(t, EventTypeLogin, identityID, time.Minute)
+		third := createEvent(t, EventTypeLogin, identityID, 0)
+		createEvent(t, EventTypeLogin, x.NewUUID(), 0)
+
+		t.Run("case=list events of an identity", func(t *testing.T) {
+			actual, err := p.ListAuditEvents(ctx, Filter{IdentityID: identityID}, 0, 10)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{third.ID, second.ID, first.ID}, ids(actual))
+			assert.Equal(t, identityID, actual[0].IdentityID.UUID)
+			assert.Equal(t, OutcomeSuccess, actual[0].Outcome)
+
+			count, err := p.CountAuditEvents(ctx, Filter{IdentityID: identityID})
+			require.NoError(t, err)
+			assert.EqualValues(t, 3, count)
+		})
+
+		t.Run("case=list events by type", func(t *testing.T) {
+			actual, err := p.ListAuditEvents(ctx, Filter{IdentityID: identityID, Type: EventTypeLogin}, 0, 10)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{third.ID, second.ID}, ids(actual))
+
+			count, err := p.CountAuditEvents(ctx, Filter{Type: EventTypeRegistration})
+			require.NoError(t, err)
+			assert.True(t, count >= 1)
+		})
+
+		t.Run("case=paginate events", func(t *testing.T) {
+			actual, err := p.ListAuditEvents(ctx, Filter{IdentityID: identityID}, 2, 2)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{first.ID}, ids(actual))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	recorderDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		EventPersistenceProvider
+	}
+
+	RecorderProvider interface {
+		AuditRecorder() *Recorder
+	}
+
+	// Recorder persists audit events and streams them to the configured sink.
+	Recorder struct {
+		d recorderDependencies
+		c *http.Client
+	}
+)
+
+func NewRecorder(d recorderDependencies, c *http.Client) *Recorder {
+	return &Recorder{d: d, c: c}
+}
+
+// Record persists the event and sends it to the sink, if one is configured. Recording an event must not
+// break the action which caused it, which is why errors are logged instead of returned.
+func (m *Recorder) Record(ctx context.Context, e *Event) {
+	c := m.d.Config(ctx)
+	if !c.AuditLogEnabled() {
+		return
+	}
+
+	if err := m.d.AuditEventPersister().CreateAuditEvent(ctx, e); err != nil {
+		m.d.Logger().WithError(err).
+			WithField("audit_event_type", e.Type).
+			WithField("identity_id", e.IdentityID).
+			Error("Unable to persist audit event.")
+	}
+
+	if sink := c.AuditLogSinkURL(); sink != nil {
+		go func(e Event) {
+			if err := m.send(sink, &e); err != nil {
+				m.d.Logger().WithError(err).
+					WithField("audit_event_id", e.ID).
+					WithField("sink_url", sink.Redacted()).
+					Error("Unable to send audit event to the sink.")
+			}
+		}(*e)
+	}
+}
+
+func (m *Recorder) send(sink *url.URL, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	res, err := m.c.Post(sink.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("expected the sink to respond with a 2xx status code but got: %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package cipher
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+type XChaCha20Poly1305 struct {
+	c XChaCha20Poly1305Configuration
+}
+
+type XChaCha20Poly1305Configuration interface {
+	config.Provider
+}
+
+func NewCipherXChaCha20Poly1305(c XChaCha20Poly1305Configuration) *XChaCha20Poly1305 {
+	return &XChaCha20Poly1305{c: c}
+}
+
+// Encrypt encrypts the message using the first cipher secret. The nonce is prepended to the ciphertext.
+func (c *XChaCha20Poly1305) Encrypt(ctx context.Context, message []byte) (string, error) {
+	if len(message) == 0 {
+		return "", nil
+	}
+
+	key := c.c.Config(ctx).SecretsCipher()[0]
+	aead, err := chacha20poly1305.NewX(key[:])
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initialize the cipher: %s", err))
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(message)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to generate nonce: %s", err))
+	}
+
+	return hex.EncodeToString(aead.Seal(nonce, nonce, message, nil)), nil
+}
+
+// Decrypt decrypts the ciphertext trying all cipher secrets, which allows rotating them.
+func (c *XChaCha20Poly1305) Decrypt(ctx context.Context, ciphertext string) ([]byte, error) {
+	if len(ciphertext) == 0 {
+		return nil, nil
+	}
+
+	raw, err := hex.DecodeString(ciphertext)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the ciphertext: %s", err))
+	}
+
+	if len(raw) < chacha20poly1305.NonceSizeX {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The ciphertext is too short."))
+	}
+
+	nonce, sealed := raw[:chacha20poly1305.NonceSizeX], raw[chacha20poly1305.NonceSizeX:]
+	for _, key := range c.c.Config(ctx).SecretsCipher() {
+		aead, err := chacha20poly1305.NewX(key[:])
+		if err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initialize the cipher: %s", err))
+		}
+
+		if message, err := aead.Open(nil, nonce, sealed, nil); err == nil {
+			return message, nil
+		}
+	}
+
+	return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to decrypt the ciphertext with any of the configured cipher secrets."))
+}
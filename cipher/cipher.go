@@ -0,0 +1,18 @@
+package cipher
+
+import "context"
+
+// Cipher provides methods for encrypting and decrypting secrets which are stored at rest, for example
+// upstream OAuth2 tokens.
+type Cipher interface {
+	// Encrypt returns an encrypted, hex encoded representation of the message or an error if encryption failed.
+	Encrypt(ctx context.Context, message []byte) (string, error)
+
+	// Decrypt returns the message of a hex encoded ciphertext or an error if it could not be decrypted with any
+	// of the configured secrets.
+	Decrypt(ctx context.Context, ciphertext string) ([]byte, error)
+}
+
+type Provider interface {
+	Cipher() Cipher
+}
@@ -0,0 +1,84 @@
+package cipher_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/cipher"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+)
+
+func TestCipher(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	for k, c := range []cipher.Cipher{
+		cipher.NewCipherXChaCha20Poly1305(reg),
+	} {
+		t.Run(fmt.Sprintf("cipher=%T/case=%d", c, k), func(t *testing.T) {
+			conf.MustSet(config.ViperKeySecretsCipher, []string{"secret-thirty-two-character-long"})
+
+			t.Run("case=should encrypt and decrypt", func(t *testing.T) {
+				encrypted, err := c.Encrypt(ctx, []byte("my secret token"))
+				require.NoError(t, err)
+				assert.NotContains(t, encrypted, "my secret token")
+
+				decrypted, err := c.Decrypt(ctx, encrypted)
+				require.NoError(t, err)
+				assert.Equal(t, "my secret token", string(decrypted))
+
+				other, err := c.Encrypt(ctx, []byte("my secret token"))
+				require.NoError(t, err)
+				assert.NotEqual(t, encrypted, other, "nonces must be random")
+			})
+
+			t.Run("case=should pass through empty values", func(t *testing.T) {
+				encrypted, err := c.Encrypt(ctx, nil)
+				require.NoError(t, err)
+				assert.Empty(t, encrypted)
+
+				decrypted, err := c.Decrypt(ctx, "")
+				require.NoError(t, err)
+				assert.Empty(t, decrypted)
+			})
+
+			t.Run("case=should decrypt with rotated secrets", func(t *testing.T) {
+				encrypted, err := c.Encrypt(ctx, []byte("my secret token"))
+				require.NoError(t, err)
+
+				conf.MustSet(config.ViperKeySecretsCipher, []string{"new-secret-thirty-two-chars-long", "secret-thirty-two-character-long"})
+				decrypted, err := c.Decrypt(ctx, encrypted)
+				require.NoError(t, err)
+				assert.Equal(t, "my secret token", string(decrypted))
+
+				conf.MustSet(config.ViperKeySecretsCipher, []string{"new-secret-thirty-two-chars-long"})
+				_, err = c.Decrypt(ctx, encrypted)
+				require.Error(t, err)
+			})
+
+			t.Run("case=should reject malformed ciphertexts", func(t *testing.T) {
+				for _, ciphertext := range []string{"not-hex", "abcd"} {
+					_, err := c.Decrypt(ctx, ciphertext)
+					require.Error(t, err)
+				}
+			})
+
+			t.Run("case=should derive keys from the default secrets", func(t *testing.T) {
+				conf.MustSet(config.ViperKeySecretsCipher, []string{})
+				conf.MustSet(config.ViperKeySecretsDefault, []string{"a-default-secret-of-any-length"})
+
+				encrypted, err := c.Encrypt(ctx, []byte("my secret token"))
+				require.NoError(t, err)
+
+				decrypted, err := c.Decrypt(ctx, encrypted)
+				require.NoError(t, err)
+				assert.Equal(t, "my secret token", string(decrypted))
+			})
+		})
+	}
+}
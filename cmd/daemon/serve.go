@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	cx "context"
 	"net/http"
 	"strings"
 	"sync"
@@ -38,6 +39,7 @@ import (
 	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/selfservice/strategy/profile"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 	"github.com/ory/kratos/x"
 )
 
@@ -78,6 +80,7 @@ func ServePublic(r driver.Registry, wg *sync.WaitGroup, cmd *cobra.Command, args
 	csrf := x.NewCSRFHandler(router, r)
 
 	n.UseFunc(x.CleanPath) // Prevent double slashes from breaking CSRF.
+	n.UseFunc(x.NewClientIPMiddleware(r))
 	r.WithCSRFHandler(csrf)
 	n.UseHandler(r.CSRFHandler())
 
@@ -219,6 +222,46 @@ func bgTasks(d driver.Registry, wg *sync.WaitGroup, cmd *cobra.Command, args []s
 	if d.Config(cmd.Context()).IsBackgroundCourierEnabled() {
 		go courier.Watch(cmd.Context(), d)
 	}
+
+	if c := d.Config(cmd.Context()); c.EventStreamEnabled() && c.EventStreamSinkType() == stream.SinkTypeHTTP {
+		go watchEventStream(cmd.Context(), d)
+	}
+
+	if d.Config(cmd.Context()).SelfServiceStrategy(settings.StrategyDeletion).Enabled {
+		go watchIdentityDeletions(cmd.Context(), d)
+	}
+}
+
+func watchEventStream(ctx cx.Context, d driver.Registry) {
+	ctx, cancel := cx.WithCancel(ctx)
+
+	d.Logger().Println("Event stream relay started.")
+	if err := graceful.Graceful(func() error {
+		return d.EventStreamRelay().Work(ctx)
+	}, func(_ cx.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		d.Logger().WithError(err).Fatalf("Failed to run event stream relay.")
+	}
+
+	d.Logger().Println("Event stream relay was shutdown gracefully.")
+}
+
+func watchIdentityDeletions(ctx cx.Context, d driver.Registry) {
+	ctx, cancel := cx.WithCancel(ctx)
+
+	d.Logger().Println("Identity purger started.")
+	if err := graceful.Graceful(func() error {
+		return d.IdentityPurger().Work(ctx)
+	}, func(_ cx.Context) error {
+		cancel()
+		return nil
+	}); err != nil {
+		d.Logger().WithError(err).Fatalf("Failed to run identity purger.")
+	}
+
+	d.Logger().Println("Identity purger was shutdown gracefully.")
 }
 
 func ServeAll(d driver.Registry, opts ...Option) func(cmd *cobra.Command, args []string) {
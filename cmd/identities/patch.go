@@ -1,19 +1,93 @@
 package identities
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/cmdx"
+	"github.com/ory/x/swaggerx"
 
 	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos-client-go/client/admin"
+	"github.com/ory/kratos-client-go/models"
+	"github.com/ory/kratos/cmd/cliclient"
 )
 
+type identityPatch struct {
+	ID    string                   `json:"id"`
+	Patch models.JSONPatchDocument `json:"patch"`
+}
+
 var PatchCmd = &cobra.Command{
 	Use:   "patch <file.json [file-2.json [file-3.json] ...]>",
-	Short: "Patch identities by ID (not yet implemented)",
-	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		// TODO
-		fmt.Println("not yet implemented")
-		os.Exit(1)
+	Short: "Patch identities by ID from files or STD_IN",
+	Example: `$ cat > ./file.json <<EOF
+{
+    "id": "6e8c3e60-7f4b-4bbc-b2a8-a9a0f7e1b0a5",
+    "patch": [
+        { "op": "replace", "path": "/traits/email", "value": "bar@example.com" }
+    ]
+}
+EOF
+
+$ kratos identities patch file.json
+# Alternatively:
+$ cat file.json | kratos identities patch`,
+	Long: `Patch identities by ID from files or STD_IN.
+
+Files can contain only a single or an array of patch documents. Each patch document consists of the "id" of the identity to be patched and a JSON Patch (RFC 6902) in "patch". The patch may modify the schema ID, state, traits, public and admin metadata, verifiable addresses, and recovery addresses.
+
+WARNING: Patching credentials is not supported.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := cliclient.NewClient(cmd)
+
+		patched := make([]*models.Identity, 0, len(args))
+		failed := make(map[string]error)
+
+		ps, err := readIdentities(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		for src, p := range ps {
+			var params identityPatch
+			if err := json.Unmarshal([]byte(p), &params); err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: Could not parse patch document: %s\n", src, err)
+				return cmdx.FailSilently(cmd)
+			}
+
+			if params.ID == "" {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: Patch document is missing the identity \"id\"\n", src)
+				return cmdx.FailSilently(cmd)
+			}
+
+			resp, err := c.Admin.PatchIdentity(admin.NewPatchIdentityParams().
+				WithID(params.ID).
+				WithBody(params.Patch).
+				WithContext(cmd.Context()).
+				WithHTTPClient(cliclient.NewHTTPClient(cmd)))
+			if err != nil {
+				failed[src] = errors.New(swaggerx.FormatSwaggerError(err))
+				continue
+			}
+
+			patched = append(patched, resp.Payload)
+		}
+
+		if len(patched) == 1 {
+			cmdx.PrintRow(cmd, (*outputIdentity)(patched[0]))
+		} else if len(patched) > 1 {
+			cmdx.PrintTable(cmd, &outputIdentityCollection{identities: patched})
+		}
+		cmdx.PrintErrors(cmd, failed)
+
+		if len(failed) != 0 {
+			return cmdx.FailSilently(cmd)
+		}
+
+		return nil
 	},
 }
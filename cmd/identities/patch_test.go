@@ -0,0 +1,81 @@
+package identities
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/cmdx"
+
+	"github.com/ory/kratos/x"
+)
+
+func TestPatchCmd(t *testing.T) {
+	reg := setup(t, PatchCmd)
+
+	var patchDocument = func(id, value string) string {
+		return fmt.Sprintf(`{"id": "%s", "patch": [{"op": "add", "path": "/traits/testKey", "value": "%s"}]}`, id, value)
+	}
+
+	t.Run("case=patches an identity from file", func(t *testing.T) {
+		is, ids := makeIdentities(t, reg, 1)
+
+		f, err := ioutil.TempFile("", "")
+		require.NoError(t, err)
+		_, err = f.WriteString(patchDocument(ids[0], "foo"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		stdOut := execNoErr(t, PatchCmd, f.Name())
+		assert.Equal(t, ids[0], gjson.Get(stdOut, "id").String(), stdOut)
+
+		i, err := reg.Persister().GetIdentity(context.Background(), is[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "foo", gjson.GetBytes(i.Traits, "testKey").String())
+	})
+
+	t.Run("case=patches multiple identities from STD_IN", func(t *testing.T) {
+		is, ids := makeIdentities(t, reg, 2)
+
+		stdOut, stdErr, err := exec(PatchCmd, bytes.NewBufferString("["+patchDocument(ids[0], "foo")+","+patchDocument(ids[1], "bar")+"]"))
+		require.NoError(t, err, "%s %s", stdOut, stdErr)
+		assert.Len(t, gjson.Parse(stdOut).Array(), 2, stdOut)
+
+		for k, expected := range []string{"foo", "bar"} {
+			i, err := reg.Persister().GetIdentity(context.Background(), is[k].ID)
+			require.NoError(t, err)
+			assert.Equal(t, expected, gjson.GetBytes(i.Traits, "testKey").String())
+		}
+	})
+
+	t.Run("case=fails to apply a patch violating the identity schema", func(t *testing.T) {
+		_, ids := makeIdentities(t, reg, 1)
+
+		stdOut, stdErr, err := exec(PatchCmd, bytes.NewBufferString(
+			fmt.Sprintf(`{"id": "%s", "patch": [{"op": "add", "path": "/traits/unknown", "value": "foo"}]}`, ids[0])))
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "patchIdentityBadRequest", stdErr)
+		assert.Len(t, stdOut, 0)
+	})
+
+	t.Run("case=fails with unknown ID", func(t *testing.T) {
+		stdOut, stdErr, err := exec(PatchCmd, bytes.NewBufferString(patchDocument(x.NewUUID().String(), "foo")))
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "[PATCH /identities/{id}][404] patchIdentityNotFound", stdErr)
+		assert.Len(t, stdOut, 0)
+	})
+
+	t.Run("case=fails without ID", func(t *testing.T) {
+		stdOut, stdErr, err := exec(PatchCmd, bytes.NewBufferString(`{"patch": []}`))
+		assert.True(t, errors.Is(err, cmdx.ErrNoPrintButFail))
+		assert.Contains(t, stdErr, "STD_IN[0]: Patch document is missing", stdErr)
+		assert.Len(t, stdOut, 0)
+	})
+}
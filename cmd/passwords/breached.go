@@ -0,0 +1,87 @@
+package passwords
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ory/kratos/selfservice/strategy/password"
+)
+
+// breachedCmd represents the breached command
+var breachedCmd = &cobra.Command{
+	Use:   "breached",
+	Short: "Build local breached passwords datasets",
+	Long: `Build local breached passwords datasets.
+
+A breached passwords dataset is a prefix-indexed copy of the Have I Been Pwned range API which allows checking
+passwords without network access. Set "selfservice.methods.password.config.haveibeenpwned_dataset" to the
+dataset's directory to use it.`,
+}
+
+// BreachedImportCmd represents the breached import command
+var BreachedImportCmd = &cobra.Command{
+	Use:   "import <hashes.txt|-> <dataset-directory>",
+	Short: "Import SHA-1 hashes of breached passwords into a local dataset",
+	Example: `$ kratos passwords breached import pwned-passwords-sha1-ordered-by-hash.txt /var/lib/kratos/breached-passwords
+# Alternatively:
+$ cat pwned-passwords-sha1-ordered-by-hash.txt | kratos passwords breached import - /var/lib/kratos/breached-passwords`,
+	Long: `Import SHA-1 hashes of breached passwords from a file or STD_IN into a local dataset.
+
+Each line contains a hex encoded SHA-1 hash optionally followed by a colon and how often the password was breached,
+as in the "ordered by hash" SHA-1 downloads of Have I Been Pwned. Importing hashes which are ordered by hash is much
+faster. The dataset directory must not exist or be empty.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeDataset(cmd, args[0], args[1], password.ImportBreachedHashes)
+	},
+}
+
+// BreachedBuildCmd represents the breached build command
+var BreachedBuildCmd = &cobra.Command{
+	Use:     "build <passwords.txt|-> <dataset-directory>",
+	Short:   "Build a local dataset from a list of breached passwords",
+	Example: `$ kratos passwords breached build breached-passwords.txt /var/lib/kratos/breached-passwords`,
+	Long: `Build a local dataset from a list of breached passwords read from a file or STD_IN.
+
+Each line contains one plaintext password. Passwords which are listed multiple times are counted as breached multiple
+times. The list is kept in memory while building the dataset. The dataset directory must not exist or be empty.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeDataset(cmd, args[0], args[1], password.ImportBreachedPasswords)
+	},
+}
+
+func writeDataset(cmd *cobra.Command, src, dir string, importer func(*password.BreachedDatasetWriter, io.Reader) (int, error)) error {
+	var r io.Reader = cmd.InOrStdin()
+	if src != "-" {
+		f, err := os.Open(src)
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not open file %s: %s\n", src, err)
+			return errors.WithStack(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	d, err := password.NewBreachedDatasetWriter(dir)
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not create the dataset: %s\n", err)
+		return err
+	}
+
+	n, err := importer(d, r)
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Could not write the dataset after adding %d hashes: %s\n", n, err)
+		return err
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Added %d hashes to the dataset %s.\n", n, dir)
+	return nil
+}
@@ -0,0 +1,62 @@
+package passwords
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/selfservice/strategy/password"
+)
+
+func TestBreachedCmd(t *testing.T) {
+	var exec = func(cmd *cobra.Command, stdIn string, args ...string) (string, string, error) {
+		stdOut, stdErr := &bytes.Buffer{}, &bytes.Buffer{}
+		cmd.SetOut(stdOut)
+		cmd.SetErr(stdErr)
+		cmd.SetIn(strings.NewReader(stdIn))
+		defer cmd.SetIn(nil)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdOut.String(), stdErr.String(), err
+	}
+
+	hash := password.BreachedPasswordHash("fecnibwoza")
+
+	t.Run("case=imports hashes from a file", func(t *testing.T) {
+		src := filepath.Join(t.TempDir(), "hashes.txt")
+		require.NoError(t, ioutil.WriteFile(src, []byte(hash+":12\n"), 0600))
+		dir := filepath.Join(t.TempDir(), "dataset")
+
+		stdOut, stdErr, err := exec(BreachedImportCmd, "", src, dir)
+		require.NoError(t, err, stdErr)
+		assert.Contains(t, stdOut, "Added 1 hashes")
+
+		actual, err := ioutil.ReadFile(filepath.Join(dir, hash[:5]+".txt"))
+		require.NoError(t, err)
+		assert.Equal(t, hash[5:]+":12\n", string(actual))
+	})
+
+	t.Run("case=builds the dataset from passwords read from STD_IN", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "dataset")
+
+		stdOut, stdErr, err := exec(BreachedBuildCmd, "fecnibwoza\nfecnibwoza\n", "-", dir)
+		require.NoError(t, err, stdErr)
+		assert.Contains(t, stdOut, "Added 1 hashes")
+
+		actual, err := ioutil.ReadFile(filepath.Join(dir, hash[:5]+".txt"))
+		require.NoError(t, err)
+		assert.Equal(t, hash[5:]+":2\n", string(actual))
+	})
+
+	t.Run("case=fails for invalid hashes", func(t *testing.T) {
+		_, stdErr, err := exec(BreachedImportCmd, "not-a-hash\n", "-", t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, stdErr, "expected a hex encoded SHA-1 hash")
+	})
+}
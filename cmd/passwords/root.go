@@ -0,0 +1,19 @@
+package passwords
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// passwordsCmd represents the passwords command
+var passwordsCmd = &cobra.Command{
+	Use:   "passwords",
+	Short: "Helpers for validating passwords",
+}
+
+func RegisterCommandRecursive(parent *cobra.Command) {
+	parent.AddCommand(passwordsCmd)
+
+	passwordsCmd.AddCommand(breachedCmd)
+	breachedCmd.AddCommand(BreachedImportCmd)
+	breachedCmd.AddCommand(BreachedBuildCmd)
+}
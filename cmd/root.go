@@ -15,6 +15,7 @@ import (
 	"github.com/ory/kratos/cmd/identities"
 	"github.com/ory/kratos/cmd/jsonnet"
 	"github.com/ory/kratos/cmd/migrate"
+	"github.com/ory/kratos/cmd/passwords"
 	"github.com/ory/kratos/cmd/serve"
 	"github.com/ory/x/cmdx"
 
@@ -47,6 +48,7 @@ func init() {
 	remote.RegisterCommandRecursive(RootCmd)
 	hashers.RegisterCommandRecursive(RootCmd)
 	courier.RegisterCommandRecursive(RootCmd)
+	passwords.RegisterCommandRecursive(RootCmd)
 
 	RootCmd.AddCommand(cmdx.Version(&config.Version, &config.Commit, &config.Date))
 }
@@ -0,0 +1,52 @@
+package template
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+// SecurityNotificationType is the type of a security notification. It is also the name of the notification's
+// template directory and configuration key.
+type SecurityNotificationType string
+
+const (
+	SecurityNotificationPasswordChanged   SecurityNotificationType = "password_changed"
+	SecurityNotificationOIDCLinked        SecurityNotificationType = "oidc_linked"
+	SecurityNotificationRecoveryCompleted SecurityNotificationType = "recovery_completed"
+	SecurityNotificationLoginNewDevice    SecurityNotificationType = "login_new_device"
+)
+
+type (
+	SecurityNotification struct {
+		c *config.Config
+		t SecurityNotificationType
+		m *SecurityNotificationModel
+	}
+	SecurityNotificationModel struct {
+		To         string
+		IPAddress  string
+		UserAgent  string
+		OccurredAt time.Time
+
+		// Provider is the ID of the linked provider and only set for SecurityNotificationOIDCLinked.
+		Provider string
+	}
+)
+
+func NewSecurityNotification(c *config.Config, t SecurityNotificationType, m *SecurityNotificationModel) *SecurityNotification {
+	return &SecurityNotification{c: c, t: t, m: m}
+}
+
+func (t *SecurityNotification) EmailRecipient() (string, error) {
+	return t.m.To, nil
+}
+
+func (t *SecurityNotification) EmailSubject() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "security", string(t.t), "email.subject.gotmpl"), t.m)
+}
+
+func (t *SecurityNotification) EmailBody() (string, error) {
+	return loadTextTemplate(filepath.Join(t.c.CourierTemplatesRoot(), "security", string(t.t), "email.body.gotmpl"), t.m)
+}
@@ -0,0 +1,42 @@
+package template_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/internal"
+)
+
+func TestSecurityNotification(t *testing.T) {
+	conf, _ := internal.NewFastRegistryWithMocks(t)
+
+	for _, tt := range []template.SecurityNotificationType{
+		template.SecurityNotificationPasswordChanged,
+		template.SecurityNotificationOIDCLinked,
+		template.SecurityNotificationRecoveryCompleted,
+		template.SecurityNotificationLoginNewDevice,
+	} {
+		t.Run("type="+string(tt), func(t *testing.T) {
+			tpl := template.NewSecurityNotification(conf, tt, &template.SecurityNotificationModel{
+				To:         "foo@ory.sh",
+				IPAddress:  "192.0.2.1",
+				UserAgent:  "Mozilla/5.0",
+				OccurredAt: time.Now(),
+				Provider:   "github",
+			})
+
+			rendered, err := tpl.EmailBody()
+			require.NoError(t, err)
+			assert.Contains(t, rendered, "192.0.2.1")
+			assert.Contains(t, rendered, "Mozilla/5.0")
+
+			rendered, err = tpl.EmailSubject()
+			require.NoError(t, err)
+			assert.NotEmpty(t, rendered)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"bytes"
 	"context"
+	"crypto/sha512"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/ory/x/logrusx"
 	"github.com/ory/x/tracing"
+	"github.com/ory/x/urlx"
 
 	kjson "github.com/knadh/koanf/parsers/json"
 )
@@ -42,10 +44,12 @@ const (
 	ViperKeyCourierSMTPFromName                                     = "courier.smtp.from_name"
 	ViperKeySecretsDefault                                          = "secrets.default"
 	ViperKeySecretsCookie                                           = "secrets.cookie"
+	ViperKeySecretsCipher                                           = "secrets.cipher"
 	ViperKeyPublicBaseURL                                           = "serve.public.base_url"
 	ViperKeyPublicDomainAliases                                     = "serve.public.domain_aliases"
 	ViperKeyPublicPort                                              = "serve.public.port"
 	ViperKeyPublicHost                                              = "serve.public.host"
+	ViperKeyPublicTrustedProxies                                    = "serve.public.trusted_proxies"
 	ViperKeyAdminBaseURL                                            = "serve.admin.base_url"
 	ViperKeyAdminPort                                               = "serve.admin.port"
 	ViperKeyAdminHost                                               = "serve.admin.host"
@@ -89,6 +93,36 @@ const (
 	ViperKeyHasherArgon2ConfigKeyLength                             = "hashers.argon2.key_length"
 	ViperKeyPasswordMaxBreaches                                     = "selfservice.methods.password.config.max_breaches"
 	ViperKeyIgnoreNetworkErrors                                     = "selfservice.methods.password.config.ignore_network_errors"
+	ViperKeyPasswordHaveIBeenPwnedURL                               = "selfservice.methods.password.config.haveibeenpwned_url"
+	ViperKeyPasswordHaveIBeenPwnedDataset                           = "selfservice.methods.password.config.haveibeenpwned_dataset"
+	ViperKeyPasswordMinLength                                       = "selfservice.methods.password.config.min_password_length"
+	ViperKeyPasswordMaxLength                                       = "selfservice.methods.password.config.max_password_length"
+	ViperKeyPasswordRequiredCharacterClasses                        = "selfservice.methods.password.config.required_character_classes"
+	ViperKeyPasswordMinStrengthScore                                = "selfservice.methods.password.config.min_strength_score"
+	ViperKeyPasswordDenyList                                        = "selfservice.methods.password.config.deny_list"
+	ViperKeyPasswordIdentifierSimilarityCheckEnabled                = "selfservice.methods.password.config.identifier_similarity_check_enabled"
+	ViperKeyPasswordMinIdentifierDistance                           = "selfservice.methods.password.config.min_identifier_distance"
+	ViperKeyPasswordMaxIdentifierSubstringRatio                     = "selfservice.methods.password.config.max_identifier_substring_ratio"
+	ViperKeyPasswordHistorySize                                     = "selfservice.methods.password.config.history_size"
+	ViperKeyPasswordMaxAge                                          = "selfservice.methods.password.config.max_age"
+	ViperKeyDeletionGracePeriod                                     = "selfservice.methods.deletion.config.grace_period"
+	ViperKeyPasswordLockoutEnabled                                  = "selfservice.methods.password.config.lockout.enabled"
+	ViperKeyPasswordLockoutMaxAttempts                              = "selfservice.methods.password.config.lockout.max_attempts"
+	ViperKeyPasswordLockoutMaxAttemptsPerIP                         = "selfservice.methods.password.config.lockout.max_attempts_per_ip"
+	ViperKeyPasswordLockoutDuration                                 = "selfservice.methods.password.config.lockout.duration"
+	ViperKeyPasswordLockoutBaseDelay                                = "selfservice.methods.password.config.lockout.base_delay"
+	ViperKeySelfServiceCaptchaProvider                              = "selfservice.captcha.provider"
+	ViperKeySelfServiceCaptchaConfig                                = "selfservice.captcha.config"
+	ViperKeySelfServiceCaptchaFailureWindow                         = "selfservice.captcha.failure_window"
+	ViperKeySelfServiceRateLimitStore                               = "selfservice.rate_limit.store"
+	ViperKeySelfServiceSecurityNotifications                        = "selfservice.security_notifications"
+	ViperKeyAuditLogEnabled                                         = "audit_log.enabled"
+	ViperKeyAuditLogSinkURL                                         = "audit_log.sink.url"
+	ViperKeyEventStreamEnabled                                      = "event_stream.enabled"
+	ViperKeyEventStreamSinkType                                     = "event_stream.sink.type"
+	ViperKeyEventStreamSinkURL                                      = "event_stream.sink.url"
+	ViperKeyEventStreamRelayInterval                                = "event_stream.relay_interval"
+	ViperKeyEventStreamRelayMaxAttempts                             = "event_stream.relay_max_attempts"
 	ViperKeyVersion                                                 = "version"
 	Argon2DefaultMemory                                      uint32 = 4 * 1024 * 1024
 	Argon2DefaultIterations                                  uint32 = 4
@@ -120,8 +154,46 @@ type (
 		URL string `json:"url"`
 	}
 	PasswordPolicy struct {
-		MaxBreaches         uint `json:"max_breaches"`
-		IgnoreNetworkErrors bool `json:"ignore_network_errors"`
+		MaxBreaches                      uint          `json:"max_breaches"`
+		IgnoreNetworkErrors              bool          `json:"ignore_network_errors"`
+		HaveIBeenPwnedURL                *url.URL      `json:"haveibeenpwned_url"`
+		HaveIBeenPwnedDataset            string        `json:"haveibeenpwned_dataset"`
+		MinLength                        int           `json:"min_password_length"`
+		MaxLength                        int           `json:"max_password_length"`
+		RequiredCharacterClasses         []string      `json:"required_character_classes"`
+		MinStrengthScore                 int           `json:"min_strength_score"`
+		DenyList                         []string      `json:"deny_list"`
+		IdentifierSimilarityCheckEnabled bool          `json:"identifier_similarity_check_enabled"`
+		MinIdentifierDistance            int           `json:"min_identifier_distance"`
+		MaxIdentifierSubstringRatio      float64       `json:"max_identifier_substring_ratio"`
+		HistorySize                      int           `json:"history_size"`
+		MaxAge                           time.Duration `json:"max_age"`
+	}
+	SelfServiceCaptcha struct {
+		Provider      string          `json:"provider"`
+		Config        json.RawMessage `json:"config"`
+		FailureWindow time.Duration   `json:"failure_window"`
+	}
+	SelfServiceFlowCaptcha struct {
+		Enabled                bool `json:"enabled"`
+		AfterFailures          int  `json:"after_failures"`
+		ChallengeRiskyRequests bool `json:"challenge_risky_requests"`
+	}
+	SelfServiceFlowRateLimit struct {
+		Enabled       bool      `json:"enabled"`
+		PerIP         RateLimit `json:"per_ip"`
+		PerIdentifier RateLimit `json:"per_identifier"`
+	}
+	RateLimit struct {
+		Limit  int           `json:"limit"`
+		Window time.Duration `json:"window"`
+	}
+	PasswordLockout struct {
+		Enabled          bool          `json:"enabled"`
+		MaxAttempts      int           `json:"max_attempts"`
+		MaxAttemptsPerIP int           `json:"max_attempts_per_ip"`
+		Duration         time.Duration `json:"duration"`
+		BaseDelay        time.Duration `json:"base_delay"`
 	}
 	Schemas []Schema
 	Config  struct {
@@ -289,6 +361,30 @@ func (p *Config) PublicListenOn() string {
 	return p.listenOn("public")
 }
 
+// PublicTrustedProxies returns the networks of the reverse proxies whose X-Forwarded-For header is used to
+// determine the client's IP address. Single IP addresses are returned as networks containing only that address.
+func (p *Config) PublicTrustedProxies() (proxies []*net.IPNet) {
+	for k, raw := range p.p.Strings(ViperKeyPublicTrustedProxies) {
+		if !strings.Contains(raw, "/") {
+			if ip := net.ParseIP(raw); ip != nil && ip.To4() != nil {
+				raw += "/32"
+			} else {
+				raw += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(raw)
+		if err != nil {
+			p.l.WithError(err).Warnf("Ignoring trusted proxy \"%s\" from configuration key \"%s.%d\".", raw, ViperKeyPublicTrustedProxies, k)
+			continue
+		}
+
+		proxies = append(proxies, network)
+	}
+
+	return proxies
+}
+
 func (p *Config) DSN() string {
 	dsn := p.p.String(ViperKeyDSN)
 
@@ -405,6 +501,64 @@ func (p *Config) SelfServiceStrategy(strategy string) *SelfServiceStrategy {
 	return s
 }
 
+func (p *Config) SelfServiceCaptcha() *SelfServiceCaptcha {
+	config := "{}"
+	out, err := p.p.Marshal(kjson.Parser())
+	if err != nil {
+		p.l.WithError(err).Warn("Unable to marshal CAPTCHA configuration.")
+	} else if c := gjson.GetBytes(out, ViperKeySelfServiceCaptchaConfig).Raw; len(c) > 0 {
+		config = c
+	}
+
+	return &SelfServiceCaptcha{
+		Provider:      p.p.StringF(ViperKeySelfServiceCaptchaProvider, "siteverify"),
+		Config:        json.RawMessage(config),
+		FailureWindow: p.p.DurationF(ViperKeySelfServiceCaptchaFailureWindow, time.Hour),
+	}
+}
+
+// SelfServiceFlowCaptcha returns the CAPTCHA configuration of the given flow (e.g. "login").
+func (p *Config) SelfServiceFlowCaptcha(flow string) *SelfServiceFlowCaptcha {
+	key := fmt.Sprintf("selfservice.flows.%s.captcha", flow)
+	return &SelfServiceFlowCaptcha{
+		Enabled:                p.p.Bool(key + ".enabled"),
+		AfterFailures:          p.p.Int(key + ".after_failures"),
+		ChallengeRiskyRequests: p.p.BoolF(key+".challenge_risky_requests", true),
+	}
+}
+
+func (p *Config) SelfServiceRateLimitStore() string {
+	return p.p.StringF(ViperKeySelfServiceRateLimitStore, "memory")
+}
+
+// SelfServiceFlowRateLimit returns the rate limit configuration of the given flow (e.g. "login") and method
+// (e.g. "password"). Values configured for the method take precedence over the values configured for the flow.
+func (p *Config) SelfServiceFlowRateLimit(flow, method string) *SelfServiceFlowRateLimit {
+	key := fmt.Sprintf("selfservice.flows.%s.rate_limit", flow)
+	methodKey := fmt.Sprintf("%s.methods.%s", key, method)
+
+	rule := func(name string, limit int, window time.Duration) RateLimit {
+		limit = p.p.IntF(key+"."+name+".limit", limit)
+		window = p.p.DurationF(key+"."+name+".window", window)
+		return RateLimit{
+			Limit:  p.p.IntF(methodKey+"."+name+".limit", limit),
+			Window: p.p.DurationF(methodKey+"."+name+".window", window),
+		}
+	}
+
+	return &SelfServiceFlowRateLimit{
+		Enabled:       p.p.BoolF(methodKey+".enabled", p.p.Bool(key+".enabled")),
+		PerIP:         rule("per_ip", 30, time.Minute),
+		PerIdentifier: rule("per_identifier", 5, time.Minute),
+	}
+}
+
+// SelfServiceSecurityNotificationEnabled returns true if the security notification of the given type (e.g.
+// "password_changed") should be sent.
+func (p *Config) SelfServiceSecurityNotificationEnabled(notification string) bool {
+	return p.p.Bool(fmt.Sprintf("%s.%s.enabled", ViperKeySelfServiceSecurityNotifications, notification))
+}
+
 func (p *Config) SecretsDefault() [][]byte {
 	secrets := p.p.Strings(ViperKeySecretsDefault)
 
@@ -435,6 +589,31 @@ func (p *Config) SecretsSession() [][]byte {
 	return result
 }
 
+// SecretsCipher returns the keys used to encrypt secrets at rest. If no cipher secrets are configured, the keys
+// are derived from the default secrets.
+func (p *Config) SecretsCipher() [][32]byte {
+	secrets := p.p.Strings(ViperKeySecretsCipher)
+	if len(secrets) == 0 {
+		if len(p.p.Strings(ViperKeySecretsDefault)) == 0 {
+			p.l.Errorf("Neither %s nor %s are set, which is why secrets are encrypted using a random key which is lost when ORY Kratos restarts. Encrypted data can then no longer be decrypted. Please set one of these configuration keys!", ViperKeySecretsCipher, ViperKeySecretsDefault)
+		}
+
+		defaults := p.SecretsDefault()
+		result := make([][32]byte, len(defaults))
+		for k, v := range defaults {
+			result[k] = sha512.Sum512_256(v)
+		}
+		return result
+	}
+
+	result := make([][32]byte, len(secrets))
+	for k, v := range secrets {
+		copy(result[k][:], v)
+	}
+
+	return result
+}
+
 func (p *Config) SelfServiceBrowserDefaultReturnTo() *url.URL {
 	return p.parseURIOrFail(ViperKeySelfServiceBrowserDefaultReturnTo)
 }
@@ -554,6 +733,36 @@ func (p *Config) SessionLifespan() time.Duration {
 	return p.p.DurationF(ViperKeySessionLifespan, time.Hour*24)
 }
 
+func (p *Config) AuditLogEnabled() bool {
+	return p.p.BoolF(ViperKeyAuditLogEnabled, true)
+}
+
+// AuditLogSinkURL returns nil if no sink is configured.
+func (p *Config) AuditLogSinkURL() *url.URL {
+	return p.p.RequestURIF(ViperKeyAuditLogSinkURL, nil)
+}
+
+func (p *Config) EventStreamEnabled() bool {
+	return p.p.Bool(ViperKeyEventStreamEnabled)
+}
+
+func (p *Config) EventStreamSinkType() string {
+	return p.p.StringF(ViperKeyEventStreamSinkType, "outbox")
+}
+
+// EventStreamSinkURL returns nil if no URL is configured.
+func (p *Config) EventStreamSinkURL() *url.URL {
+	return p.p.RequestURIF(ViperKeyEventStreamSinkURL, nil)
+}
+
+func (p *Config) EventStreamRelayInterval() time.Duration {
+	return p.p.DurationF(ViperKeyEventStreamRelayInterval, time.Second)
+}
+
+func (p *Config) EventStreamRelayMaxAttempts() int {
+	return p.p.IntF(ViperKeyEventStreamRelayMaxAttempts, 10)
+}
+
 func (p *Config) SessionPersistentCookie() bool {
 	return p.p.Bool(ViperKeySessionPersistentCookie)
 }
@@ -675,6 +884,12 @@ func (p *Config) SelfServiceFlowSettingsPrivilegedSessionMaxAge() time.Duration
 	return p.p.DurationF(ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, time.Hour)
 }
 
+// SelfServiceDeletionGracePeriod returns how long identities which deleted themselves are kept before they are
+// purged. Identities are deleted immediately if it is zero.
+func (p *Config) SelfServiceDeletionGracePeriod() time.Duration {
+	return p.p.Duration(ViperKeyDeletionGracePeriod)
+}
+
 func (p *Config) SessionSameSiteMode() http.SameSite {
 	switch p.p.StringF(ViperKeySessionSameSite, "Lax") {
 	case "Lax":
@@ -719,7 +934,29 @@ func (p *Config) ConfigVersion() string {
 
 func (p *Config) PasswordPolicyConfig() *PasswordPolicy {
 	return &PasswordPolicy{
-		MaxBreaches:         uint(p.p.Int(ViperKeyPasswordMaxBreaches)),
-		IgnoreNetworkErrors: p.p.BoolF(ViperKeyIgnoreNetworkErrors, true),
+		MaxBreaches:                      uint(p.p.Int(ViperKeyPasswordMaxBreaches)),
+		IgnoreNetworkErrors:              p.p.BoolF(ViperKeyIgnoreNetworkErrors, true),
+		HaveIBeenPwnedURL:                p.p.URIF(ViperKeyPasswordHaveIBeenPwnedURL, urlx.ParseOrPanic("https://api.pwnedpasswords.com/range")),
+		HaveIBeenPwnedDataset:            p.p.String(ViperKeyPasswordHaveIBeenPwnedDataset),
+		MinLength:                        p.p.IntF(ViperKeyPasswordMinLength, 6),
+		MaxLength:                        p.p.Int(ViperKeyPasswordMaxLength),
+		RequiredCharacterClasses:         p.p.Strings(ViperKeyPasswordRequiredCharacterClasses),
+		MinStrengthScore:                 p.p.Int(ViperKeyPasswordMinStrengthScore),
+		DenyList:                         p.p.Strings(ViperKeyPasswordDenyList),
+		IdentifierSimilarityCheckEnabled: p.p.BoolF(ViperKeyPasswordIdentifierSimilarityCheckEnabled, true),
+		MinIdentifierDistance:            p.p.IntF(ViperKeyPasswordMinIdentifierDistance, 5),
+		MaxIdentifierSubstringRatio:      p.p.Float64F(ViperKeyPasswordMaxIdentifierSubstringRatio, 0.5),
+		HistorySize:                      p.p.Int(ViperKeyPasswordHistorySize),
+		MaxAge:                           p.p.Duration(ViperKeyPasswordMaxAge),
+	}
+}
+
+func (p *Config) PasswordLockoutConfig() *PasswordLockout {
+	return &PasswordLockout{
+		Enabled:          p.p.Bool(ViperKeyPasswordLockoutEnabled),
+		MaxAttempts:      p.p.IntF(ViperKeyPasswordLockoutMaxAttempts, 5),
+		MaxAttemptsPerIP: p.p.IntF(ViperKeyPasswordLockoutMaxAttemptsPerIP, 0),
+		Duration:         p.p.DurationF(ViperKeyPasswordLockoutDuration, time.Minute*15),
+		BaseDelay:        p.p.DurationF(ViperKeyPasswordLockoutBaseDelay, time.Second),
 	}
 }
@@ -142,7 +142,7 @@ func TestViperProvider(t *testing.T) {
 				config  string
 				enabled bool
 			}{
-				{id: "password", enabled: true, config: `{"ignore_network_errors":true,"max_breaches":0}`},
+				{id: "password", enabled: true, config: `{"haveibeenpwned_url":"https://api.pwnedpasswords.com/range","ignore_network_errors":true,"max_breaches":0,"min_password_length":6,"max_password_length":0,"required_character_classes":[],"min_strength_score":0,"deny_list":[],"identifier_similarity_check_enabled":true,"min_identifier_distance":5,"max_identifier_substring_ratio":0.5,"history_size":0,"max_age":"0s","lockout":{"base_delay":"1s","duration":"15m","enabled":false,"max_attempts":5,"max_attempts_per_ip":0}}`},
 				{id: "oidc", enabled: true, config: `{"providers":[{"client_id":"a","client_secret":"b","id":"github","provider":"github","mapper_url":"http://test.kratos.ory.sh/default-identity.schema.json"}]}`},
 			} {
 				strategy := p.SelfServiceStrategy(tc.id)
@@ -352,6 +352,14 @@ func TestViperProvider_Secrets(t *testing.T) {
 	assert.NotEmpty(t, def)
 	assert.Equal(t, def, p.SecretsSession())
 	assert.Equal(t, def, p.SecretsDefault())
+
+	cipher := p.SecretsCipher()
+	require.Len(t, cipher, len(def))
+	assert.NotEqual(t, def[0], cipher[0][:])
+	assert.Equal(t, cipher, p.SecretsCipher())
+
+	p.MustSet(ViperKeySecretsCipher, []string{"secret-thirty-two-character-long"})
+	assert.Equal(t, "secret-thirty-two-character-long", string(p.SecretsCipher()[0][:]))
 }
 
 func TestViperProvider_Defaults(t *testing.T) {
@@ -457,6 +465,156 @@ func TestViperProvider_ReturnTo(t *testing.T) {
 	assert.Equal(t, "https://www.ory.sh/verification", p.SelfServiceFlowVerificationReturnTo(urlx.ParseOrPanic("https://www.ory.sh/")).String())
 }
 
+func TestViperProvider_PasswordLockout(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+
+	c := p.PasswordLockoutConfig()
+	assert.False(t, c.Enabled)
+	assert.Equal(t, 5, c.MaxAttempts)
+	assert.Equal(t, 0, c.MaxAttemptsPerIP)
+	assert.Equal(t, 15*time.Minute, c.Duration)
+	assert.Equal(t, time.Second, c.BaseDelay)
+
+	p.MustSet(ViperKeyPasswordLockoutEnabled, true)
+	p.MustSet(ViperKeyPasswordLockoutMaxAttempts, 3)
+	p.MustSet(ViperKeyPasswordLockoutMaxAttemptsPerIP, 50)
+	p.MustSet(ViperKeyPasswordLockoutDuration, "1h")
+	p.MustSet(ViperKeyPasswordLockoutBaseDelay, "0s")
+
+	c = p.PasswordLockoutConfig()
+	assert.True(t, c.Enabled)
+	assert.Equal(t, 3, c.MaxAttempts)
+	assert.Equal(t, 50, c.MaxAttemptsPerIP)
+	assert.Equal(t, time.Hour, c.Duration)
+	assert.Equal(t, time.Duration(0), c.BaseDelay)
+}
+
+func TestViperProvider_PublicTrustedProxies(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+	assert.Empty(t, p.PublicTrustedProxies())
+
+	p.MustSet(ViperKeyPublicTrustedProxies, []string{"10.0.0.0/8", "192.168.1.1", "::1", "not-an-ip"})
+	var actual []string
+	for _, n := range p.PublicTrustedProxies() {
+		actual = append(actual, n.String())
+	}
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1/32", "::1/128"}, actual)
+}
+
+func TestViperProvider_Captcha(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+
+	c := p.SelfServiceCaptcha()
+	assert.Equal(t, "siteverify", c.Provider)
+	assert.JSONEq(t, "{}", string(c.Config))
+	assert.Equal(t, time.Hour, c.FailureWindow)
+
+	f := p.SelfServiceFlowCaptcha("login")
+	assert.False(t, f.Enabled)
+	assert.Equal(t, 0, f.AfterFailures)
+	assert.True(t, f.ChallengeRiskyRequests)
+
+	p.MustSet(ViperKeySelfServiceCaptchaConfig, map[string]interface{}{"verify_url": "https://www.ory.sh/siteverify", "site_key": "foo", "secret": "bar"})
+	p.MustSet(ViperKeySelfServiceCaptchaFailureWindow, "10m")
+	p.MustSet("selfservice.flows.login.captcha.enabled", true)
+	p.MustSet("selfservice.flows.login.captcha.after_failures", 3)
+	p.MustSet("selfservice.flows.login.captcha.challenge_risky_requests", false)
+
+	c = p.SelfServiceCaptcha()
+	assert.JSONEq(t, `{"verify_url": "https://www.ory.sh/siteverify", "site_key": "foo", "secret": "bar"}`, string(c.Config))
+	assert.Equal(t, 10*time.Minute, c.FailureWindow)
+
+	f = p.SelfServiceFlowCaptcha("login")
+	assert.True(t, f.Enabled)
+	assert.Equal(t, 3, f.AfterFailures)
+	assert.False(t, f.ChallengeRiskyRequests)
+	assert.False(t, p.SelfServiceFlowCaptcha("registration").Enabled)
+}
+
+func TestViperProvider_RateLimit(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+
+	assert.Equal(t, "memory", p.SelfServiceRateLimitStore())
+
+	c := p.SelfServiceFlowRateLimit("login", "password")
+	assert.False(t, c.Enabled)
+	assert.Equal(t, RateLimit{Limit: 30, Window: time.Minute}, c.PerIP)
+	assert.Equal(t, RateLimit{Limit: 5, Window: time.Minute}, c.PerIdentifier)
+
+	p.MustSet(ViperKeySelfServiceRateLimitStore, "sql")
+	p.MustSet("selfservice.flows.login.rate_limit.enabled", true)
+	p.MustSet("selfservice.flows.login.rate_limit.per_ip.limit", 100)
+	p.MustSet("selfservice.flows.login.rate_limit.per_ip.window", "1h")
+	p.MustSet("selfservice.flows.login.rate_limit.methods.password.per_ip.limit", 10)
+	p.MustSet("selfservice.flows.login.rate_limit.methods.ldap.enabled", false)
+
+	assert.Equal(t, "sql", p.SelfServiceRateLimitStore())
+
+	c = p.SelfServiceFlowRateLimit("login", "password")
+	assert.True(t, c.Enabled)
+	assert.Equal(t, RateLimit{Limit: 10, Window: time.Hour}, c.PerIP)
+	assert.Equal(t, RateLimit{Limit: 5, Window: time.Minute}, c.PerIdentifier)
+
+	c = p.SelfServiceFlowRateLimit("login", "ldap")
+	assert.False(t, c.Enabled)
+	assert.Equal(t, RateLimit{Limit: 100, Window: time.Hour}, c.PerIP)
+
+	assert.False(t, p.SelfServiceFlowRateLimit("registration", "password").Enabled)
+}
+
+func TestViperProvider_AuditLog(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+
+	assert.True(t, p.AuditLogEnabled())
+	assert.Nil(t, p.AuditLogSinkURL())
+
+	p.MustSet(ViperKeyAuditLogEnabled, false)
+	p.MustSet(ViperKeyAuditLogSinkURL, "https://www.ory.sh/audit")
+
+	assert.False(t, p.AuditLogEnabled())
+	assert.Equal(t, "https://www.ory.sh/audit", p.AuditLogSinkURL().String())
+}
+
+func TestViperProvider_EventStream(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+
+	assert.False(t, p.EventStreamEnabled())
+	assert.Equal(t, "outbox", p.EventStreamSinkType())
+	assert.Nil(t, p.EventStreamSinkURL())
+	assert.Equal(t, time.Second, p.EventStreamRelayInterval())
+
+	p.MustSet(ViperKeyEventStreamEnabled, true)
+	p.MustSet(ViperKeyEventStreamSinkType, "http")
+	p.MustSet(ViperKeyEventStreamSinkURL, "https://www.ory.sh/events")
+	p.MustSet(ViperKeyEventStreamRelayInterval, "10s")
+
+	assert.True(t, p.EventStreamEnabled())
+	assert.Equal(t, "http", p.EventStreamSinkType())
+	assert.Equal(t, "https://www.ory.sh/events", p.EventStreamSinkURL().String())
+	assert.Equal(t, 10*time.Second, p.EventStreamRelayInterval())
+}
+
+func TestViperProvider_SecurityNotifications(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+
+	for _, n := range []string{"password_changed", "oidc_linked", "recovery_completed", "login_new_device"} {
+		assert.False(t, p.SelfServiceSecurityNotificationEnabled(n), n)
+	}
+
+	p.MustSet(ViperKeySelfServiceSecurityNotifications+".password_changed.enabled", true)
+	assert.True(t, p.SelfServiceSecurityNotificationEnabled("password_changed"))
+	assert.False(t, p.SelfServiceSecurityNotificationEnabled("login_new_device"))
+}
+
+func TestViperProvider_DeletionGracePeriod(t *testing.T) {
+	p := MustNew(logrusx.New("", ""), configx.SkipValidation())
+	assert.Equal(t, time.Duration(0), p.SelfServiceDeletionGracePeriod())
+	assert.False(t, p.SelfServiceStrategy("deletion").Enabled)
+
+	p.MustSet(ViperKeyDeletionGracePeriod, "720h")
+	assert.Equal(t, 720*time.Hour, p.SelfServiceDeletionGracePeriod())
+}
+
 func TestViperProvider_DSN(t *testing.T) {
 	t.Run("case=dsn: memory", func(t *testing.T) {
 		p := MustNew(logrusx.New("", ""), configx.SkipValidation())
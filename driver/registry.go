@@ -11,6 +11,8 @@ import (
 
 	"github.com/ory/x/logrusx"
 
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/hash"
@@ -36,6 +38,7 @@ import (
 	"github.com/ory/kratos/selfservice/errorx"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 )
 
 type Registry interface {
@@ -78,16 +81,28 @@ type Registry interface {
 	errorx.PersistenceProvider
 
 	hash.HashProvider
+	cipher.Provider
 
 	identity.HandlerProvider
 	identity.ValidationProvider
 	identity.PoolProvider
 	identity.PrivilegedPoolProvider
 	identity.ManagementProvider
+	identity.PurgerProvider
+	identity.ExporterProvider
+	identity.ExportedSessionPersistenceProvider
 	identity.ActiveCredentialsCounterStrategyProvider
 
 	schema.HandlerProvider
 
+	audit.HandlerProvider
+	audit.RecorderProvider
+	audit.EventPersistenceProvider
+
+	stream.OutboxPersistenceProvider
+	stream.SinkProvider
+	stream.RelayProvider
+
 	password2.ValidationProvider
 
 	session.HandlerProvider
@@ -128,6 +143,7 @@ type Registry interface {
 	recovery.ErrorHandlerProvider
 	recovery.HandlerProvider
 	recovery.StrategyProvider
+	recovery.HookExecutorProvider
 
 	x.CSRFTokenGeneratorProvider
 }
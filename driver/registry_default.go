@@ -16,13 +16,19 @@ import (
 
 	"github.com/gobuffalo/pop/v5"
 
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/selfservice/ratelimit"
+	"github.com/ory/kratos/selfservice/strategy/deletion"
+	"github.com/ory/kratos/selfservice/strategy/ldap"
 	"github.com/ory/kratos/selfservice/strategy/link"
 	"github.com/ory/kratos/selfservice/strategy/profile"
 	"github.com/ory/kratos/x"
@@ -33,6 +39,7 @@ import (
 
 	"github.com/ory/x/dbal"
 	"github.com/ory/x/healthx"
+	"github.com/ory/x/httpx"
 	"github.com/ory/x/sqlcon"
 
 	"github.com/ory/x/tracing"
@@ -46,6 +53,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/logout"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/saml"
 
 	"github.com/ory/herodot"
 
@@ -54,6 +62,7 @@ import (
 	"github.com/ory/kratos/selfservice/errorx"
 	password2 "github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 )
 
 type RegistryDefault struct {
@@ -75,21 +84,32 @@ type RegistryDefault struct {
 	hookVerifier         *hook.Verifier
 	hookSessionIssuer    *hook.SessionIssuer
 	hookSessionDestroyer *hook.SessionDestroyer
+	hookSecurityNotifier *hook.SecurityNotifier
 
 	identityHandler   *identity.Handler
 	identityValidator *identity.Validator
 	identityManager   *identity.Manager
+	identityPurger    *identity.Purger
+	identityExporter  *identity.Exporter
 
 	continuityManager continuity.Manager
 
 	schemaHandler *schema.Handler
 
+	auditHandler  *audit.Handler
+	auditRecorder *audit.Recorder
+
+	eventStreamRelay  *stream.Relay
+	eventStreamClient *http.Client
+
 	sessionHandler *session.Handler
 	sessionManager session.Manager
 
 	passwordHasher    hash.Hasher
 	passwordValidator password2.Validator
 
+	cipher cipher.Cipher
+
 	errorHandler *errorx.Handler
 	errorManager *errorx.Manager
 
@@ -114,9 +134,14 @@ type RegistryDefault struct {
 
 	selfserviceRecoveryErrorHandler *recovery.ErrorHandler
 	selfserviceRecoveryHandler      *recovery.Handler
+	selfserviceRecoveryExecutor     *recovery.HookExecutor
 
 	selfserviceLogoutHandler *logout.Handler
 
+	selfserviceCaptchaManager *captcha.Manager
+
+	selfserviceRateLimiter *ratelimit.Limiter
+
 	selfserviceStrategies []interface{}
 
 	buildVersion string
@@ -154,10 +179,12 @@ func (m *RegistryDefault) RegisterPublicRoutes(ctx context.Context, router *x.Ro
 func (m *RegistryDefault) RegisterAdminRoutes(ctx context.Context, router *x.RouterAdmin) {
 	m.RegistrationHandler().RegisterAdminRoutes(router)
 	m.LoginHandler().RegisterAdminRoutes(router)
+	m.AllLoginStrategies().RegisterAdminRoutes(router)
 	m.SchemaHandler().RegisterAdminRoutes(router)
 	m.SettingsHandler().RegisterAdminRoutes(router)
 	m.IdentityHandler().RegisterAdminRoutes(router)
 	m.SessionHandler().RegisterAdminRoutes(router)
+	m.AuditHandler().RegisterAdminRoutes(router)
 	m.SelfServiceErrorHandler().RegisterAdminRoutes(router)
 
 	m.RecoveryHandler().RegisterAdminRoutes(router)
@@ -247,7 +274,10 @@ func (m *RegistryDefault) selfServiceStrategies() []interface{} {
 		m.selfserviceStrategies = []interface{}{
 			password2.NewStrategy(m),
 			oidc.NewStrategy(m),
+			saml.NewStrategy(m),
+			ldap.NewStrategy(m),
 			profile.NewStrategy(m),
+			deletion.NewStrategy(m),
 			link.NewStrategy(m),
 		}
 	}
@@ -341,6 +371,51 @@ func (m *RegistryDefault) IdentityHandler() *identity.Handler {
 	return m.identityHandler
 }
 
+func (m *RegistryDefault) AuditHandler() *audit.Handler {
+	if m.auditHandler == nil {
+		m.auditHandler = audit.NewHandler(m)
+	}
+	return m.auditHandler
+}
+
+func (m *RegistryDefault) AuditRecorder() *audit.Recorder {
+	if m.auditRecorder == nil {
+		m.auditRecorder = audit.NewRecorder(m, httpx.NewResilientClientLatencyToleranceMedium(nil))
+	}
+	return m.auditRecorder
+}
+
+func (m *RegistryDefault) AuditEventPersister() audit.EventPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) ExportedSessionPersister() identity.ExportedSessionPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) StreamOutboxPersister() stream.OutboxPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) EventStreamSink(ctx context.Context) stream.Sink {
+	c := m.Config(ctx)
+	if c.EventStreamSinkType() != stream.SinkTypeHTTP || c.EventStreamSinkURL() == nil {
+		return nil
+	}
+
+	if m.eventStreamClient == nil {
+		m.eventStreamClient = httpx.NewResilientClientLatencyToleranceMedium(nil)
+	}
+	return stream.NewHTTPSink(m.eventStreamClient, c.EventStreamSinkURL())
+}
+
+func (m *RegistryDefault) EventStreamRelay() *stream.Relay {
+	if m.eventStreamRelay == nil {
+		m.eventStreamRelay = stream.NewRelay(m)
+	}
+	return m.eventStreamRelay
+}
+
 func (m *RegistryDefault) SchemaHandler() *schema.Handler {
 	if m.schemaHandler == nil {
 		m.schemaHandler = schema.NewHandler(m)
@@ -362,6 +437,13 @@ func (m *RegistryDefault) Hasher() hash.Hasher {
 	return m.passwordHasher
 }
 
+func (m *RegistryDefault) Cipher() cipher.Cipher {
+	if m.cipher == nil {
+		m.cipher = cipher.NewCipherXChaCha20Poly1305(m)
+	}
+	return m.cipher
+}
+
 func (m *RegistryDefault) PasswordValidator() password2.Validator {
 	if m.passwordValidator == nil {
 		m.passwordValidator = password2.NewDefaultPasswordValidatorStrategy(m)
@@ -573,6 +655,42 @@ func (m *RegistryDefault) VerificationTokenPersister() link.VerificationTokenPer
 	return m.Persister()
 }
 
+func (m *RegistryDefault) LoginAttemptPersister() password2.LoginAttemptPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) CaptchaFailurePersister() captcha.FailurePersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) OIDCProviderPersister() oidc.ProviderPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) OIDCLinkNotifier() oidc.LinkNotifier {
+	return m.HookSecurityNotifier()
+}
+
+func (m *RegistryDefault) CaptchaManager() *captcha.Manager {
+	if m.selfserviceCaptchaManager == nil {
+		m.selfserviceCaptchaManager = captcha.NewManager(m, httpx.NewResilientClientLatencyToleranceMedium(nil))
+	}
+
+	return m.selfserviceCaptchaManager
+}
+
+func (m *RegistryDefault) RateLimitHitPersister() ratelimit.HitPersister {
+	return m.Persister()
+}
+
+func (m *RegistryDefault) RateLimiter() *ratelimit.Limiter {
+	if m.selfserviceRateLimiter == nil {
+		m.selfserviceRateLimiter = ratelimit.NewLimiter(m)
+	}
+
+	return m.selfserviceRateLimiter
+}
+
 func (m *RegistryDefault) Persister() persistence.Persister {
 	return m.persister
 }
@@ -599,6 +717,20 @@ func (m *RegistryDefault) IdentityManager() *identity.Manager {
 	return m.identityManager
 }
 
+func (m *RegistryDefault) IdentityPurger() *identity.Purger {
+	if m.identityPurger == nil {
+		m.identityPurger = identity.NewPurger(m)
+	}
+	return m.identityPurger
+}
+
+func (m *RegistryDefault) IdentityExporter() *identity.Exporter {
+	if m.identityExporter == nil {
+		m.identityExporter = identity.NewExporter(m)
+	}
+	return m.identityExporter
+}
+
 func (m *RegistryDefault) PrometheusManager() *prometheus.MetricsManager {
 	m.rwl.Lock()
 	defer m.rwl.Unlock()
@@ -26,6 +26,13 @@ func (m *RegistryDefault) HookSessionDestroyer() *hook.SessionDestroyer {
 	return m.hookSessionDestroyer
 }
 
+func (m *RegistryDefault) HookSecurityNotifier() *hook.SecurityNotifier {
+	if m.hookSecurityNotifier == nil {
+		m.hookSecurityNotifier = hook.NewSecurityNotifier(m)
+	}
+	return m.hookSecurityNotifier
+}
+
 func (m *RegistryDefault) WithHooks(hooks map[string]func(config.SelfServiceHook) interface{}) {
 	m.injectedSelfserviceHooks = hooks
 }
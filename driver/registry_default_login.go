@@ -3,6 +3,7 @@ package driver
 import (
 	"context"
 
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow/login"
 )
@@ -24,6 +25,20 @@ func (m *RegistryDefault) PreLoginHooks(ctx context.Context) (b []login.PreHookE
 }
 
 func (m *RegistryDefault) PostLoginHooks(ctx context.Context, credentialsType identity.CredentialsType) (b []login.PostHookExecutor) {
+	// The OpenID Connect strategy links a provider once the user signed in to the identity owning the
+	// provider's email address. That sign-in uses the identity's existing credentials, not OpenID Connect.
+	if credentialsType != identity.CredentialsTypeOIDC {
+		if strategy, err := m.LoginStrategies(ctx).Strategy(identity.CredentialsTypeOIDC); err == nil {
+			if hook, ok := strategy.(login.PostHookExecutor); ok {
+				b = append(b, hook)
+			}
+		}
+	}
+
+	if m.Config(ctx).SelfServiceSecurityNotificationEnabled(string(template.SecurityNotificationLoginNewDevice)) {
+		b = append(b, m.HookSecurityNotifier())
+	}
+
 	for _, v := range m.getHooks(string(credentialsType), m.Config(ctx).SelfServiceFlowLoginAfterHooks(string(credentialsType))) {
 		if hook, ok := v.(login.PostHookExecutor); ok {
 			b = append(b, hook)
@@ -3,6 +3,7 @@ package driver
 import (
 	"context"
 
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 )
 
@@ -22,6 +23,21 @@ func (m *RegistryDefault) RecoveryHandler() *recovery.Handler {
 	return m.selfserviceRecoveryHandler
 }
 
+func (m *RegistryDefault) RecoveryExecutor() *recovery.HookExecutor {
+	if m.selfserviceRecoveryExecutor == nil {
+		m.selfserviceRecoveryExecutor = recovery.NewHookExecutor(m)
+	}
+
+	return m.selfserviceRecoveryExecutor
+}
+
+func (m *RegistryDefault) PostRecoveryHooks(ctx context.Context) (b []recovery.PostHookExecutor) {
+	if m.Config(ctx).SelfServiceSecurityNotificationEnabled(string(template.SecurityNotificationRecoveryCompleted)) {
+		b = append(b, m.HookSecurityNotifier())
+	}
+	return
+}
+
 func (m *RegistryDefault) RecoveryStrategies(ctx context.Context) (recoveryStrategies recovery.Strategies) {
 	for _, strategy := range m.selfServiceStrategies() {
 		if s, ok := strategy.(recovery.Strategy); ok {
@@ -3,6 +3,7 @@ package driver
 import (
 	"context"
 
+	"github.com/ory/kratos/courier/template"
 	"github.com/ory/kratos/selfservice/flow/settings"
 )
 
@@ -20,6 +21,11 @@ func (m *RegistryDefault) PostSettingsPostPersistHooks(ctx context.Context, sett
 		b = append(b, m.HookVerifier())
 	}
 
+	if m.Config(ctx).SelfServiceSecurityNotificationEnabled(string(template.SecurityNotificationPasswordChanged)) ||
+		m.Config(ctx).SelfServiceSecurityNotificationEnabled(string(template.SecurityNotificationOIDCLinked)) {
+		b = append(b, m.HookSecurityNotifier())
+	}
+
 	for _, v := range m.getHooks(settingsType, m.Config(ctx).SelfServiceFlowSettingsAfterHooks(settingsType)) {
 		if hook, ok := v.(settings.PostHookPostPersistExecutor); ok {
 			b = append(b, hook)
@@ -28,6 +34,15 @@ func (m *RegistryDefault) PostSettingsPostPersistHooks(ctx context.Context, sett
 	return
 }
 
+func (m *RegistryDefault) PostSettingsDeletionHooks(ctx context.Context) (b []settings.PostHookDeletionExecutor) {
+	for _, v := range m.getHooks(settings.StrategyDeletion, m.Config(ctx).SelfServiceFlowSettingsAfterHooks(settings.StrategyDeletion)) {
+		if hook, ok := v.(settings.PostHookDeletionExecutor); ok {
+			b = append(b, hook)
+		}
+	}
+	return
+}
+
 func (m *RegistryDefault) SettingsHookExecutor() *settings.HookExecutor {
 	if m.selfserviceSettingsExecutor == nil {
 		m.selfserviceSettingsExecutor = settings.NewHookExecutor(m)
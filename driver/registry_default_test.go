@@ -61,6 +61,19 @@ func TestDriverDefault_Hooks(t *testing.T) {
 			assert.Equal(t, []settings.PostHookPostPersistExecutor{hook.NewVerifier(reg)}, h)
 		})
 	})
+
+	t.Run("case=oidc link", func(t *testing.T) {
+		conf, reg := internal.NewFastRegistryWithMocks(t)
+
+		require.Len(t, reg.PostLoginHooks(ctx, identity.CredentialsTypePassword), 0)
+
+		conf.MustSet(config.ViperKeySelfServiceStrategyConfig+".oidc.enabled", true)
+		h := reg.PostLoginHooks(ctx, identity.CredentialsTypePassword)
+		require.Len(t, h, 1)
+		assert.Equal(t, []login.PostHookExecutor{reg.AllLoginStrategies().MustStrategy(identity.CredentialsTypeOIDC).(login.PostHookExecutor)}, h)
+
+		assert.Len(t, reg.PostLoginHooks(ctx, identity.CredentialsTypeOIDC), 0)
+	})
 }
 
 func TestDriverDefault_Strategies(t *testing.T) {
@@ -196,7 +209,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	_, reg := internal.NewFastRegistryWithMocks(t)
 
 	t.Run("case=all login strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc"}
+		expects := []string{"password", "oidc", "saml", "ldap"}
 		s := reg.AllLoginStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -205,7 +218,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all registration strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc"}
+		expects := []string{"password", "oidc", "saml"}
 		s := reg.AllRegistrationStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
@@ -214,7 +227,7 @@ func TestDefaultRegistry_AllStrategies(t *testing.T) {
 	})
 
 	t.Run("case=all settings strategies", func(t *testing.T) {
-		expects := []string{"password", "oidc", "profile"}
+		expects := []string{"password", "oidc", "profile", "deletion"}
 		s := reg.AllSettingsStrategies()
 		require.Len(t, s, len(expects))
 		for k, e := range expects {
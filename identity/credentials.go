@@ -24,6 +24,8 @@ const (
 	// make sure to add all of these values to the test that ensures they are created during migration
 	CredentialsTypePassword CredentialsType = "password"
 	CredentialsTypeOIDC     CredentialsType = "oidc"
+	CredentialsTypeSAML     CredentialsType = "saml"
+	CredentialsTypeLDAP     CredentialsType = "ldap"
 )
 
 type (
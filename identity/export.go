@@ -0,0 +1,181 @@
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/audit"
+)
+
+// exportAuditEventsLimit is the number of recent audit events included in an export.
+const exportAuditEventsLimit = 100
+
+type (
+	// Export contains all data stored about an identity, except for its credentials.
+	//
+	// swagger:model identityExport
+	Export struct {
+		// Identity is the exported identity without its credentials.
+		//
+		// required: true
+		Identity *Identity `json:"identity"`
+
+		// OIDCProviders contains the OpenID Connect providers the identity is linked with.
+		//
+		// required: true
+		OIDCProviders []ExportedOIDCProvider `json:"oidc_providers"`
+
+		// Sessions contains the identity's sessions, newest first.
+		//
+		// required: true
+		Sessions []ExportedSession `json:"sessions"`
+
+		// AuditEvents contains the identity's most recent audit events, newest first.
+		//
+		// required: true
+		AuditEvents []audit.Event `json:"audit_events"`
+
+		// ExportedAt is the time the export was created at.
+		//
+		// required: true
+		ExportedAt time.Time `json:"exported_at"`
+	}
+
+	// ExportedOIDCProvider is an OpenID Connect provider an identity is linked with.
+	//
+	// swagger:model identityExportedOIDCProvider
+	ExportedOIDCProvider struct {
+		// required: true
+		Provider string `json:"provider"`
+
+		// required: true
+		Subject string `json:"subject"`
+	}
+
+	// ExportedSession is a session of an identity and the device it was issued to.
+	//
+	// swagger:model identityExportedSession
+	ExportedSession struct {
+		// required: true
+		ID uuid.UUID `json:"id"`
+
+		// required: true
+		Active bool `json:"active"`
+
+		// required: true
+		IssuedAt time.Time `json:"issued_at"`
+
+		// required: true
+		ExpiresAt time.Time `json:"expires_at"`
+
+		// required: true
+		AuthenticatedAt time.Time `json:"authenticated_at"`
+
+		// IPAddress is the IP address of the client which the session was issued to.
+		IPAddress string `json:"ip_address"`
+
+		// UserAgent is the user agent of the client which the session was issued to.
+		UserAgent string `json:"user_agent"`
+	}
+
+	// ExportedSessionPersister lists an identity's sessions for exports. It is implemented by the session
+	// persistence layer, which depends on this package.
+	ExportedSessionPersister interface {
+		// ListExportedSessions returns the identity's sessions, newest first.
+		ListExportedSessions(ctx context.Context, identityID uuid.UUID) ([]ExportedSession, error)
+	}
+
+	ExportedSessionPersistenceProvider interface {
+		ExportedSessionPersister() ExportedSessionPersister
+	}
+
+	exporterDependencies interface {
+		PrivilegedPoolProvider
+		ExportedSessionPersistenceProvider
+		audit.EventPersistenceProvider
+	}
+
+	ExporterProvider interface {
+		IdentityExporter() *Exporter
+	}
+
+	// Exporter collects all data stored about an identity.
+	Exporter struct {
+		d exporterDependencies
+	}
+)
+
+func NewExporter(d exporterDependencies) *Exporter {
+	return &Exporter{d: d}
+}
+
+// Export returns all data stored about the identity. Credentials are removed from the identity, only the
+// OpenID Connect providers and subjects it is linked with are exported.
+func (e *Exporter) Export(ctx context.Context, id uuid.UUID) (*Export, error) {
+	i, err := e.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := e.d.ExportedSessionPersister().ListExportedSessions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := e.d.AuditEventPersister().ListAuditEvents(ctx, audit.Filter{IdentityID: id}, 0, exportAuditEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessions == nil {
+		sessions = []ExportedSession{}
+	}
+
+	if events == nil {
+		events = []audit.Event{}
+	}
+
+	return &Export{
+		Identity:      i.CopyWithoutCredentials(),
+		OIDCProviders: exportOIDCProviders(i),
+		Sessions:      sessions,
+		AuditEvents:   events,
+		ExportedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// Declassify returns a copy of the export which may be shown to the identity itself. Admin metadata is removed
+// and the client details and errors of events caused by admins are blanked.
+func (e *Export) Declassify() *Export {
+	ee := *e
+	ee.Identity = e.Identity.Declassify()
+	ee.AuditEvents = make([]audit.Event, len(e.AuditEvents))
+	for k, ev := range e.AuditEvents {
+		if ev.Actor == audit.ActorAdmin {
+			ev.IPAddress, ev.UserAgent, ev.Error = "", "", ""
+		}
+		ee.AuditEvents[k] = ev
+	}
+	return &ee
+}
+
+func exportOIDCProviders(i *Identity) []ExportedOIDCProvider {
+	providers := []ExportedOIDCProvider{}
+
+	c, ok := i.GetCredentials(CredentialsTypeOIDC)
+	if !ok {
+		return providers
+	}
+
+	for _, p := range gjson.GetBytes(c.Config, "providers").Array() {
+		providers = append(providers, ExportedOIDCProvider{
+			Provider: p.Get("provider").String(),
+			Subject:  p.Get("subject").String(),
+		})
+	}
+
+	return providers
+}
@@ -1,7 +1,9 @@
 package identity
 
 import (
+	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/ory/kratos/driver/config"
@@ -12,6 +14,7 @@ import (
 	"github.com/ory/x/jsonx"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/x"
 )
 
@@ -24,6 +27,8 @@ type (
 		ManagementProvider
 		x.WriterProvider
 		config.Provider
+		audit.RecorderProvider
+		ExporterProvider
 	}
 	HandlerProvider interface {
 		IdentityHandler() *Handler
@@ -40,10 +45,12 @@ func NewHandler(r handlerDependencies) *Handler {
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
 	admin.GET(RouteBase, h.list)
 	admin.GET(RouteBase+"/:id", h.get)
+	admin.GET(RouteBase+"/:id/export", h.export)
 	admin.DELETE(RouteBase+"/:id", h.delete)
 
 	admin.POST(RouteBase, h.create)
 	admin.PUT(RouteBase+"/:id", h.update)
+	admin.PATCH(RouteBase+"/:id", h.patch)
 }
 
 // A single identity.
@@ -162,6 +169,55 @@ func (h *Handler) get(w http.ResponseWriter, r *http.Request, ps httprouter.Para
 	h.r.Writer().Write(w, r, i)
 }
 
+// A personal data export of an identity.
+//
+// swagger:response identityExportResponse
+// nolint:deadcode,unused
+type identityExportResponse struct {
+	// required: true
+	// in: body
+	Body *Export
+}
+
+// swagger:parameters exportIdentity
+// nolint:deadcode,unused
+type exportIdentityParameters struct {
+	// ID must be set to the ID of identity you want to export
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route GET /identities/{id}/export admin exportIdentity
+//
+// Export an Identity's Personal Data
+//
+// Returns all data stored about the identity in one JSON document: its traits and addresses, the
+// OpenID Connect providers it is linked with, its sessions including the devices they were issued to,
+// and its recent audit events. Credentials are never exported.
+//
+// Identities can export their own data using the `/sessions/whoami/export` endpoint of the Public API.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: identityExportResponse
+//       404: genericError
+//       500: genericError
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	e, err := h.r.IdentityExporter().Export(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Writer().Write(w, r, e)
+}
+
 // swagger:parameters createIdentity
 // nolint:deadcode,unused
 type createIdentityParameters struct {
@@ -183,6 +239,21 @@ type CreateIdentity struct {
 	// required: true
 	// in: body
 	Traits json.RawMessage `json:"traits"`
+
+	// MetadataPublic contains arbitrary metadata which is visible to the identity itself.
+	//
+	// in: body
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+
+	// MetadataAdmin contains arbitrary metadata which is only visible using the admin API.
+	//
+	// in: body
+	MetadataAdmin json.RawMessage `json:"metadata_admin"`
+
+	// State is the identity's state. Defaults to `active` if omitted.
+	//
+	// in: body
+	State State `json:"state"`
 }
 
 // swagger:route POST /identities admin createIdentity
@@ -214,11 +285,19 @@ func (h *Handler) create(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
-	i := &Identity{SchemaID: cr.SchemaID, Traits: []byte(cr.Traits)}
+	i := &Identity{
+		SchemaID:       cr.SchemaID,
+		Traits:         []byte(cr.Traits),
+		MetadataPublic: []byte(cr.MetadataPublic),
+		MetadataAdmin:  []byte(cr.MetadataAdmin),
+		State:          cr.State,
+	}
 	if err := h.r.IdentityManager().Create(r.Context(), i); err != nil {
+		h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityCreated, i.ID, err))
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
+	h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityCreated, i.ID, nil))
 
 	h.r.Writer().WriteCreated(w, r,
 		urlx.AppendPaths(
@@ -253,6 +332,17 @@ type UpdateIdentity struct {
 	//
 	// required: true
 	Traits json.RawMessage `json:"traits"`
+
+	// MetadataPublic contains arbitrary metadata which is visible to the identity itself. If omitted,
+	// the existing public metadata will be removed.
+	MetadataPublic json.RawMessage `json:"metadata_public"`
+
+	// MetadataAdmin contains arbitrary metadata which is only visible using the admin API. If omitted,
+	// the existing admin metadata will be removed.
+	MetadataAdmin json.RawMessage `json:"metadata_admin"`
+
+	// State is the identity's state. If set will update the Identity's State.
+	State State `json:"state"`
 }
 
 // swagger:route PUT /identities/{id} admin updateIdentity
@@ -262,7 +352,8 @@ type UpdateIdentity struct {
 // This endpoint updates an identity. It is NOT possible to set an identity's credentials (password, ...)
 // using this method! A way to achieve that will be introduced in the future.
 //
-// The full identity payload (except credentials) is expected. This endpoint does not support patching.
+// The full identity payload (except credentials) is expected. To update only parts of an identity, use the
+// `PATCH /identities/{id}` endpoint instead.
 //
 // Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 //
@@ -298,14 +389,111 @@ func (h *Handler) update(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	}
 
 	identity.Traits = []byte(ur.Traits)
+	identity.MetadataPublic = []byte(ur.MetadataPublic)
+	identity.MetadataAdmin = []byte(ur.MetadataAdmin)
+	if ur.State != "" {
+		identity.State = ur.State
+	}
+	if err := h.r.IdentityManager().Update(
+		r.Context(),
+		identity,
+		ManagerAllowWriteProtectedTraits,
+	); err != nil {
+		h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, identity.ID, err))
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+	h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, identity.ID, nil))
+
+	h.r.Writer().Write(w, r, identity)
+}
+
+// swagger:parameters patchIdentity
+// nolint:deadcode,unused
+type patchIdentityParameters struct {
+	// ID must be set to the ID of identity you want to patch
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body x.JSONPatchDocument
+}
+
+// swagger:route PATCH /identities/{id} admin patchIdentity
+//
+// Patch an Identity
+//
+// This endpoint partially updates an identity using an [RFC 6902 JSON Patch](https://tools.ietf.org/html/rfc6902).
+// The patch may modify the `schema_id`, `state`, `traits`, `metadata_public`, `metadata_admin`,
+// `verifiable_addresses`, and `recovery_addresses` fields. The patched identity is validated against its JSON
+// Schema before it is persisted. It is NOT possible to set an identity's credentials (password, ...) using this
+// method!
+//
+// Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: identityResponse
+//       400: genericError
+//       404: genericError
+//       500: genericError
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(err))
+		return
+	}
+
+	identity, err := h.r.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	original, err := json.Marshal(identity)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, errors.WithStack(err))
+		return
+	}
+
+	patched, err := x.ApplyJSONPatch(requestBody, original, "/id", "/schema_url", "/state_changed_at", "/deletion_scheduled_at")
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var updated Identity
+	if err := jsonx.NewStrictDecoder(bytes.NewReader(patched)).Decode(&updated); err != nil {
+		h.r.Writer().WriteErrorCode(w, r, http.StatusBadRequest, errors.WithStack(err))
+		return
+	}
+
+	identity.SchemaID = updated.SchemaID
+	identity.Traits = updated.Traits
+	identity.VerifiableAddresses = updated.VerifiableAddresses
+	identity.RecoveryAddresses = updated.RecoveryAddresses
+	identity.MetadataPublic = updated.MetadataPublic
+	identity.MetadataAdmin = updated.MetadataAdmin
+	identity.State = updated.State
 	if err := h.r.IdentityManager().Update(
 		r.Context(),
 		identity,
 		ManagerAllowWriteProtectedTraits,
 	); err != nil {
+		h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, identity.ID, err))
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
+	h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, identity.ID, nil))
 
 	h.r.Writer().Write(w, r, identity)
 }
@@ -340,10 +528,13 @@ type deleteIdentityParameters struct {
 //		 404: genericError
 //       500: genericError
 func (h *Handler) delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	if err := h.r.IdentityPool().(PrivilegedPool).DeleteIdentity(r.Context(), x.ParseUUID(ps.ByName("id"))); err != nil {
+	id := x.ParseUUID(ps.ByName("id"))
+	if err := h.r.IdentityPool().(PrivilegedPool).DeleteIdentity(r.Context(), id); err != nil {
+		h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityDeleted, id, err))
 		h.r.Writer().WriteError(w, r, err)
 		return
 	}
+	h.r.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityDeleted, id, nil))
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -2,11 +2,13 @@ package identity_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ory/x/urlx"
 
@@ -17,9 +19,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -160,6 +164,20 @@ func TestHandler(t *testing.T) {
 			remove(t, "/identities/"+i.ID.String(), http.StatusNoContent)
 			_ = get(t, "/identities/"+i.ID.String(), http.StatusNotFound)
 		})
+
+		t.Run("case=should have recorded the changes in the audit log", func(t *testing.T) {
+			events, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{IdentityID: i.ID}, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 3)
+
+			var types []audit.EventType
+			for _, e := range events {
+				types = append(types, e.Type)
+				assert.Equal(t, audit.ActorAdmin, e.Actor)
+				assert.Equal(t, audit.OutcomeSuccess, e.Outcome)
+			}
+			assert.ElementsMatch(t, []audit.EventType{audit.EventTypeIdentityCreated, audit.EventTypeIdentityUpdated, audit.EventTypeIdentityDeleted}, types)
+		})
 	})
 
 	t.Run("case=should not be able to create an identity with an invalid schema", func(t *testing.T) {
@@ -204,6 +222,66 @@ func TestHandler(t *testing.T) {
 		assert.EqualValues(t, updatedEmail, res.Get("verifiable_addresses.0.value").String(), "%s", res.Raw)
 	})
 
+	t.Run("case=should create and update metadata", func(t *testing.T) {
+		var cr identity.CreateIdentity
+		cr.SchemaID = "employee"
+		cr.Traits = []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+		cr.MetadataPublic = []byte(`{"plan":"free"}`)
+		cr.MetadataAdmin = []byte(`{"crm_id":"foo"}`)
+		res := send(t, "POST", "/identities", http.StatusCreated, &cr)
+		assert.EqualValues(t, "free", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.EqualValues(t, "foo", res.Get("metadata_admin.crm_id").String(), "%s", res.Raw)
+
+		id := res.Get("id").String()
+		res = get(t, "/identities/"+id, http.StatusOK)
+		assert.EqualValues(t, "free", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.EqualValues(t, "foo", res.Get("metadata_admin.crm_id").String(), "%s", res.Raw)
+
+		res = send(t, "PUT", "/identities/"+id, http.StatusOK, &identity.UpdateIdentity{
+			Traits:         cr.Traits,
+			MetadataPublic: []byte(`{"plan":"premium"}`),
+		})
+		assert.EqualValues(t, "premium", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.False(t, res.Get("metadata_admin.crm_id").Exists(), "%s", res.Raw)
+
+		res = get(t, "/identities/"+id, http.StatusOK)
+		assert.EqualValues(t, "premium", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+		assert.False(t, res.Get("metadata_admin.crm_id").Exists(), "%s", res.Raw)
+	})
+
+	t.Run("case=should create and update the state", func(t *testing.T) {
+		var cr identity.CreateIdentity
+		cr.SchemaID = "employee"
+		cr.Traits = []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+		res := send(t, "POST", "/identities", http.StatusCreated, &cr)
+		assert.EqualValues(t, identity.StateActive, res.Get("state").String(), "%s", res.Raw)
+		assert.False(t, res.Get("state_changed_at").Exists(), "%s", res.Raw)
+
+		id := res.Get("id").String()
+		res = send(t, "PUT", "/identities/"+id, http.StatusOK, &identity.UpdateIdentity{
+			Traits: cr.Traits,
+			State:  identity.StateInactive,
+		})
+		assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+		assert.True(t, res.Get("state_changed_at").Exists(), "%s", res.Raw)
+
+		res = send(t, "PUT", "/identities/"+id, http.StatusOK, &identity.UpdateIdentity{
+			Traits: cr.Traits,
+		})
+		assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+
+		res = send(t, "PUT", "/identities/"+id, http.StatusBadRequest, &identity.UpdateIdentity{
+			Traits: cr.Traits,
+			State:  "deleted",
+		})
+		assert.Contains(t, res.Get("error.reason").String(), "deleted", "%s", res.Raw)
+
+		cr.Traits = []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+		cr.State = identity.StateInactive
+		res = send(t, "POST", "/identities", http.StatusCreated, &cr)
+		assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+	})
+
 	t.Run("case=should update the schema id and fail because traits are invalid", func(t *testing.T) {
 		var cr identity.CreateIdentity
 		cr.SchemaID = "employee"
@@ -232,6 +310,126 @@ func TestHandler(t *testing.T) {
 		assert.EqualValues(t, "ory street", res.Get("traits.address").String(), "%s", res.Raw)
 	})
 
+	t.Run("suite=patch", func(t *testing.T) {
+		var create = func(t *testing.T) string {
+			var cr identity.CreateIdentity
+			cr.SchemaID = "employee"
+			cr.Traits = []byte(`{"email":"` + x.NewUUID().String() + `@ory.sh", "department": "ory"}`)
+			return send(t, "POST", "/identities", http.StatusCreated, &cr).Get("id").String()
+		}
+
+		t.Run("case=should patch traits", func(t *testing.T) {
+			id := create(t)
+			res := send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "replace", "path": "/traits/department", "value": "kratos"}
+			]`))
+			assert.EqualValues(t, "kratos", res.Get("traits.department").String(), "%s", res.Raw)
+
+			res = get(t, "/identities/"+id, http.StatusOK)
+			assert.EqualValues(t, "kratos", res.Get("traits.department").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should patch the email and sync the addresses", func(t *testing.T) {
+			id := create(t)
+			updatedEmail := x.NewUUID().String() + "@ory.sh"
+			res := send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "replace", "path": "/traits/email", "value": "`+updatedEmail+`"}
+			]`))
+			assert.EqualValues(t, updatedEmail, res.Get("recovery_addresses.0.value").String(), "%s", res.Raw)
+			assert.EqualValues(t, updatedEmail, res.Get("verifiable_addresses.0.value").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should patch verifiable address status", func(t *testing.T) {
+			id := create(t)
+			res := send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "replace", "path": "/verifiable_addresses/0/verified", "value": true},
+				{"op": "replace", "path": "/verifiable_addresses/0/status", "value": "completed"}
+			]`))
+			assert.True(t, res.Get("verifiable_addresses.0.verified").Bool(), "%s", res.Raw)
+
+			res = get(t, "/identities/"+id, http.StatusOK)
+			assert.True(t, res.Get("verifiable_addresses.0.verified").Bool(), "%s", res.Raw)
+			assert.EqualValues(t, identity.VerifiableAddressStatusCompleted, res.Get("verifiable_addresses.0.status").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should patch the schema id and fail because traits are invalid", func(t *testing.T) {
+			id := create(t)
+			res := send(t, "PATCH", "/identities/"+id, http.StatusBadRequest, json.RawMessage(`[
+				{"op": "replace", "path": "/schema_id", "value": "customer"}
+			]`))
+			assert.Contains(t, res.Get("error.reason").String(), `additionalProperties "department" not allowed`, "%s", res.Raw)
+		})
+
+		t.Run("case=should patch the schema id and traits", func(t *testing.T) {
+			id := create(t)
+			res := send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "replace", "path": "/schema_id", "value": "customer"},
+				{"op": "remove", "path": "/traits/department"},
+				{"op": "add", "path": "/traits/address", "value": "ory street"}
+			]`))
+			assert.EqualValues(t, "customer", res.Get("schema_id").String(), "%s", res.Raw)
+			assert.EqualValues(t, "ory street", res.Get("traits.address").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should patch metadata", func(t *testing.T) {
+			id := create(t)
+			res := send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "add", "path": "/metadata_public", "value": {"plan": "premium"}},
+				{"op": "add", "path": "/metadata_admin", "value": {"crm_id": "foo"}}
+			]`))
+			assert.EqualValues(t, "premium", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+			assert.EqualValues(t, "foo", res.Get("metadata_admin.crm_id").String(), "%s", res.Raw)
+
+			res = send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "replace", "path": "/metadata_admin/crm_id", "value": "bar"}
+			]`))
+			assert.EqualValues(t, "premium", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+			assert.EqualValues(t, "bar", res.Get("metadata_admin.crm_id").String(), "%s", res.Raw)
+
+			res = get(t, "/identities/"+id, http.StatusOK)
+			assert.EqualValues(t, "premium", res.Get("metadata_public.plan").String(), "%s", res.Raw)
+			assert.EqualValues(t, "bar", res.Get("metadata_admin.crm_id").String(), "%s", res.Raw)
+		})
+
+		t.Run("case=should patch the state", func(t *testing.T) {
+			id := create(t)
+			res := send(t, "PATCH", "/identities/"+id, http.StatusOK, json.RawMessage(`[
+				{"op": "replace", "path": "/state", "value": "inactive"}
+			]`))
+			assert.EqualValues(t, identity.StateInactive, res.Get("state").String(), "%s", res.Raw)
+			assert.True(t, res.Get("state_changed_at").Exists(), "%s", res.Raw)
+		})
+
+		for _, path := range []string{"/id", "/schema_url", "/state_changed_at", "/deletion_scheduled_at"} {
+			t.Run("case=should not be able to patch "+path, func(t *testing.T) {
+				id := create(t)
+				res := send(t, "PATCH", "/identities/"+id, http.StatusBadRequest, json.RawMessage(`[
+					{"op": "replace", "path": "`+path+`", "value": "foo"}
+				]`))
+				assert.Contains(t, res.Get("error.reason").String(), path, "%s", res.Raw)
+			})
+		}
+
+		t.Run("case=should fail on unknown fields", func(t *testing.T) {
+			id := create(t)
+			_ = send(t, "PATCH", "/identities/"+id, http.StatusBadRequest, json.RawMessage(`[
+				{"op": "add", "path": "/credentials", "value": {}}
+			]`))
+		})
+
+		t.Run("case=should fail on an invalid patch", func(t *testing.T) {
+			id := create(t)
+			_ = send(t, "PATCH", "/identities/"+id, http.StatusBadRequest, json.RawMessage(`[
+				{"op": "test", "path": "/schema_id", "value": "customer"},
+				{"op": "replace", "path": "/traits/department", "value": "kratos"}
+			]`))
+		})
+
+		t.Run("case=should not be able to patch an identity that does not exist", func(t *testing.T) {
+			_ = send(t, "PATCH", "/identities/"+x.NewUUID().String(), http.StatusNotFound, json.RawMessage(`[]`))
+		})
+	})
+
 	t.Run("case=should be able to update multiple identities", func(t *testing.T) {
 		for i := 0; i <= 5; i++ {
 			var cr identity.CreateIdentity
@@ -266,4 +464,47 @@ func TestHandler(t *testing.T) {
 	t.Run("case=should return 404 for non-existing identities", func(t *testing.T) {
 		remove(t, "/identities/"+x.NewUUID().String(), http.StatusNotFound)
 	})
+
+	t.Run("case=should export an identity without its credentials", func(t *testing.T) {
+		_ = get(t, "/identities/"+x.NewUUID().String()+"/export", http.StatusNotFound)
+
+		i := identity.NewIdentity("")
+		i.Traits = identity.Traits(`{"bar":"export"}`)
+		i.MetadataAdmin = []byte(`{"admin":"note"}`)
+		i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+			Type: identity.CredentialsTypePassword, Identifiers: []string{x.NewUUID().String()}, Config: []byte(`{"hashed_password":"secret"}`),
+		})
+		i.SetCredentials(identity.CredentialsTypeOIDC, identity.Credentials{
+			Type: identity.CredentialsTypeOIDC, Identifiers: []string{"github:" + i.ID.String()},
+			Config: []byte(`{"providers":[{"provider":"github","subject":"` + i.ID.String() + `","initial_access_token":"secret"}]}`),
+		})
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+
+		s := session.NewActiveSession(i, conf, time.Now().UTC())
+		s.IPAddress = "192.0.2.1"
+		s.UserAgent = "Mozilla/5.0"
+		require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), s))
+
+		e := audit.NewSelfServiceEvent(httptest.NewRequest("POST", "/", nil), audit.EventTypeLogin, "password", i.ID, nil)
+		require.NoError(t, reg.AuditEventPersister().CreateAuditEvent(context.Background(), e))
+
+		res := get(t, "/identities/"+i.ID.String()+"/export", http.StatusOK)
+		assert.Equal(t, i.ID.String(), res.Get("identity.id").String(), "%s", res.Raw)
+		assert.Equal(t, "export", res.Get("identity.traits.bar").String(), "%s", res.Raw)
+		assert.Equal(t, "note", res.Get("identity.metadata_admin.admin").String(), "%s", res.Raw)
+		assert.False(t, res.Get("identity.credentials").Exists(), "%s", res.Raw)
+		assert.NotContains(t, res.Raw, "secret")
+
+		assert.Equal(t, "github", res.Get("oidc_providers.0.provider").String(), "%s", res.Raw)
+		assert.Equal(t, i.ID.String(), res.Get("oidc_providers.0.subject").String(), "%s", res.Raw)
+
+		require.Len(t, res.Get("sessions").Array(), 1, "%s", res.Raw)
+		assert.Equal(t, s.ID.String(), res.Get("sessions.0.id").String(), "%s", res.Raw)
+		assert.Equal(t, "192.0.2.1", res.Get("sessions.0.ip_address").String(), "%s", res.Raw)
+		assert.Equal(t, "Mozilla/5.0", res.Get("sessions.0.user_agent").String(), "%s", res.Raw)
+		assert.False(t, res.Get("sessions.0.token").Exists(), "%s", res.Raw)
+
+		require.Len(t, res.Get("audit_events").Array(), 1, "%s", res.Raw)
+		assert.Equal(t, e.ID.String(), res.Get("audit_events.0.id").String(), "%s", res.Raw)
+	})
 }
@@ -20,7 +20,20 @@ import (
 	"github.com/ory/kratos/x"
 )
 
+const (
+	// StateActive is the state of an identity which is allowed to sign in.
+	StateActive State = "active"
+
+	// StateInactive is the state of an identity which is not allowed to sign in.
+	StateInactive State = "inactive"
+)
+
 type (
+	// State represents the state of an identity.
+	//
+	// swagger:model identityState
+	State string
+
 	// Identity represents an ORY Kratos identity
 	//
 	// An identity can be a real human, a service, an IoT device - everything that
@@ -52,6 +65,30 @@ type (
 		// required: true
 		SchemaURL string `json:"schema_url" faker:"-" db:"-"`
 
+		// State is the identity's state. Inactive identities can not sign in and their sessions are
+		// no longer valid.
+		//
+		// required: true
+		State State `json:"state" faker:"-" db:"state"`
+
+		// StateChangedAt contains the last time when the identity's state changed.
+		//
+		// Extensions:
+		// ---
+		// x-nullable: true
+		// ---
+		StateChangedAt *time.Time `json:"state_changed_at,omitempty" faker:"-" db:"state_changed_at"`
+
+		// DeletionScheduledAt is set if the identity deleted itself and the deletion is pending until the
+		// grace period ends. The identity is inactive until then. Setting its state to active cancels the
+		// deletion.
+		//
+		// Extensions:
+		// ---
+		// x-nullable: true
+		// ---
+		DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty" faker:"-" db:"deletion_scheduled_at"`
+
 		// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 		// in a self-service manner. The input will always be validated against the JSON Schema defined
 		// in `schema_url`.
@@ -75,6 +112,13 @@ type (
 		// ---
 		RecoveryAddresses []RecoveryAddress `json:"recovery_addresses,omitempty" faker:"-" has_many:"identity_recovery_addresses" fk_id:"identity_id"`
 
+		// MetadataPublic contains arbitrary metadata which is visible to the identity itself, for example in
+		// the session or the settings flow. It can only be modified using the admin API.
+		MetadataPublic sqlxx.NullJSONRawMessage `json:"metadata_public,omitempty" faker:"-" db:"metadata_public"`
+
+		// MetadataAdmin contains arbitrary metadata which is only visible and modifiable using the admin API.
+		MetadataAdmin sqlxx.NullJSONRawMessage `json:"metadata_admin,omitempty" faker:"-" db:"metadata_admin"`
+
 		// CredentialsCollection is a helper struct field for gobuffalo.pop.
 		CredentialsCollection CredentialsCollection `json:"-" faker:"-" has_many:"identity_credentials" fk_id:"identity_id"`
 
@@ -116,6 +160,14 @@ func (t *Traits) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (s State) IsValid() error {
+	switch s {
+	case StateActive, StateInactive:
+		return nil
+	}
+	return errors.WithStack(herodot.ErrBadRequest.WithReasonf("Identity state must be one of %q or %q but got %q.", StateActive, StateInactive, s))
+}
+
 func (i Identity) TableName(ctx context.Context) string {
 	return corp.ContextualizeTableName(ctx, "identities")
 }
@@ -127,6 +179,11 @@ func (i *Identity) lock() *sync.RWMutex {
 	return i.l
 }
 
+// IsActive returns true if the identity is allowed to sign in.
+func (i *Identity) IsActive() bool {
+	return i.State == StateActive
+}
+
 func (i *Identity) SetSecurityAnswers(answers map[string]string) {
 	i.lock().Lock()
 	defer i.lock().Unlock()
@@ -175,6 +232,14 @@ func (i *Identity) CopyWithoutCredentials() *Identity {
 	return &ii
 }
 
+// Declassify returns a copy of the identity without credentials and without the metadata
+// which must only be visible through the admin API.
+func (i *Identity) Declassify() *Identity {
+	ii := i.CopyWithoutCredentials()
+	ii.MetadataAdmin = nil
+	return ii
+}
+
 func NewIdentity(traitsSchemaID string) *Identity {
 	if traitsSchemaID == "" {
 		traitsSchemaID = config.DefaultIdentityTraitsSchemaID
@@ -186,6 +251,7 @@ func NewIdentity(traitsSchemaID string) *Identity {
 		Traits:              Traits("{}"),
 		SchemaID:            traitsSchemaID,
 		VerifiableAddresses: []VerifiableAddress{},
+		State:               StateActive,
 		l:                   new(sync.RWMutex),
 	}
 }
@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -61,6 +62,10 @@ func newManagerOptions(opts []ManagerOption) *managerOptions {
 }
 
 func (m *Manager) Create(ctx context.Context, i *Identity, opts ...ManagerOption) error {
+	if i.State == "" {
+		i.State = StateActive
+	}
+
 	o := newManagerOptions(opts)
 	if err := m.validate(ctx, i, o); err != nil {
 		return err
@@ -84,6 +89,12 @@ func (m *Manager) requiresPrivilegedAccess(_ context.Context, original, updated
 			*updated = *original
 			return errors.WithStack(ErrProtectedFieldModified)
 		}
+
+		if original.State != updated.State {
+			// reset the identity
+			*updated = *original
+			return errors.WithStack(ErrProtectedFieldModified)
+		}
 	}
 	return nil
 }
@@ -103,6 +114,16 @@ func (m *Manager) Update(ctx context.Context, updated *Identity, opts ...Manager
 		return err
 	}
 
+	if original.State != updated.State {
+		changedAt := time.Now().UTC()
+		updated.StateChangedAt = &changedAt
+	}
+
+	if updated.State == StateActive {
+		// Activating the identity cancels a scheduled deletion.
+		updated.DeletionScheduledAt = nil
+	}
+
 	return m.r.IdentityPool().(PrivilegedPool).UpdateIdentity(ctx, updated)
 }
 
@@ -147,6 +168,10 @@ func (m *Manager) UpdateTraits(ctx context.Context, id uuid.UUID, traits Traits,
 }
 
 func (m *Manager) validate(ctx context.Context, i *Identity, o *managerOptions) error {
+	if err := i.State.IsValid(); err != nil {
+		return err
+	}
+
 	if err := m.r.IdentityValidator().Validate(ctx, i); err != nil {
 		if _, ok := errorsx.Cause(err).(*jsonschema.ValidationError); ok && !o.ExposeValidationErrors {
 			return herodot.ErrBadRequest.WithReasonf("%s", err).WithWrap(err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -108,6 +109,47 @@ func TestManager(t *testing.T) {
 			checkExtensionFields(fromStore, "email-update-1@ory.sh")(t)
 		})
 
+		t.Run("case=should update state and record the time of the change", func(t *testing.T) {
+			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			original.Traits = newTraits("state-update-1@ory.sh", "")
+			require.NoError(t, reg.IdentityManager().Create(context.Background(), original))
+			assert.Equal(t, identity.StateActive, original.State)
+			assert.Nil(t, original.StateChangedAt)
+
+			original.State = identity.StateInactive
+			require.NoError(t, reg.IdentityManager().Update(context.Background(), original, identity.ManagerAllowWriteProtectedTraits))
+
+			fromStore, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), original.ID)
+			require.NoError(t, err)
+			assert.Equal(t, identity.StateInactive, fromStore.State)
+			require.NotNil(t, fromStore.StateChangedAt)
+			assert.WithinDuration(t, time.Now(), *fromStore.StateChangedAt, time.Minute)
+		})
+
+		t.Run("case=should not update state without option", func(t *testing.T) {
+			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			original.Traits = newTraits("state-update-2@ory.sh", "")
+			require.NoError(t, reg.IdentityManager().Create(context.Background(), original))
+
+			original.State = identity.StateInactive
+			err := reg.IdentityManager().Update(context.Background(), original)
+			require.Error(t, err)
+			assert.Equal(t, identity.ErrProtectedFieldModified, errors.Cause(err))
+
+			fromStore, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), original.ID)
+			require.NoError(t, err)
+			assert.Equal(t, identity.StateActive, fromStore.State)
+		})
+
+		t.Run("case=should not update to an invalid state", func(t *testing.T) {
+			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			original.Traits = newTraits("state-update-3@ory.sh", "")
+			require.NoError(t, reg.IdentityManager().Create(context.Background(), original))
+
+			original.State = "deleted"
+			require.Error(t, reg.IdentityManager().Update(context.Background(), original, identity.ManagerAllowWriteProtectedTraits))
+		})
+
 		t.Run("case=changing recovery address removes it from the store", func(t *testing.T) {
 			originalEmail := x.NewUUID().String() + "@ory.sh"
 			original := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
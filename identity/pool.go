@@ -83,6 +83,10 @@ type (
 
 		// ListRecoveryAddresses lists all tracked recovery addresses.
 		ListRecoveryAddresses(ctx context.Context, page, itemsPerPage int) ([]RecoveryAddress, error)
+
+		// ListIdentitiesScheduledForDeletion returns up to limit identities whose deletion was scheduled for a
+		// time before the given one, oldest first. The identities' addresses are not populated.
+		ListIdentitiesScheduledForDeletion(ctx context.Context, before time.Time, limit int) ([]Identity, error)
 	}
 )
 
@@ -347,6 +351,40 @@ func TestPool(ctx context.Context, conf *config.Config, p interface {
 			require.Error(t, err)
 		})
 
+		t.Run("case=list identities scheduled for deletion", func(t *testing.T) {
+			now := time.Now().UTC().Truncate(time.Second)
+			var scheduled = func(at *time.Time) *Identity {
+				i := passwordIdentity("", x.NewUUID().String())
+				i.State = StateInactive
+				i.DeletionScheduledAt = at
+				require.NoError(t, p.CreateIdentity(ctx, i))
+				return i
+			}
+
+			due, later := now.Add(-time.Minute), now.Add(time.Hour)
+			first := scheduled(&due)
+			notDue := scheduled(&later)
+			notScheduled := scheduled(nil)
+			t.Cleanup(func() {
+				for _, i := range []*Identity{first, notDue, notScheduled} {
+					_ = p.DeleteIdentity(ctx, i.ID)
+				}
+			})
+
+			actual, err := p.ListIdentitiesScheduledForDeletion(ctx, now, 10)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, first.ID, actual[0].ID)
+			assert.Equal(t, StateInactive, actual[0].State)
+			require.NotNil(t, actual[0].DeletionScheduledAt)
+			assert.Equal(t, due.Unix(), actual[0].DeletionScheduledAt.Unix())
+
+			actual, err = p.ListIdentitiesScheduledForDeletion(ctx, later.Add(time.Minute), 1)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, first.ID, actual[0].ID)
+		})
+
 		t.Run("case=create with empty credentials config", func(t *testing.T) {
 			// This test covers a case where the config value of a credentials setting is empty. This causes
 			// issues with postgres' json field.
@@ -0,0 +1,75 @@
+package identity
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/x"
+)
+
+// purgeInterval is how often the Purger checks for identities whose deletion grace period ended.
+const purgeInterval = time.Minute
+
+type (
+	purgerDependencies interface {
+		x.LoggingProvider
+		PrivilegedPoolProvider
+	}
+
+	PurgerProvider interface {
+		IdentityPurger() *Purger
+	}
+
+	// Purger deletes identities whose scheduled deletion is due.
+	Purger struct {
+		d purgerDependencies
+	}
+)
+
+func NewPurger(d purgerDependencies) *Purger {
+	return &Purger{d: d}
+}
+
+// Work purges identities until the context is canceled.
+func (p *Purger) Work(ctx context.Context) error {
+	for {
+		if err := p.Purge(ctx, time.Now().UTC()); err != nil {
+			p.d.Logger().WithError(err).Warn("Unable to purge identities scheduled for deletion, will retry.")
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(purgeInterval):
+		}
+	}
+}
+
+// Purge deletes all identities whose deletion was scheduled before the given time.
+func (p *Purger) Purge(ctx context.Context, now time.Time) error {
+	for {
+		is, err := p.d.PrivilegedIdentityPool().ListIdentitiesScheduledForDeletion(ctx, now, 100)
+		if err != nil {
+			return err
+		}
+
+		if len(is) == 0 {
+			return nil
+		}
+
+		for k := range is {
+			if err := p.d.PrivilegedIdentityPool().DeleteIdentity(ctx, is[k].ID); err != nil {
+				return err
+			}
+
+			p.d.Logger().
+				WithField("identity_id", is[k].ID).
+				Info("Purged identity after its deletion grace period ended.")
+		}
+	}
+}
@@ -32,18 +32,40 @@ type ClientOption func(*runtime.ClientOperation)
 type ClientService interface {
 	CreateIdentity(params *CreateIdentityParams, opts ...ClientOption) (*CreateIdentityCreated, error)
 
+	CreateOIDCProvider(params *CreateOIDCProviderParams, opts ...ClientOption) (*CreateOIDCProviderCreated, error)
+
 	CreateRecoveryLink(params *CreateRecoveryLinkParams, opts ...ClientOption) (*CreateRecoveryLinkOK, error)
 
 	DeleteIdentity(params *DeleteIdentityParams, opts ...ClientOption) (*DeleteIdentityNoContent, error)
 
+	DeleteOIDCProvider(params *DeleteOIDCProviderParams, opts ...ClientOption) (*DeleteOIDCProviderNoContent, error)
+
+	ExpirePassword(params *ExpirePasswordParams, opts ...ClientOption) (*ExpirePasswordNoContent, error)
+
+	ExportIdentity(params *ExportIdentityParams, opts ...ClientOption) (*ExportIdentityOK, error)
+
 	GetIdentity(params *GetIdentityParams, opts ...ClientOption) (*GetIdentityOK, error)
 
+	GetIdentityOIDCTokens(params *GetIdentityOIDCTokensParams, opts ...ClientOption) (*GetIdentityOIDCTokensOK, error)
+
+	GetOIDCProvider(params *GetOIDCProviderParams, opts ...ClientOption) (*GetOIDCProviderOK, error)
+
+	ListAuditEvents(params *ListAuditEventsParams, opts ...ClientOption) (*ListAuditEventsOK, error)
+
 	ListIdentities(params *ListIdentitiesParams, opts ...ClientOption) (*ListIdentitiesOK, error)
 
+	ListOIDCProviders(params *ListOIDCProvidersParams, opts ...ClientOption) (*ListOIDCProvidersOK, error)
+
+	PatchIdentity(params *PatchIdentityParams, opts ...ClientOption) (*PatchIdentityOK, error)
+
 	Prometheus(params *PrometheusParams, opts ...ClientOption) (*PrometheusOK, error)
 
+	UnlockIdentity(params *UnlockIdentityParams, opts ...ClientOption) (*UnlockIdentityNoContent, error)
+
 	UpdateIdentity(params *UpdateIdentityParams, opts ...ClientOption) (*UpdateIdentityOK, error)
 
+	UpdateOIDCProvider(params *UpdateOIDCProviderParams, opts ...ClientOption) (*UpdateOIDCProviderOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -90,6 +112,51 @@ func (a *Client) CreateIdentity(params *CreateIdentityParams, opts ...ClientOpti
 	panic(msg)
 }
 
+/*
+  CreateOIDCProvider creates an open ID connect provider
+
+  This endpoint stores an OpenID Connect Provider in the database. The provider can be used for signing in
+immediately, without restarting ORY Kratos. The client secret and the Apple private key are stored encrypted.
+
+The provider's ID must not be used by a provider in the configuration file.
+
+Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+*/
+func (a *Client) CreateOIDCProvider(params *CreateOIDCProviderParams, opts ...ClientOption) (*CreateOIDCProviderCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateOIDCProviderParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "createOIDCProvider",
+		Method:             "POST",
+		PathPattern:        "/oidc/providers",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &CreateOIDCProviderReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateOIDCProviderCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for createOIDCProvider: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   CreateRecoveryLink creates a recovery link
 
@@ -175,6 +242,137 @@ func (a *Client) DeleteIdentity(params *DeleteIdentityParams, opts ...ClientOpti
 	panic(msg)
 }
 
+/*
+  DeleteOIDCProvider deletes an open ID connect provider stored in the database
+
+  Identities which signed up with the provider keep their OpenID Connect credentials but can no longer sign in
+with the provider.
+
+Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+*/
+func (a *Client) DeleteOIDCProvider(params *DeleteOIDCProviderParams, opts ...ClientOption) (*DeleteOIDCProviderNoContent, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteOIDCProviderParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "deleteOIDCProvider",
+		Method:             "DELETE",
+		PathPattern:        "/oidc/providers/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &DeleteOIDCProviderReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteOIDCProviderNoContent)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for deleteOIDCProvider: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ExpirePassword expires an identity s password
+
+  Calling this endpoint expires the password of the identity given its ID. The next time the identity signs in
+using the password, it must change the password using the settings flow before the session can be used.
+Existing sessions are not affected.
+
+Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+*/
+func (a *Client) ExpirePassword(params *ExpirePasswordParams, opts ...ClientOption) (*ExpirePasswordNoContent, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExpirePasswordParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "expirePassword",
+		Method:             "POST",
+		PathPattern:        "/identities/{id}/password/expire",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &ExpirePasswordReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExpirePasswordNoContent)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for expirePassword: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ExportIdentity exports an identity s personal data
+
+  Returns all data stored about the identity in one JSON document: its traits and addresses, the
+OpenID Connect providers it is linked with, its sessions including the devices they were issued to,
+and its recent audit events. Credentials are never exported.
+
+Identities can export their own data using the `/sessions/whoami/export` endpoint of the Public API.
+*/
+func (a *Client) ExportIdentity(params *ExportIdentityParams, opts ...ClientOption) (*ExportIdentityOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExportIdentityParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "exportIdentity",
+		Method:             "GET",
+		PathPattern:        "/identities/{id}/export",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &ExportIdentityReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExportIdentityOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for exportIdentity: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetIdentity gets an identity
 
@@ -215,6 +413,132 @@ func (a *Client) GetIdentity(params *GetIdentityParams, opts ...ClientOption) (*
 	panic(msg)
 }
 
+/*
+  GetIdentityOIDCTokens gets the upstream o auth2 tokens of an identity
+
+  This endpoint returns the upstream OAuth2 tokens of all OpenID Connect Providers which are linked to the identity,
+for example to call the GitHub or Google APIs on behalf of the user. Expired access tokens are refreshed if the
+provider issued a refresh token. Refresh tokens are never returned.
+
+Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+*/
+func (a *Client) GetIdentityOIDCTokens(params *GetIdentityOIDCTokensParams, opts ...ClientOption) (*GetIdentityOIDCTokensOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIdentityOIDCTokensParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getIdentityOIDCTokens",
+		Method:             "GET",
+		PathPattern:        "/identities/{id}/credentials/oidc/tokens",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &GetIdentityOIDCTokensReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIdentityOIDCTokensOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getIdentityOIDCTokens: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetOIDCProvider gets an open ID connect provider stored in the database
+
+  Secrets are never returned.
+Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+*/
+func (a *Client) GetOIDCProvider(params *GetOIDCProviderParams, opts ...ClientOption) (*GetOIDCProviderOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetOIDCProviderParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getOIDCProvider",
+		Method:             "GET",
+		PathPattern:        "/oidc/providers/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &GetOIDCProviderReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetOIDCProviderOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getOIDCProvider: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListAuditEvents lists audit events
+
+  Lists security relevant events such as logins, password changes, account recoveries, and changes
+made using the admin API, newest first.
+*/
+func (a *Client) ListAuditEvents(params *ListAuditEventsParams, opts ...ClientOption) (*ListAuditEventsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListAuditEventsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "listAuditEvents",
+		Method:             "GET",
+		PathPattern:        "/audit/events",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &ListAuditEventsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListAuditEventsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for listAuditEvents: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   ListIdentities lists identities
 
@@ -257,6 +581,94 @@ func (a *Client) ListIdentities(params *ListIdentitiesParams, opts ...ClientOpti
 	panic(msg)
 }
 
+/*
+  ListOIDCProviders lists the open ID connect providers stored in the database
+
+  This endpoint lists the OpenID Connect Providers which are managed using the admin API. Providers from the
+configuration file are not included. Secrets are never returned.
+
+Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+*/
+func (a *Client) ListOIDCProviders(params *ListOIDCProvidersParams, opts ...ClientOption) (*ListOIDCProvidersOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListOIDCProvidersParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "listOIDCProviders",
+		Method:             "GET",
+		PathPattern:        "/oidc/providers",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &ListOIDCProvidersReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListOIDCProvidersOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for listOIDCProviders: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  PatchIdentity patches an identity
+
+  This endpoint partially updates an identity using an [RFC 6902 JSON Patch](https://tools.ietf.org/html/rfc6902).
+The patch may modify the `schema_id`, `traits`, `verifiable_addresses`, and `recovery_addresses` fields. The
+patched identity is validated against its JSON Schema before it is persisted. It is NOT possible to set an
+identity's credentials (password, ...) using this method!
+
+Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+*/
+func (a *Client) PatchIdentity(params *PatchIdentityParams, opts ...ClientOption) (*PatchIdentityOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPatchIdentityParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "patchIdentity",
+		Method:             "PATCH",
+		PathPattern:        "/identities/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &PatchIdentityReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PatchIdentityOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for patchIdentity: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   Prometheus gets snapshot metrics from the hydra service if you re using k8s you can then add annotations to your deployment like so
 
@@ -302,13 +714,58 @@ func (a *Client) Prometheus(params *PrometheusParams, opts ...ClientOption) (*Pr
 	panic(msg)
 }
 
+/*
+  UnlockIdentity unlocks an identity
+
+  Calling this endpoint forgets all failed password login attempts for the identifiers of the identity given its ID
+which unlocks the identity if it was locked out because of too many failed login attempts. Failed login attempts
+tracked per IP address are not affected.
+
+Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+*/
+func (a *Client) UnlockIdentity(params *UnlockIdentityParams, opts ...ClientOption) (*UnlockIdentityNoContent, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUnlockIdentityParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "unlockIdentity",
+		Method:             "DELETE",
+		PathPattern:        "/identities/{id}/lockout",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &UnlockIdentityReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UnlockIdentityNoContent)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for unlockIdentity: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   UpdateIdentity updates an identity
 
   This endpoint updates an identity. It is NOT possible to set an identity's credentials (password, ...)
 using this method! A way to achieve that will be introduced in the future.
 
-The full identity payload (except credentials) is expected. This endpoint does not support patching.
+The full identity payload (except credentials) is expected. To update only parts of an identity, use the
+`PATCH /identities/{id}` endpoint instead.
 
 Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
 */
@@ -347,6 +804,49 @@ func (a *Client) UpdateIdentity(params *UpdateIdentityParams, opts ...ClientOpti
 	panic(msg)
 }
 
+/*
+  UpdateOIDCProvider updates an open ID connect provider stored in the database
+
+  This endpoint replaces the configuration of an OpenID Connect Provider which is stored in the database. Changes
+take effect immediately. If the client secret or the Apple private key are omitted, the stored ones are kept.
+
+Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+*/
+func (a *Client) UpdateOIDCProvider(params *UpdateOIDCProviderParams, opts ...ClientOption) (*UpdateOIDCProviderOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateOIDCProviderParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "updateOIDCProvider",
+		Method:             "PUT",
+		PathPattern:        "/oidc/providers/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &UpdateOIDCProviderReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdateOIDCProviderOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for updateOIDCProvider: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
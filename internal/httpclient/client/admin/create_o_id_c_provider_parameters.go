@@ -0,0 +1,148 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// NewCreateOIDCProviderParams creates a new CreateOIDCProviderParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewCreateOIDCProviderParams() *CreateOIDCProviderParams {
+	return &CreateOIDCProviderParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewCreateOIDCProviderParamsWithTimeout creates a new CreateOIDCProviderParams object
+// with the ability to set a timeout on a request.
+func NewCreateOIDCProviderParamsWithTimeout(timeout time.Duration) *CreateOIDCProviderParams {
+	return &CreateOIDCProviderParams{
+		timeout: timeout,
+	}
+}
+
+// NewCreateOIDCProviderParamsWithContext creates a new CreateOIDCProviderParams object
+// with the ability to set a context for a request.
+func NewCreateOIDCProviderParamsWithContext(ctx context.Context) *CreateOIDCProviderParams {
+	return &CreateOIDCProviderParams{
+		Context: ctx,
+	}
+}
+
+// NewCreateOIDCProviderParamsWithHTTPClient creates a new CreateOIDCProviderParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewCreateOIDCProviderParamsWithHTTPClient(client *http.Client) *CreateOIDCProviderParams {
+	return &CreateOIDCProviderParams{
+		HTTPClient: client,
+	}
+}
+
+/* CreateOIDCProviderParams contains all the parameters to send to the API endpoint
+   for the create o ID c provider operation.
+
+   Typically these are written to a http.Request.
+*/
+type CreateOIDCProviderParams struct {
+
+	// Body.
+	Body *models.OidcProvider
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the create o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CreateOIDCProviderParams) WithDefaults() *CreateOIDCProviderParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the create o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CreateOIDCProviderParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the create o ID c provider params
+func (o *CreateOIDCProviderParams) WithTimeout(timeout time.Duration) *CreateOIDCProviderParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the create o ID c provider params
+func (o *CreateOIDCProviderParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the create o ID c provider params
+func (o *CreateOIDCProviderParams) WithContext(ctx context.Context) *CreateOIDCProviderParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the create o ID c provider params
+func (o *CreateOIDCProviderParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the create o ID c provider params
+func (o *CreateOIDCProviderParams) WithHTTPClient(client *http.Client) *CreateOIDCProviderParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the create o ID c provider params
+func (o *CreateOIDCProviderParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the create o ID c provider params
+func (o *CreateOIDCProviderParams) WithBody(body *models.OidcProvider) *CreateOIDCProviderParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the create o ID c provider params
+func (o *CreateOIDCProviderParams) SetBody(body *models.OidcProvider) {
+	o.Body = body
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *CreateOIDCProviderParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
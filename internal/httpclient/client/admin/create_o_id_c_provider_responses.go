@@ -0,0 +1,181 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// CreateOIDCProviderReader is a Reader for the CreateOIDCProvider structure.
+type CreateOIDCProviderReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CreateOIDCProviderReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 201:
+		result := NewCreateOIDCProviderCreated()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewCreateOIDCProviderBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 409:
+		result := NewCreateOIDCProviderConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewCreateOIDCProviderInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewCreateOIDCProviderCreated creates a CreateOIDCProviderCreated with default headers values
+func NewCreateOIDCProviderCreated() *CreateOIDCProviderCreated {
+	return &CreateOIDCProviderCreated{}
+}
+
+/* CreateOIDCProviderCreated describes a response with status code 201, with default header values.
+
+A single OpenID Connect Provider.
+*/
+type CreateOIDCProviderCreated struct {
+	Payload *models.OidcProvider
+}
+
+func (o *CreateOIDCProviderCreated) Error() string {
+	return fmt.Sprintf("[POST /oidc/providers][%d] createOIdCProviderCreated  %+v", 201, o.Payload)
+}
+func (o *CreateOIDCProviderCreated) GetPayload() *models.OidcProvider {
+	return o.Payload
+}
+
+func (o *CreateOIDCProviderCreated) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.OidcProvider)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateOIDCProviderBadRequest creates a CreateOIDCProviderBadRequest with default headers values
+func NewCreateOIDCProviderBadRequest() *CreateOIDCProviderBadRequest {
+	return &CreateOIDCProviderBadRequest{}
+}
+
+/* CreateOIDCProviderBadRequest describes a response with status code 400, with default header values.
+
+genericError
+*/
+type CreateOIDCProviderBadRequest struct {
+	Payload *models.GenericError
+}
+
+func (o *CreateOIDCProviderBadRequest) Error() string {
+	return fmt.Sprintf("[POST /oidc/providers][%d] createOIdCProviderBadRequest  %+v", 400, o.Payload)
+}
+func (o *CreateOIDCProviderBadRequest) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CreateOIDCProviderBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateOIDCProviderConflict creates a CreateOIDCProviderConflict with default headers values
+func NewCreateOIDCProviderConflict() *CreateOIDCProviderConflict {
+	return &CreateOIDCProviderConflict{}
+}
+
+/* CreateOIDCProviderConflict describes a response with status code 409, with default header values.
+
+genericError
+*/
+type CreateOIDCProviderConflict struct {
+	Payload *models.GenericError
+}
+
+func (o *CreateOIDCProviderConflict) Error() string {
+	return fmt.Sprintf("[POST /oidc/providers][%d] createOIdCProviderConflict  %+v", 409, o.Payload)
+}
+func (o *CreateOIDCProviderConflict) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CreateOIDCProviderConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateOIDCProviderInternalServerError creates a CreateOIDCProviderInternalServerError with default headers values
+func NewCreateOIDCProviderInternalServerError() *CreateOIDCProviderInternalServerError {
+	return &CreateOIDCProviderInternalServerError{}
+}
+
+/* CreateOIDCProviderInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type CreateOIDCProviderInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *CreateOIDCProviderInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /oidc/providers][%d] createOIdCProviderInternalServerError  %+v", 500, o.Payload)
+}
+func (o *CreateOIDCProviderInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CreateOIDCProviderInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
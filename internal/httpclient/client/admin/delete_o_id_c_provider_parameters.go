@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewDeleteOIDCProviderParams creates a new DeleteOIDCProviderParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewDeleteOIDCProviderParams() *DeleteOIDCProviderParams {
+	return &DeleteOIDCProviderParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewDeleteOIDCProviderParamsWithTimeout creates a new DeleteOIDCProviderParams object
+// with the ability to set a timeout on a request.
+func NewDeleteOIDCProviderParamsWithTimeout(timeout time.Duration) *DeleteOIDCProviderParams {
+	return &DeleteOIDCProviderParams{
+		timeout: timeout,
+	}
+}
+
+// NewDeleteOIDCProviderParamsWithContext creates a new DeleteOIDCProviderParams object
+// with the ability to set a context for a request.
+func NewDeleteOIDCProviderParamsWithContext(ctx context.Context) *DeleteOIDCProviderParams {
+	return &DeleteOIDCProviderParams{
+		Context: ctx,
+	}
+}
+
+// NewDeleteOIDCProviderParamsWithHTTPClient creates a new DeleteOIDCProviderParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewDeleteOIDCProviderParamsWithHTTPClient(client *http.Client) *DeleteOIDCProviderParams {
+	return &DeleteOIDCProviderParams{
+		HTTPClient: client,
+	}
+}
+
+/* DeleteOIDCProviderParams contains all the parameters to send to the API endpoint
+   for the delete o ID c provider operation.
+
+   Typically these are written to a http.Request.
+*/
+type DeleteOIDCProviderParams struct {
+
+	/* ID.
+
+	   ID is the provider's ID.
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the delete o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *DeleteOIDCProviderParams) WithDefaults() *DeleteOIDCProviderParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the delete o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *DeleteOIDCProviderParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) WithTimeout(timeout time.Duration) *DeleteOIDCProviderParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) WithContext(ctx context.Context) *DeleteOIDCProviderParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) WithHTTPClient(client *http.Client) *DeleteOIDCProviderParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithID adds the id to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) WithID(id string) *DeleteOIDCProviderParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the delete o ID c provider params
+func (o *DeleteOIDCProviderParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *DeleteOIDCProviderParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// DeleteOIDCProviderReader is a Reader for the DeleteOIDCProvider structure.
+type DeleteOIDCProviderReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DeleteOIDCProviderReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 204:
+		result := NewDeleteOIDCProviderNoContent()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 404:
+		result := NewDeleteOIDCProviderNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewDeleteOIDCProviderInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewDeleteOIDCProviderNoContent creates a DeleteOIDCProviderNoContent with default headers values
+func NewDeleteOIDCProviderNoContent() *DeleteOIDCProviderNoContent {
+	return &DeleteOIDCProviderNoContent{}
+}
+
+/* DeleteOIDCProviderNoContent describes a response with status code 204, with default header values.
+
+Empty responses are sent when, for example, resources are deleted. The HTTP status code for empty responses is typically 201.
+*/
+type DeleteOIDCProviderNoContent struct {
+}
+
+func (o *DeleteOIDCProviderNoContent) Error() string {
+	return fmt.Sprintf("[DELETE /oidc/providers/{id}][%d] deleteOIdCProviderNoContent ", 204)
+}
+
+func (o *DeleteOIDCProviderNoContent) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewDeleteOIDCProviderNotFound creates a DeleteOIDCProviderNotFound with default headers values
+func NewDeleteOIDCProviderNotFound() *DeleteOIDCProviderNotFound {
+	return &DeleteOIDCProviderNotFound{}
+}
+
+/* DeleteOIDCProviderNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type DeleteOIDCProviderNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *DeleteOIDCProviderNotFound) Error() string {
+	return fmt.Sprintf("[DELETE /oidc/providers/{id}][%d] deleteOIdCProviderNotFound  %+v", 404, o.Payload)
+}
+func (o *DeleteOIDCProviderNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *DeleteOIDCProviderNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDeleteOIDCProviderInternalServerError creates a DeleteOIDCProviderInternalServerError with default headers values
+func NewDeleteOIDCProviderInternalServerError() *DeleteOIDCProviderInternalServerError {
+	return &DeleteOIDCProviderInternalServerError{}
+}
+
+/* DeleteOIDCProviderInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type DeleteOIDCProviderInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *DeleteOIDCProviderInternalServerError) Error() string {
+	return fmt.Sprintf("[DELETE /oidc/providers/{id}][%d] deleteOIdCProviderInternalServerError  %+v", 500, o.Payload)
+}
+func (o *DeleteOIDCProviderInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *DeleteOIDCProviderInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewExpirePasswordParams creates a new ExpirePasswordParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewExpirePasswordParams() *ExpirePasswordParams {
+	return &ExpirePasswordParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewExpirePasswordParamsWithTimeout creates a new ExpirePasswordParams object
+// with the ability to set a timeout on a request.
+func NewExpirePasswordParamsWithTimeout(timeout time.Duration) *ExpirePasswordParams {
+	return &ExpirePasswordParams{
+		timeout: timeout,
+	}
+}
+
+// NewExpirePasswordParamsWithContext creates a new ExpirePasswordParams object
+// with the ability to set a context for a request.
+func NewExpirePasswordParamsWithContext(ctx context.Context) *ExpirePasswordParams {
+	return &ExpirePasswordParams{
+		Context: ctx,
+	}
+}
+
+// NewExpirePasswordParamsWithHTTPClient creates a new ExpirePasswordParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewExpirePasswordParamsWithHTTPClient(client *http.Client) *ExpirePasswordParams {
+	return &ExpirePasswordParams{
+		HTTPClient: client,
+	}
+}
+
+/* ExpirePasswordParams contains all the parameters to send to the API endpoint
+   for the expire password operation.
+
+   Typically these are written to a http.Request.
+*/
+type ExpirePasswordParams struct {
+
+	/* ID.
+
+	   ID is the identity's ID.
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the expire password params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ExpirePasswordParams) WithDefaults() *ExpirePasswordParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the expire password params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ExpirePasswordParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the expire password params
+func (o *ExpirePasswordParams) WithTimeout(timeout time.Duration) *ExpirePasswordParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the expire password params
+func (o *ExpirePasswordParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the expire password params
+func (o *ExpirePasswordParams) WithContext(ctx context.Context) *ExpirePasswordParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the expire password params
+func (o *ExpirePasswordParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the expire password params
+func (o *ExpirePasswordParams) WithHTTPClient(client *http.Client) *ExpirePasswordParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the expire password params
+func (o *ExpirePasswordParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithID adds the id to the expire password params
+func (o *ExpirePasswordParams) WithID(id string) *ExpirePasswordParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the expire password params
+func (o *ExpirePasswordParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ExpirePasswordParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// ExpirePasswordReader is a Reader for the ExpirePassword structure.
+type ExpirePasswordReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ExpirePasswordReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 204:
+		result := NewExpirePasswordNoContent()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 404:
+		result := NewExpirePasswordNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewExpirePasswordInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewExpirePasswordNoContent creates a ExpirePasswordNoContent with default headers values
+func NewExpirePasswordNoContent() *ExpirePasswordNoContent {
+	return &ExpirePasswordNoContent{}
+}
+
+/* ExpirePasswordNoContent describes a response with status code 204, with default header values.
+
+Empty responses are sent when, for example, resources are deleted. The HTTP status code for empty responses is typically 201.
+*/
+type ExpirePasswordNoContent struct {
+}
+
+func (o *ExpirePasswordNoContent) Error() string {
+	return fmt.Sprintf("[POST /identities/{id}/password/expire][%d] expirePasswordNoContent ", 204)
+}
+
+func (o *ExpirePasswordNoContent) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewExpirePasswordNotFound creates a ExpirePasswordNotFound with default headers values
+func NewExpirePasswordNotFound() *ExpirePasswordNotFound {
+	return &ExpirePasswordNotFound{}
+}
+
+/* ExpirePasswordNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type ExpirePasswordNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *ExpirePasswordNotFound) Error() string {
+	return fmt.Sprintf("[POST /identities/{id}/password/expire][%d] expirePasswordNotFound  %+v", 404, o.Payload)
+}
+func (o *ExpirePasswordNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExpirePasswordNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExpirePasswordInternalServerError creates a ExpirePasswordInternalServerError with default headers values
+func NewExpirePasswordInternalServerError() *ExpirePasswordInternalServerError {
+	return &ExpirePasswordInternalServerError{}
+}
+
+/* ExpirePasswordInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type ExpirePasswordInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *ExpirePasswordInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /identities/{id}/password/expire][%d] expirePasswordInternalServerError  %+v", 500, o.Payload)
+}
+func (o *ExpirePasswordInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExpirePasswordInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewExportIdentityParams creates a new ExportIdentityParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewExportIdentityParams() *ExportIdentityParams {
+	return &ExportIdentityParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewExportIdentityParamsWithTimeout creates a new ExportIdentityParams object
+// with the ability to set a timeout on a request.
+func NewExportIdentityParamsWithTimeout(timeout time.Duration) *ExportIdentityParams {
+	return &ExportIdentityParams{
+		timeout: timeout,
+	}
+}
+
+// NewExportIdentityParamsWithContext creates a new ExportIdentityParams object
+// with the ability to set a context for a request.
+func NewExportIdentityParamsWithContext(ctx context.Context) *ExportIdentityParams {
+	return &ExportIdentityParams{
+		Context: ctx,
+	}
+}
+
+// NewExportIdentityParamsWithHTTPClient creates a new ExportIdentityParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewExportIdentityParamsWithHTTPClient(client *http.Client) *ExportIdentityParams {
+	return &ExportIdentityParams{
+		HTTPClient: client,
+	}
+}
+
+/* ExportIdentityParams contains all the parameters to send to the API endpoint
+   for the export identity operation.
+
+   Typically these are written to a http.Request.
+*/
+type ExportIdentityParams struct {
+
+	/* ID.
+
+	   ID must be set to the ID of identity you want to export
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the export identity params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ExportIdentityParams) WithDefaults() *ExportIdentityParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the export identity params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ExportIdentityParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the export identity params
+func (o *ExportIdentityParams) WithTimeout(timeout time.Duration) *ExportIdentityParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the export identity params
+func (o *ExportIdentityParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the export identity params
+func (o *ExportIdentityParams) WithContext(ctx context.Context) *ExportIdentityParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the export identity params
+func (o *ExportIdentityParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the export identity params
+func (o *ExportIdentityParams) WithHTTPClient(client *http.Client) *ExportIdentityParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the export identity params
+func (o *ExportIdentityParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithID adds the id to the export identity params
+func (o *ExportIdentityParams) WithID(id string) *ExportIdentityParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the export identity params
+func (o *ExportIdentityParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ExportIdentityParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
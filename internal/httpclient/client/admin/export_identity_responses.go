@@ -0,0 +1,143 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// ExportIdentityReader is a Reader for the ExportIdentity structure.
+type ExportIdentityReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ExportIdentityReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewExportIdentityOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 404:
+		result := NewExportIdentityNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewExportIdentityInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewExportIdentityOK creates a ExportIdentityOK with default headers values
+func NewExportIdentityOK() *ExportIdentityOK {
+	return &ExportIdentityOK{}
+}
+
+/* ExportIdentityOK describes a response with status code 200, with default header values.
+
+identityExportResponse
+*/
+type ExportIdentityOK struct {
+	Payload *models.IdentityExport
+}
+
+func (o *ExportIdentityOK) Error() string {
+	return fmt.Sprintf("[GET /identities/{id}/export][%d] exportIdentityOK  %+v", 200, o.Payload)
+}
+func (o *ExportIdentityOK) GetPayload() *models.IdentityExport {
+	return o.Payload
+}
+
+func (o *ExportIdentityOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.IdentityExport)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportIdentityNotFound creates a ExportIdentityNotFound with default headers values
+func NewExportIdentityNotFound() *ExportIdentityNotFound {
+	return &ExportIdentityNotFound{}
+}
+
+/* ExportIdentityNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type ExportIdentityNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *ExportIdentityNotFound) Error() string {
+	return fmt.Sprintf("[GET /identities/{id}/export][%d] exportIdentityNotFound  %+v", 404, o.Payload)
+}
+func (o *ExportIdentityNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExportIdentityNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportIdentityInternalServerError creates a ExportIdentityInternalServerError with default headers values
+func NewExportIdentityInternalServerError() *ExportIdentityInternalServerError {
+	return &ExportIdentityInternalServerError{}
+}
+
+/* ExportIdentityInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type ExportIdentityInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *ExportIdentityInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /identities/{id}/export][%d] exportIdentityInternalServerError  %+v", 500, o.Payload)
+}
+func (o *ExportIdentityInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExportIdentityInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
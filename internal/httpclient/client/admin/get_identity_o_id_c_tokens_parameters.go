@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetIdentityOIDCTokensParams creates a new GetIdentityOIDCTokensParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewGetIdentityOIDCTokensParams() *GetIdentityOIDCTokensParams {
+	return &GetIdentityOIDCTokensParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetIdentityOIDCTokensParamsWithTimeout creates a new GetIdentityOIDCTokensParams object
+// with the ability to set a timeout on a request.
+func NewGetIdentityOIDCTokensParamsWithTimeout(timeout time.Duration) *GetIdentityOIDCTokensParams {
+	return &GetIdentityOIDCTokensParams{
+		timeout: timeout,
+	}
+}
+
+// NewGetIdentityOIDCTokensParamsWithContext creates a new GetIdentityOIDCTokensParams object
+// with the ability to set a context for a request.
+func NewGetIdentityOIDCTokensParamsWithContext(ctx context.Context) *GetIdentityOIDCTokensParams {
+	return &GetIdentityOIDCTokensParams{
+		Context: ctx,
+	}
+}
+
+// NewGetIdentityOIDCTokensParamsWithHTTPClient creates a new GetIdentityOIDCTokensParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewGetIdentityOIDCTokensParamsWithHTTPClient(client *http.Client) *GetIdentityOIDCTokensParams {
+	return &GetIdentityOIDCTokensParams{
+		HTTPClient: client,
+	}
+}
+
+/* GetIdentityOIDCTokensParams contains all the parameters to send to the API endpoint
+   for the get identity o ID c tokens operation.
+
+   Typically these are written to a http.Request.
+*/
+type GetIdentityOIDCTokensParams struct {
+
+	/* ID.
+
+	   ID is the identity's ID.
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the get identity o ID c tokens params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *GetIdentityOIDCTokensParams) WithDefaults() *GetIdentityOIDCTokensParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the get identity o ID c tokens params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *GetIdentityOIDCTokensParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) WithTimeout(timeout time.Duration) *GetIdentityOIDCTokensParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) WithContext(ctx context.Context) *GetIdentityOIDCTokensParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) WithHTTPClient(client *http.Client) *GetIdentityOIDCTokensParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithID adds the id to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) WithID(id string) *GetIdentityOIDCTokensParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the get identity o ID c tokens params
+func (o *GetIdentityOIDCTokensParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetIdentityOIDCTokensParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// GetIdentityOIDCTokensReader is a Reader for the GetIdentityOIDCTokens structure.
+type GetIdentityOIDCTokensReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetIdentityOIDCTokensReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetIdentityOIDCTokensOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 404:
+		result := NewGetIdentityOIDCTokensNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetIdentityOIDCTokensInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewGetIdentityOIDCTokensOK creates a GetIdentityOIDCTokensOK with default headers values
+func NewGetIdentityOIDCTokensOK() *GetIdentityOIDCTokensOK {
+	return &GetIdentityOIDCTokensOK{}
+}
+
+/* GetIdentityOIDCTokensOK describes a response with status code 200, with default header values.
+
+A list of upstream OAuth2 tokens.
+*/
+type GetIdentityOIDCTokensOK struct {
+	Payload []*models.OidcProviderToken
+}
+
+func (o *GetIdentityOIDCTokensOK) Error() string {
+	return fmt.Sprintf("[GET /identities/{id}/credentials/oidc/tokens][%d] getIdentityOIdCTokensOK  %+v", 200, o.Payload)
+}
+func (o *GetIdentityOIDCTokensOK) GetPayload() []*models.OidcProviderToken {
+	return o.Payload
+}
+
+func (o *GetIdentityOIDCTokensOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetIdentityOIDCTokensNotFound creates a GetIdentityOIDCTokensNotFound with default headers values
+func NewGetIdentityOIDCTokensNotFound() *GetIdentityOIDCTokensNotFound {
+	return &GetIdentityOIDCTokensNotFound{}
+}
+
+/* GetIdentityOIDCTokensNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type GetIdentityOIDCTokensNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *GetIdentityOIDCTokensNotFound) Error() string {
+	return fmt.Sprintf("[GET /identities/{id}/credentials/oidc/tokens][%d] getIdentityOIdCTokensNotFound  %+v", 404, o.Payload)
+}
+func (o *GetIdentityOIDCTokensNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *GetIdentityOIDCTokensNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetIdentityOIDCTokensInternalServerError creates a GetIdentityOIDCTokensInternalServerError with default headers values
+func NewGetIdentityOIDCTokensInternalServerError() *GetIdentityOIDCTokensInternalServerError {
+	return &GetIdentityOIDCTokensInternalServerError{}
+}
+
+/* GetIdentityOIDCTokensInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type GetIdentityOIDCTokensInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *GetIdentityOIDCTokensInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /identities/{id}/credentials/oidc/tokens][%d] getIdentityOIdCTokensInternalServerError  %+v", 500, o.Payload)
+}
+func (o *GetIdentityOIDCTokensInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *GetIdentityOIDCTokensInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetOIDCProviderParams creates a new GetOIDCProviderParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewGetOIDCProviderParams() *GetOIDCProviderParams {
+	return &GetOIDCProviderParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetOIDCProviderParamsWithTimeout creates a new GetOIDCProviderParams object
+// with the ability to set a timeout on a request.
+func NewGetOIDCProviderParamsWithTimeout(timeout time.Duration) *GetOIDCProviderParams {
+	return &GetOIDCProviderParams{
+		timeout: timeout,
+	}
+}
+
+// NewGetOIDCProviderParamsWithContext creates a new GetOIDCProviderParams object
+// with the ability to set a context for a request.
+func NewGetOIDCProviderParamsWithContext(ctx context.Context) *GetOIDCProviderParams {
+	return &GetOIDCProviderParams{
+		Context: ctx,
+	}
+}
+
+// NewGetOIDCProviderParamsWithHTTPClient creates a new GetOIDCProviderParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewGetOIDCProviderParamsWithHTTPClient(client *http.Client) *GetOIDCProviderParams {
+	return &GetOIDCProviderParams{
+		HTTPClient: client,
+	}
+}
+
+/* GetOIDCProviderParams contains all the parameters to send to the API endpoint
+   for the get o ID c provider operation.
+
+   Typically these are written to a http.Request.
+*/
+type GetOIDCProviderParams struct {
+
+	/* ID.
+
+	   ID is the provider's ID.
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the get o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *GetOIDCProviderParams) WithDefaults() *GetOIDCProviderParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the get o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *GetOIDCProviderParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the get o ID c provider params
+func (o *GetOIDCProviderParams) WithTimeout(timeout time.Duration) *GetOIDCProviderParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get o ID c provider params
+func (o *GetOIDCProviderParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get o ID c provider params
+func (o *GetOIDCProviderParams) WithContext(ctx context.Context) *GetOIDCProviderParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get o ID c provider params
+func (o *GetOIDCProviderParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get o ID c provider params
+func (o *GetOIDCProviderParams) WithHTTPClient(client *http.Client) *GetOIDCProviderParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get o ID c provider params
+func (o *GetOIDCProviderParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithID adds the id to the get o ID c provider params
+func (o *GetOIDCProviderParams) WithID(id string) *GetOIDCProviderParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the get o ID c provider params
+func (o *GetOIDCProviderParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetOIDCProviderParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// GetOIDCProviderReader is a Reader for the GetOIDCProvider structure.
+type GetOIDCProviderReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetOIDCProviderReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetOIDCProviderOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 404:
+		result := NewGetOIDCProviderNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetOIDCProviderInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewGetOIDCProviderOK creates a GetOIDCProviderOK with default headers values
+func NewGetOIDCProviderOK() *GetOIDCProviderOK {
+	return &GetOIDCProviderOK{}
+}
+
+/* GetOIDCProviderOK describes a response with status code 200, with default header values.
+
+A single OpenID Connect Provider.
+*/
+type GetOIDCProviderOK struct {
+	Payload *models.OidcProvider
+}
+
+func (o *GetOIDCProviderOK) Error() string {
+	return fmt.Sprintf("[GET /oidc/providers/{id}][%d] getOIdCProviderOK  %+v", 200, o.Payload)
+}
+func (o *GetOIDCProviderOK) GetPayload() *models.OidcProvider {
+	return o.Payload
+}
+
+func (o *GetOIDCProviderOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.OidcProvider)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetOIDCProviderNotFound creates a GetOIDCProviderNotFound with default headers values
+func NewGetOIDCProviderNotFound() *GetOIDCProviderNotFound {
+	return &GetOIDCProviderNotFound{}
+}
+
+/* GetOIDCProviderNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type GetOIDCProviderNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *GetOIDCProviderNotFound) Error() string {
+	return fmt.Sprintf("[GET /oidc/providers/{id}][%d] getOIdCProviderNotFound  %+v", 404, o.Payload)
+}
+func (o *GetOIDCProviderNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *GetOIDCProviderNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetOIDCProviderInternalServerError creates a GetOIDCProviderInternalServerError with default headers values
+func NewGetOIDCProviderInternalServerError() *GetOIDCProviderInternalServerError {
+	return &GetOIDCProviderInternalServerError{}
+}
+
+/* GetOIDCProviderInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type GetOIDCProviderInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *GetOIDCProviderInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /oidc/providers/{id}][%d] getOIdCProviderInternalServerError  %+v", 500, o.Payload)
+}
+func (o *GetOIDCProviderInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *GetOIDCProviderInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
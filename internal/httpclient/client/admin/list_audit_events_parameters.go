@@ -0,0 +1,289 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListAuditEventsParams creates a new ListAuditEventsParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewListAuditEventsParams() *ListAuditEventsParams {
+	return &ListAuditEventsParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListAuditEventsParamsWithTimeout creates a new ListAuditEventsParams object
+// with the ability to set a timeout on a request.
+func NewListAuditEventsParamsWithTimeout(timeout time.Duration) *ListAuditEventsParams {
+	return &ListAuditEventsParams{
+		timeout: timeout,
+	}
+}
+
+// NewListAuditEventsParamsWithContext creates a new ListAuditEventsParams object
+// with the ability to set a context for a request.
+func NewListAuditEventsParamsWithContext(ctx context.Context) *ListAuditEventsParams {
+	return &ListAuditEventsParams{
+		Context: ctx,
+	}
+}
+
+// NewListAuditEventsParamsWithHTTPClient creates a new ListAuditEventsParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewListAuditEventsParamsWithHTTPClient(client *http.Client) *ListAuditEventsParams {
+	return &ListAuditEventsParams{
+		HTTPClient: client,
+	}
+}
+
+/* ListAuditEventsParams contains all the parameters to send to the API endpoint
+   for the list audit events operation.
+
+   Typically these are written to a http.Request.
+*/
+type ListAuditEventsParams struct {
+
+	/* IdentityID.
+
+	     Identity ID
+
+	Only lists events of this identity.
+	*/
+	IdentityID *string
+
+	/* Page.
+
+	   Pagination Page
+
+	   Format: int64
+	*/
+	Page *int64
+
+	/* PerPage.
+
+	     Items per Page
+
+	This is the number of items per page.
+
+	     Format: int64
+	     Default: 100
+	*/
+	PerPage *int64
+
+	/* Type.
+
+	     Event Type
+
+	Only lists events of this type (e.g. `login`).
+	*/
+	Type *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the list audit events params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListAuditEventsParams) WithDefaults() *ListAuditEventsParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the list audit events params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListAuditEventsParams) SetDefaults() {
+	var (
+		pageDefault = int64(0)
+
+		perPageDefault = int64(100)
+	)
+
+	val := ListAuditEventsParams{
+		Page:    &pageDefault,
+		PerPage: &perPageDefault,
+	}
+
+	val.timeout = o.timeout
+	val.Context = o.Context
+	val.HTTPClient = o.HTTPClient
+	*o = val
+}
+
+// WithTimeout adds the timeout to the list audit events params
+func (o *ListAuditEventsParams) WithTimeout(timeout time.Duration) *ListAuditEventsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list audit events params
+func (o *ListAuditEventsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list audit events params
+func (o *ListAuditEventsParams) WithContext(ctx context.Context) *ListAuditEventsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list audit events params
+func (o *ListAuditEventsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list audit events params
+func (o *ListAuditEventsParams) WithHTTPClient(client *http.Client) *ListAuditEventsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list audit events params
+func (o *ListAuditEventsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithIdentityID adds the identityID to the list audit events params
+func (o *ListAuditEventsParams) WithIdentityID(identityID *string) *ListAuditEventsParams {
+	o.SetIdentityID(identityID)
+	return o
+}
+
+// SetIdentityID adds the identityId to the list audit events params
+func (o *ListAuditEventsParams) SetIdentityID(identityID *string) {
+	o.IdentityID = identityID
+}
+
+// WithPage adds the page to the list audit events params
+func (o *ListAuditEventsParams) WithPage(page *int64) *ListAuditEventsParams {
+	o.SetPage(page)
+	return o
+}
+
+// SetPage adds the page to the list audit events params
+func (o *ListAuditEventsParams) SetPage(page *int64) {
+	o.Page = page
+}
+
+// WithPerPage adds the perPage to the list audit events params
+func (o *ListAuditEventsParams) WithPerPage(perPage *int64) *ListAuditEventsParams {
+	o.SetPerPage(perPage)
+	return o
+}
+
+// SetPerPage adds the perPage to the list audit events params
+func (o *ListAuditEventsParams) SetPerPage(perPage *int64) {
+	o.PerPage = perPage
+}
+
+// WithType adds the typeVar to the list audit events params
+func (o *ListAuditEventsParams) WithType(typeVar *string) *ListAuditEventsParams {
+	o.SetType(typeVar)
+	return o
+}
+
+// SetType adds the type to the list audit events params
+func (o *ListAuditEventsParams) SetType(typeVar *string) {
+	o.Type = typeVar
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListAuditEventsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.IdentityID != nil {
+
+		// query param identity_id
+		var qrIdentityID string
+
+		if o.IdentityID != nil {
+			qrIdentityID = *o.IdentityID
+		}
+		qIdentityID := qrIdentityID
+		if qIdentityID != "" {
+
+			if err := r.SetQueryParam("identity_id", qIdentityID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Page != nil {
+
+		// query param page
+		var qrPage int64
+
+		if o.Page != nil {
+			qrPage = *o.Page
+		}
+		qPage := swag.FormatInt64(qrPage)
+		if qPage != "" {
+
+			if err := r.SetQueryParam("page", qPage); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.PerPage != nil {
+
+		// query param per_page
+		var qrPerPage int64
+
+		if o.PerPage != nil {
+			qrPerPage = *o.PerPage
+		}
+		qPerPage := swag.FormatInt64(qrPerPage)
+		if qPerPage != "" {
+
+			if err := r.SetQueryParam("per_page", qPerPage); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Type != nil {
+
+		// query param type
+		var qrType string
+
+		if o.Type != nil {
+			qrType = *o.Type
+		}
+		qType := qrType
+		if qType != "" {
+
+			if err := r.SetQueryParam("type", qType); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// ListAuditEventsReader is a Reader for the ListAuditEvents structure.
+type ListAuditEventsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListAuditEventsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListAuditEventsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewListAuditEventsInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewListAuditEventsOK creates a ListAuditEventsOK with default headers values
+func NewListAuditEventsOK() *ListAuditEventsOK {
+	return &ListAuditEventsOK{}
+}
+
+/* ListAuditEventsOK describes a response with status code 200, with default header values.
+
+A list of audit events.
+*/
+type ListAuditEventsOK struct {
+	Payload []*models.AuditEvent
+}
+
+func (o *ListAuditEventsOK) Error() string {
+	return fmt.Sprintf("[GET /audit/events][%d] listAuditEventsOK  %+v", 200, o.Payload)
+}
+func (o *ListAuditEventsOK) GetPayload() []*models.AuditEvent {
+	return o.Payload
+}
+
+func (o *ListAuditEventsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListAuditEventsInternalServerError creates a ListAuditEventsInternalServerError with default headers values
+func NewListAuditEventsInternalServerError() *ListAuditEventsInternalServerError {
+	return &ListAuditEventsInternalServerError{}
+}
+
+/* ListAuditEventsInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type ListAuditEventsInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *ListAuditEventsInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /audit/events][%d] listAuditEventsInternalServerError  %+v", 500, o.Payload)
+}
+func (o *ListAuditEventsInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ListAuditEventsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
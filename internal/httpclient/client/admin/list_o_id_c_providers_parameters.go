@@ -0,0 +1,126 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewListOIDCProvidersParams creates a new ListOIDCProvidersParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewListOIDCProvidersParams() *ListOIDCProvidersParams {
+	return &ListOIDCProvidersParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListOIDCProvidersParamsWithTimeout creates a new ListOIDCProvidersParams object
+// with the ability to set a timeout on a request.
+func NewListOIDCProvidersParamsWithTimeout(timeout time.Duration) *ListOIDCProvidersParams {
+	return &ListOIDCProvidersParams{
+		timeout: timeout,
+	}
+}
+
+// NewListOIDCProvidersParamsWithContext creates a new ListOIDCProvidersParams object
+// with the ability to set a context for a request.
+func NewListOIDCProvidersParamsWithContext(ctx context.Context) *ListOIDCProvidersParams {
+	return &ListOIDCProvidersParams{
+		Context: ctx,
+	}
+}
+
+// NewListOIDCProvidersParamsWithHTTPClient creates a new ListOIDCProvidersParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewListOIDCProvidersParamsWithHTTPClient(client *http.Client) *ListOIDCProvidersParams {
+	return &ListOIDCProvidersParams{
+		HTTPClient: client,
+	}
+}
+
+/* ListOIDCProvidersParams contains all the parameters to send to the API endpoint
+   for the list o ID c providers operation.
+
+   Typically these are written to a http.Request.
+*/
+type ListOIDCProvidersParams struct {
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the list o ID c providers params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListOIDCProvidersParams) WithDefaults() *ListOIDCProvidersParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the list o ID c providers params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListOIDCProvidersParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the list o ID c providers params
+func (o *ListOIDCProvidersParams) WithTimeout(timeout time.Duration) *ListOIDCProvidersParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list o ID c providers params
+func (o *ListOIDCProvidersParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list o ID c providers params
+func (o *ListOIDCProvidersParams) WithContext(ctx context.Context) *ListOIDCProvidersParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list o ID c providers params
+func (o *ListOIDCProvidersParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list o ID c providers params
+func (o *ListOIDCProvidersParams) WithHTTPClient(client *http.Client) *ListOIDCProvidersParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list o ID c providers params
+func (o *ListOIDCProvidersParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListOIDCProvidersParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// ListOIDCProvidersReader is a Reader for the ListOIDCProviders structure.
+type ListOIDCProvidersReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListOIDCProvidersReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListOIDCProvidersOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewListOIDCProvidersInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewListOIDCProvidersOK creates a ListOIDCProvidersOK with default headers values
+func NewListOIDCProvidersOK() *ListOIDCProvidersOK {
+	return &ListOIDCProvidersOK{}
+}
+
+/* ListOIDCProvidersOK describes a response with status code 200, with default header values.
+
+A list of OpenID Connect Providers.
+*/
+type ListOIDCProvidersOK struct {
+	Payload []*models.OidcProvider
+}
+
+func (o *ListOIDCProvidersOK) Error() string {
+	return fmt.Sprintf("[GET /oidc/providers][%d] listOIdCProvidersOK  %+v", 200, o.Payload)
+}
+func (o *ListOIDCProvidersOK) GetPayload() []*models.OidcProvider {
+	return o.Payload
+}
+
+func (o *ListOIDCProvidersOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListOIDCProvidersInternalServerError creates a ListOIDCProvidersInternalServerError with default headers values
+func NewListOIDCProvidersInternalServerError() *ListOIDCProvidersInternalServerError {
+	return &ListOIDCProvidersInternalServerError{}
+}
+
+/* ListOIDCProvidersInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type ListOIDCProvidersInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *ListOIDCProvidersInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /oidc/providers][%d] listOIdCProvidersInternalServerError  %+v", 500, o.Payload)
+}
+func (o *ListOIDCProvidersInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ListOIDCProvidersInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
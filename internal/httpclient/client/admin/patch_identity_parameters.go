@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// NewPatchIdentityParams creates a new PatchIdentityParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewPatchIdentityParams() *PatchIdentityParams {
+	return &PatchIdentityParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewPatchIdentityParamsWithTimeout creates a new PatchIdentityParams object
+// with the ability to set a timeout on a request.
+func NewPatchIdentityParamsWithTimeout(timeout time.Duration) *PatchIdentityParams {
+	return &PatchIdentityParams{
+		timeout: timeout,
+	}
+}
+
+// NewPatchIdentityParamsWithContext creates a new PatchIdentityParams object
+// with the ability to set a context for a request.
+func NewPatchIdentityParamsWithContext(ctx context.Context) *PatchIdentityParams {
+	return &PatchIdentityParams{
+		Context: ctx,
+	}
+}
+
+// NewPatchIdentityParamsWithHTTPClient creates a new PatchIdentityParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewPatchIdentityParamsWithHTTPClient(client *http.Client) *PatchIdentityParams {
+	return &PatchIdentityParams{
+		HTTPClient: client,
+	}
+}
+
+/* PatchIdentityParams contains all the parameters to send to the API endpoint
+   for the patch identity operation.
+
+   Typically these are written to a http.Request.
+*/
+type PatchIdentityParams struct {
+
+	// Body.
+	Body models.JSONPatchDocument
+
+	/* ID.
+
+	   ID must be set to the ID of identity you want to patch
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the patch identity params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PatchIdentityParams) WithDefaults() *PatchIdentityParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the patch identity params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PatchIdentityParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the patch identity params
+func (o *PatchIdentityParams) WithTimeout(timeout time.Duration) *PatchIdentityParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the patch identity params
+func (o *PatchIdentityParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the patch identity params
+func (o *PatchIdentityParams) WithContext(ctx context.Context) *PatchIdentityParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the patch identity params
+func (o *PatchIdentityParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the patch identity params
+func (o *PatchIdentityParams) WithHTTPClient(client *http.Client) *PatchIdentityParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the patch identity params
+func (o *PatchIdentityParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the patch identity params
+func (o *PatchIdentityParams) WithBody(body models.JSONPatchDocument) *PatchIdentityParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the patch identity params
+func (o *PatchIdentityParams) SetBody(body models.JSONPatchDocument) {
+	o.Body = body
+}
+
+// WithID adds the id to the patch identity params
+func (o *PatchIdentityParams) WithID(id string) *PatchIdentityParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the patch identity params
+func (o *PatchIdentityParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *PatchIdentityParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
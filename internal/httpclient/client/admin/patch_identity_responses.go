@@ -0,0 +1,181 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// PatchIdentityReader is a Reader for the PatchIdentity structure.
+type PatchIdentityReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *PatchIdentityReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewPatchIdentityOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewPatchIdentityBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewPatchIdentityNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewPatchIdentityInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewPatchIdentityOK creates a PatchIdentityOK with default headers values
+func NewPatchIdentityOK() *PatchIdentityOK {
+	return &PatchIdentityOK{}
+}
+
+/* PatchIdentityOK describes a response with status code 200, with default header values.
+
+A single identity.
+*/
+type PatchIdentityOK struct {
+	Payload *models.Identity
+}
+
+func (o *PatchIdentityOK) Error() string {
+	return fmt.Sprintf("[PATCH /identities/{id}][%d] patchIdentityOK  %+v", 200, o.Payload)
+}
+func (o *PatchIdentityOK) GetPayload() *models.Identity {
+	return o.Payload
+}
+
+func (o *PatchIdentityOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Identity)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewPatchIdentityBadRequest creates a PatchIdentityBadRequest with default headers values
+func NewPatchIdentityBadRequest() *PatchIdentityBadRequest {
+	return &PatchIdentityBadRequest{}
+}
+
+/* PatchIdentityBadRequest describes a response with status code 400, with default header values.
+
+genericError
+*/
+type PatchIdentityBadRequest struct {
+	Payload *models.GenericError
+}
+
+func (o *PatchIdentityBadRequest) Error() string {
+	return fmt.Sprintf("[PATCH /identities/{id}][%d] patchIdentityBadRequest  %+v", 400, o.Payload)
+}
+func (o *PatchIdentityBadRequest) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *PatchIdentityBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewPatchIdentityNotFound creates a PatchIdentityNotFound with default headers values
+func NewPatchIdentityNotFound() *PatchIdentityNotFound {
+	return &PatchIdentityNotFound{}
+}
+
+/* PatchIdentityNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type PatchIdentityNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *PatchIdentityNotFound) Error() string {
+	return fmt.Sprintf("[PATCH /identities/{id}][%d] patchIdentityNotFound  %+v", 404, o.Payload)
+}
+func (o *PatchIdentityNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *PatchIdentityNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewPatchIdentityInternalServerError creates a PatchIdentityInternalServerError with default headers values
+func NewPatchIdentityInternalServerError() *PatchIdentityInternalServerError {
+	return &PatchIdentityInternalServerError{}
+}
+
+/* PatchIdentityInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type PatchIdentityInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *PatchIdentityInternalServerError) Error() string {
+	return fmt.Sprintf("[PATCH /identities/{id}][%d] patchIdentityInternalServerError  %+v", 500, o.Payload)
+}
+func (o *PatchIdentityInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *PatchIdentityInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewUnlockIdentityParams creates a new UnlockIdentityParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewUnlockIdentityParams() *UnlockIdentityParams {
+	return &UnlockIdentityParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewUnlockIdentityParamsWithTimeout creates a new UnlockIdentityParams object
+// with the ability to set a timeout on a request.
+func NewUnlockIdentityParamsWithTimeout(timeout time.Duration) *UnlockIdentityParams {
+	return &UnlockIdentityParams{
+		timeout: timeout,
+	}
+}
+
+// NewUnlockIdentityParamsWithContext creates a new UnlockIdentityParams object
+// with the ability to set a context for a request.
+func NewUnlockIdentityParamsWithContext(ctx context.Context) *UnlockIdentityParams {
+	return &UnlockIdentityParams{
+		Context: ctx,
+	}
+}
+
+// NewUnlockIdentityParamsWithHTTPClient creates a new UnlockIdentityParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewUnlockIdentityParamsWithHTTPClient(client *http.Client) *UnlockIdentityParams {
+	return &UnlockIdentityParams{
+		HTTPClient: client,
+	}
+}
+
+/* UnlockIdentityParams contains all the parameters to send to the API endpoint
+   for the unlock identity operation.
+
+   Typically these are written to a http.Request.
+*/
+type UnlockIdentityParams struct {
+
+	/* ID.
+
+	   ID is the identity's ID.
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the unlock identity params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UnlockIdentityParams) WithDefaults() *UnlockIdentityParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the unlock identity params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UnlockIdentityParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the unlock identity params
+func (o *UnlockIdentityParams) WithTimeout(timeout time.Duration) *UnlockIdentityParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the unlock identity params
+func (o *UnlockIdentityParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the unlock identity params
+func (o *UnlockIdentityParams) WithContext(ctx context.Context) *UnlockIdentityParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the unlock identity params
+func (o *UnlockIdentityParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the unlock identity params
+func (o *UnlockIdentityParams) WithHTTPClient(client *http.Client) *UnlockIdentityParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the unlock identity params
+func (o *UnlockIdentityParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithID adds the id to the unlock identity params
+func (o *UnlockIdentityParams) WithID(id string) *UnlockIdentityParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the unlock identity params
+func (o *UnlockIdentityParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *UnlockIdentityParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// UnlockIdentityReader is a Reader for the UnlockIdentity structure.
+type UnlockIdentityReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *UnlockIdentityReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 204:
+		result := NewUnlockIdentityNoContent()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 404:
+		result := NewUnlockIdentityNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewUnlockIdentityInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewUnlockIdentityNoContent creates a UnlockIdentityNoContent with default headers values
+func NewUnlockIdentityNoContent() *UnlockIdentityNoContent {
+	return &UnlockIdentityNoContent{}
+}
+
+/* UnlockIdentityNoContent describes a response with status code 204, with default header values.
+
+Empty responses are sent when, for example, resources are deleted. The HTTP status code for empty responses is typically 201.
+*/
+type UnlockIdentityNoContent struct {
+}
+
+func (o *UnlockIdentityNoContent) Error() string {
+	return fmt.Sprintf("[DELETE /identities/{id}/lockout][%d] unlockIdentityNoContent ", 204)
+}
+
+func (o *UnlockIdentityNoContent) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewUnlockIdentityNotFound creates a UnlockIdentityNotFound with default headers values
+func NewUnlockIdentityNotFound() *UnlockIdentityNotFound {
+	return &UnlockIdentityNotFound{}
+}
+
+/* UnlockIdentityNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type UnlockIdentityNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *UnlockIdentityNotFound) Error() string {
+	return fmt.Sprintf("[DELETE /identities/{id}/lockout][%d] unlockIdentityNotFound  %+v", 404, o.Payload)
+}
+func (o *UnlockIdentityNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UnlockIdentityNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUnlockIdentityInternalServerError creates a UnlockIdentityInternalServerError with default headers values
+func NewUnlockIdentityInternalServerError() *UnlockIdentityInternalServerError {
+	return &UnlockIdentityInternalServerError{}
+}
+
+/* UnlockIdentityInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type UnlockIdentityInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *UnlockIdentityInternalServerError) Error() string {
+	return fmt.Sprintf("[DELETE /identities/{id}/lockout][%d] unlockIdentityInternalServerError  %+v", 500, o.Payload)
+}
+func (o *UnlockIdentityInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UnlockIdentityInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// NewUpdateOIDCProviderParams creates a new UpdateOIDCProviderParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewUpdateOIDCProviderParams() *UpdateOIDCProviderParams {
+	return &UpdateOIDCProviderParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewUpdateOIDCProviderParamsWithTimeout creates a new UpdateOIDCProviderParams object
+// with the ability to set a timeout on a request.
+func NewUpdateOIDCProviderParamsWithTimeout(timeout time.Duration) *UpdateOIDCProviderParams {
+	return &UpdateOIDCProviderParams{
+		timeout: timeout,
+	}
+}
+
+// NewUpdateOIDCProviderParamsWithContext creates a new UpdateOIDCProviderParams object
+// with the ability to set a context for a request.
+func NewUpdateOIDCProviderParamsWithContext(ctx context.Context) *UpdateOIDCProviderParams {
+	return &UpdateOIDCProviderParams{
+		Context: ctx,
+	}
+}
+
+// NewUpdateOIDCProviderParamsWithHTTPClient creates a new UpdateOIDCProviderParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewUpdateOIDCProviderParamsWithHTTPClient(client *http.Client) *UpdateOIDCProviderParams {
+	return &UpdateOIDCProviderParams{
+		HTTPClient: client,
+	}
+}
+
+/* UpdateOIDCProviderParams contains all the parameters to send to the API endpoint
+   for the update o ID c provider operation.
+
+   Typically these are written to a http.Request.
+*/
+type UpdateOIDCProviderParams struct {
+
+	// Body.
+	Body *models.OidcProvider
+
+	/* ID.
+
+	   ID is the provider's ID.
+	*/
+	ID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the update o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UpdateOIDCProviderParams) WithDefaults() *UpdateOIDCProviderParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the update o ID c provider params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UpdateOIDCProviderParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) WithTimeout(timeout time.Duration) *UpdateOIDCProviderParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) WithContext(ctx context.Context) *UpdateOIDCProviderParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) WithHTTPClient(client *http.Client) *UpdateOIDCProviderParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) WithBody(body *models.OidcProvider) *UpdateOIDCProviderParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) SetBody(body *models.OidcProvider) {
+	o.Body = body
+}
+
+// WithID adds the id to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) WithID(id string) *UpdateOIDCProviderParams {
+	o.SetID(id)
+	return o
+}
+
+// SetID adds the id to the update o ID c provider params
+func (o *UpdateOIDCProviderParams) SetID(id string) {
+	o.ID = id
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *UpdateOIDCProviderParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	// path param id
+	if err := r.SetPathParam("id", o.ID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package admin
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// UpdateOIDCProviderReader is a Reader for the UpdateOIDCProvider structure.
+type UpdateOIDCProviderReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *UpdateOIDCProviderReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewUpdateOIDCProviderOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewUpdateOIDCProviderBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewUpdateOIDCProviderNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewUpdateOIDCProviderInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewUpdateOIDCProviderOK creates a UpdateOIDCProviderOK with default headers values
+func NewUpdateOIDCProviderOK() *UpdateOIDCProviderOK {
+	return &UpdateOIDCProviderOK{}
+}
+
+/* UpdateOIDCProviderOK describes a response with status code 200, with default header values.
+
+A single OpenID Connect Provider.
+*/
+type UpdateOIDCProviderOK struct {
+	Payload *models.OidcProvider
+}
+
+func (o *UpdateOIDCProviderOK) Error() string {
+	return fmt.Sprintf("[PUT /oidc/providers/{id}][%d] updateOIdCProviderOK  %+v", 200, o.Payload)
+}
+func (o *UpdateOIDCProviderOK) GetPayload() *models.OidcProvider {
+	return o.Payload
+}
+
+func (o *UpdateOIDCProviderOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.OidcProvider)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateOIDCProviderBadRequest creates a UpdateOIDCProviderBadRequest with default headers values
+func NewUpdateOIDCProviderBadRequest() *UpdateOIDCProviderBadRequest {
+	return &UpdateOIDCProviderBadRequest{}
+}
+
+/* UpdateOIDCProviderBadRequest describes a response with status code 400, with default header values.
+
+genericError
+*/
+type UpdateOIDCProviderBadRequest struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateOIDCProviderBadRequest) Error() string {
+	return fmt.Sprintf("[PUT /oidc/providers/{id}][%d] updateOIdCProviderBadRequest  %+v", 400, o.Payload)
+}
+func (o *UpdateOIDCProviderBadRequest) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateOIDCProviderBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateOIDCProviderNotFound creates a UpdateOIDCProviderNotFound with default headers values
+func NewUpdateOIDCProviderNotFound() *UpdateOIDCProviderNotFound {
+	return &UpdateOIDCProviderNotFound{}
+}
+
+/* UpdateOIDCProviderNotFound describes a response with status code 404, with default header values.
+
+genericError
+*/
+type UpdateOIDCProviderNotFound struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateOIDCProviderNotFound) Error() string {
+	return fmt.Sprintf("[PUT /oidc/providers/{id}][%d] updateOIdCProviderNotFound  %+v", 404, o.Payload)
+}
+func (o *UpdateOIDCProviderNotFound) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateOIDCProviderNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateOIDCProviderInternalServerError creates a UpdateOIDCProviderInternalServerError with default headers values
+func NewUpdateOIDCProviderInternalServerError() *UpdateOIDCProviderInternalServerError {
+	return &UpdateOIDCProviderInternalServerError{}
+}
+
+/* UpdateOIDCProviderInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type UpdateOIDCProviderInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *UpdateOIDCProviderInternalServerError) Error() string {
+	return fmt.Sprintf("[PUT /oidc/providers/{id}][%d] updateOIdCProviderInternalServerError  %+v", 500, o.Payload)
+}
+func (o *UpdateOIDCProviderInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *UpdateOIDCProviderInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
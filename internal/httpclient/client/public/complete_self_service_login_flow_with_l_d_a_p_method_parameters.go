@@ -0,0 +1,175 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package public
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodParams creates a new CompleteSelfServiceLoginFlowWithLDAPMethodParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodParams() *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodParamsWithTimeout creates a new CompleteSelfServiceLoginFlowWithLDAPMethodParams object
+// with the ability to set a timeout on a request.
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodParamsWithTimeout(timeout time.Duration) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodParams{
+		timeout: timeout,
+	}
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodParamsWithContext creates a new CompleteSelfServiceLoginFlowWithLDAPMethodParams object
+// with the ability to set a context for a request.
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodParamsWithContext(ctx context.Context) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodParams{
+		Context: ctx,
+	}
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodParamsWithHTTPClient creates a new CompleteSelfServiceLoginFlowWithLDAPMethodParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodParamsWithHTTPClient(client *http.Client) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodParams{
+		HTTPClient: client,
+	}
+}
+
+/* CompleteSelfServiceLoginFlowWithLDAPMethodParams contains all the parameters to send to the API endpoint
+   for the complete self service login flow with l d a p method operation.
+
+   Typically these are written to a http.Request.
+*/
+type CompleteSelfServiceLoginFlowWithLDAPMethodParams struct {
+
+	// Body.
+	Body *models.CompleteSelfServiceLoginFlowWithLDAPMethod
+
+	/* Flow.
+
+	   The Flow ID
+	*/
+	Flow string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the complete self service login flow with l d a p method params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WithDefaults() *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the complete self service login flow with l d a p method params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WithTimeout(timeout time.Duration) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WithContext(ctx context.Context) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WithHTTPClient(client *http.Client) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WithBody(body *models.CompleteSelfServiceLoginFlowWithLDAPMethod) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) SetBody(body *models.CompleteSelfServiceLoginFlowWithLDAPMethod) {
+	o.Body = body
+}
+
+// WithFlow adds the flow to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WithFlow(flow string) *CompleteSelfServiceLoginFlowWithLDAPMethodParams {
+	o.SetFlow(flow)
+	return o
+}
+
+// SetFlow adds the flow to the complete self service login flow with l d a p method params
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) SetFlow(flow string) {
+	o.Flow = flow
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	// query param flow
+	qrFlow := o.Flow
+	qFlow := qrFlow
+	if qFlow != "" {
+
+		if err := r.SetQueryParam("flow", qFlow); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
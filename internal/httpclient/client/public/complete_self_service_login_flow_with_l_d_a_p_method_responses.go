@@ -0,0 +1,170 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package public
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// CompleteSelfServiceLoginFlowWithLDAPMethodReader is a Reader for the CompleteSelfServiceLoginFlowWithLDAPMethod structure.
+type CompleteSelfServiceLoginFlowWithLDAPMethodReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewCompleteSelfServiceLoginFlowWithLDAPMethodOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 302:
+		result := NewCompleteSelfServiceLoginFlowWithLDAPMethodFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 400:
+		result := NewCompleteSelfServiceLoginFlowWithLDAPMethodBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewCompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodOK creates a CompleteSelfServiceLoginFlowWithLDAPMethodOK with default headers values
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodOK() *CompleteSelfServiceLoginFlowWithLDAPMethodOK {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodOK{}
+}
+
+/* CompleteSelfServiceLoginFlowWithLDAPMethodOK describes a response with status code 200, with default header values.
+
+loginViaApiResponse
+*/
+type CompleteSelfServiceLoginFlowWithLDAPMethodOK struct {
+	Payload *models.LoginViaAPIResponse
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodOK) Error() string {
+	return fmt.Sprintf("[POST /self-service/login/methods/ldap][%d] completeSelfServiceLoginFlowWithLDAPMethodOK  %+v", 200, o.Payload)
+}
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodOK) GetPayload() *models.LoginViaAPIResponse {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.LoginViaAPIResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodFound creates a CompleteSelfServiceLoginFlowWithLDAPMethodFound with default headers values
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodFound() *CompleteSelfServiceLoginFlowWithLDAPMethodFound {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodFound{}
+}
+
+/* CompleteSelfServiceLoginFlowWithLDAPMethodFound describes a response with status code 302, with default header values.
+
+Empty responses are sent when, for example, resources are deleted. The HTTP status code for empty responses is typically 201.
+*/
+type CompleteSelfServiceLoginFlowWithLDAPMethodFound struct {
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodFound) Error() string {
+	return fmt.Sprintf("[POST /self-service/login/methods/ldap][%d] completeSelfServiceLoginFlowWithLDAPMethodFound ", 302)
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodBadRequest creates a CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest with default headers values
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodBadRequest() *CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest{}
+}
+
+/* CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest describes a response with status code 400, with default header values.
+
+loginFlow
+*/
+type CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest struct {
+	Payload *models.LoginFlow
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest) Error() string {
+	return fmt.Sprintf("[POST /self-service/login/methods/ldap][%d] completeSelfServiceLoginFlowWithLDAPMethodBadRequest  %+v", 400, o.Payload)
+}
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest) GetPayload() *models.LoginFlow {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.LoginFlow)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError creates a CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError with default headers values
+func NewCompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError() *CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError {
+	return &CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError{}
+}
+
+/* CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /self-service/login/methods/ldap][%d] completeSelfServiceLoginFlowWithLDAPMethodInternalServerError  %+v", 500, o.Payload)
+}
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceLoginFlowWithLDAPMethodInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package public
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodParams creates a new CompleteSelfServiceSettingsFlowWithDeletionMethodParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodParams() *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodParamsWithTimeout creates a new CompleteSelfServiceSettingsFlowWithDeletionMethodParams object
+// with the ability to set a timeout on a request.
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodParamsWithTimeout(timeout time.Duration) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodParams{
+		timeout: timeout,
+	}
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodParamsWithContext creates a new CompleteSelfServiceSettingsFlowWithDeletionMethodParams object
+// with the ability to set a context for a request.
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodParamsWithContext(ctx context.Context) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodParams{
+		Context: ctx,
+	}
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodParamsWithHTTPClient creates a new CompleteSelfServiceSettingsFlowWithDeletionMethodParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodParamsWithHTTPClient(client *http.Client) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodParams{
+		HTTPClient: client,
+	}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodParams contains all the parameters to send to the API endpoint
+   for the complete self service settings flow with deletion method operation.
+
+   Typically these are written to a http.Request.
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodParams struct {
+
+	// Body.
+	Body *models.CompleteSelfServiceSettingsFlowWithDeletionMethod
+
+	/* Flow.
+
+	   Flow is flow ID.
+	*/
+	Flow *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the complete self service settings flow with deletion method params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WithDefaults() *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the complete self service settings flow with deletion method params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WithTimeout(timeout time.Duration) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WithContext(ctx context.Context) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WithHTTPClient(client *http.Client) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WithBody(body *models.CompleteSelfServiceSettingsFlowWithDeletionMethod) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) SetBody(body *models.CompleteSelfServiceSettingsFlowWithDeletionMethod) {
+	o.Body = body
+}
+
+// WithFlow adds the flow to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WithFlow(flow *string) *CompleteSelfServiceSettingsFlowWithDeletionMethodParams {
+	o.SetFlow(flow)
+	return o
+}
+
+// SetFlow adds the flow to the complete self service settings flow with deletion method params
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) SetFlow(flow *string) {
+	o.Flow = flow
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	if o.Flow != nil {
+
+		// query param flow
+		var qrFlow string
+
+		if o.Flow != nil {
+			qrFlow = *o.Flow
+		}
+		qFlow := qrFlow
+		if qFlow != "" {
+
+			if err := r.SetQueryParam("flow", qFlow); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package public
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// CompleteSelfServiceSettingsFlowWithDeletionMethodReader is a Reader for the CompleteSelfServiceSettingsFlowWithDeletionMethod structure.
+type CompleteSelfServiceSettingsFlowWithDeletionMethodReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 204:
+		result := NewCompleteSelfServiceSettingsFlowWithDeletionMethodNoContent()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 302:
+		result := NewCompleteSelfServiceSettingsFlowWithDeletionMethodFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 400:
+		result := NewCompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 401:
+		result := NewCompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 403:
+		result := NewCompleteSelfServiceSettingsFlowWithDeletionMethodForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewCompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodNoContent creates a CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent with default headers values
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodNoContent() *CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent{}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent describes a response with status code 204, with default header values.
+
+Empty responses are sent when, for example, resources are deleted. The HTTP status code for empty responses is typically 201.
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent struct {
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent) Error() string {
+	return fmt.Sprintf("[POST /self-service/settings/methods/deletion][%d] completeSelfServiceSettingsFlowWithDeletionMethodNoContent ", 204)
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodFound creates a CompleteSelfServiceSettingsFlowWithDeletionMethodFound with default headers values
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodFound() *CompleteSelfServiceSettingsFlowWithDeletionMethodFound {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodFound{}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodFound describes a response with status code 302, with default header values.
+
+Empty responses are sent when, for example, resources are deleted. The HTTP status code for empty responses is typically 201.
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodFound struct {
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodFound) Error() string {
+	return fmt.Sprintf("[POST /self-service/settings/methods/deletion][%d] completeSelfServiceSettingsFlowWithDeletionMethodFound ", 302)
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest creates a CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest with default headers values
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest() *CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest{}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest describes a response with status code 400, with default header values.
+
+settingsFlow
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest struct {
+	Payload *models.SettingsFlow
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest) Error() string {
+	return fmt.Sprintf("[POST /self-service/settings/methods/deletion][%d] completeSelfServiceSettingsFlowWithDeletionMethodBadRequest  %+v", 400, o.Payload)
+}
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest) GetPayload() *models.SettingsFlow {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.SettingsFlow)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized creates a CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized with default headers values
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized() *CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized{}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized describes a response with status code 401, with default header values.
+
+genericError
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized struct {
+	Payload *models.GenericError
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized) Error() string {
+	return fmt.Sprintf("[POST /self-service/settings/methods/deletion][%d] completeSelfServiceSettingsFlowWithDeletionMethodUnauthorized  %+v", 401, o.Payload)
+}
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodUnauthorized) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodForbidden creates a CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden with default headers values
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodForbidden() *CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden{}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden describes a response with status code 403, with default header values.
+
+genericError
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden struct {
+	Payload *models.GenericError
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden) Error() string {
+	return fmt.Sprintf("[POST /self-service/settings/methods/deletion][%d] completeSelfServiceSettingsFlowWithDeletionMethodForbidden  %+v", 403, o.Payload)
+}
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError creates a CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError with default headers values
+func NewCompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError() *CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError {
+	return &CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError{}
+}
+
+/* CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /self-service/settings/methods/deletion][%d] completeSelfServiceSettingsFlowWithDeletionMethodInternalServerError  %+v", 500, o.Payload)
+}
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *CompleteSelfServiceSettingsFlowWithDeletionMethodInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
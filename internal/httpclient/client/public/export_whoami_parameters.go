@@ -0,0 +1,183 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package public
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewExportWhoamiParams creates a new ExportWhoamiParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewExportWhoamiParams() *ExportWhoamiParams {
+	return &ExportWhoamiParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewExportWhoamiParamsWithTimeout creates a new ExportWhoamiParams object
+// with the ability to set a timeout on a request.
+func NewExportWhoamiParamsWithTimeout(timeout time.Duration) *ExportWhoamiParams {
+	return &ExportWhoamiParams{
+		timeout: timeout,
+	}
+}
+
+// NewExportWhoamiParamsWithContext creates a new ExportWhoamiParams object
+// with the ability to set a context for a request.
+func NewExportWhoamiParamsWithContext(ctx context.Context) *ExportWhoamiParams {
+	return &ExportWhoamiParams{
+		Context: ctx,
+	}
+}
+
+// NewExportWhoamiParamsWithHTTPClient creates a new ExportWhoamiParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewExportWhoamiParamsWithHTTPClient(client *http.Client) *ExportWhoamiParams {
+	return &ExportWhoamiParams{
+		HTTPClient: client,
+	}
+}
+
+/* ExportWhoamiParams contains all the parameters to send to the API endpoint
+   for the export whoami operation.
+
+   Typically these are written to a http.Request.
+*/
+type ExportWhoamiParams struct {
+
+	/* Authorization.
+
+	   in: authorization
+	*/
+	Authorization *string
+
+	// Cookie.
+	Cookie *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the export whoami params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ExportWhoamiParams) WithDefaults() *ExportWhoamiParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the export whoami params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ExportWhoamiParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the export whoami params
+func (o *ExportWhoamiParams) WithTimeout(timeout time.Duration) *ExportWhoamiParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the export whoami params
+func (o *ExportWhoamiParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the export whoami params
+func (o *ExportWhoamiParams) WithContext(ctx context.Context) *ExportWhoamiParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the export whoami params
+func (o *ExportWhoamiParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the export whoami params
+func (o *ExportWhoamiParams) WithHTTPClient(client *http.Client) *ExportWhoamiParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the export whoami params
+func (o *ExportWhoamiParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithAuthorization adds the authorization to the export whoami params
+func (o *ExportWhoamiParams) WithAuthorization(authorization *string) *ExportWhoamiParams {
+	o.SetAuthorization(authorization)
+	return o
+}
+
+// SetAuthorization adds the authorization to the export whoami params
+func (o *ExportWhoamiParams) SetAuthorization(authorization *string) {
+	o.Authorization = authorization
+}
+
+// WithCookie adds the cookie to the export whoami params
+func (o *ExportWhoamiParams) WithCookie(cookie *string) *ExportWhoamiParams {
+	o.SetCookie(cookie)
+	return o
+}
+
+// SetCookie adds the cookie to the export whoami params
+func (o *ExportWhoamiParams) SetCookie(cookie *string) {
+	o.Cookie = cookie
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ExportWhoamiParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Authorization != nil {
+
+		// query param Authorization
+		var qrAuthorization string
+
+		if o.Authorization != nil {
+			qrAuthorization = *o.Authorization
+		}
+		qAuthorization := qrAuthorization
+		if qAuthorization != "" {
+
+			if err := r.SetQueryParam("Authorization", qAuthorization); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Cookie != nil {
+
+		// header param Cookie
+		if err := r.SetHeaderParam("Cookie", *o.Cookie); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package public
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/ory/kratos-client-go/models"
+)
+
+// ExportWhoamiReader is a Reader for the ExportWhoami structure.
+type ExportWhoamiReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ExportWhoamiReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewExportWhoamiOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 401:
+		result := NewExportWhoamiUnauthorized()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 403:
+		result := NewExportWhoamiForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewExportWhoamiInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewExportWhoamiOK creates a ExportWhoamiOK with default headers values
+func NewExportWhoamiOK() *ExportWhoamiOK {
+	return &ExportWhoamiOK{}
+}
+
+/* ExportWhoamiOK describes a response with status code 200, with default header values.
+
+identityExportResponse
+*/
+type ExportWhoamiOK struct {
+	Payload *models.IdentityExport
+}
+
+func (o *ExportWhoamiOK) Error() string {
+	return fmt.Sprintf("[GET /sessions/whoami/export][%d] exportWhoamiOK  %+v", 200, o.Payload)
+}
+func (o *ExportWhoamiOK) GetPayload() *models.IdentityExport {
+	return o.Payload
+}
+
+func (o *ExportWhoamiOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.IdentityExport)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportWhoamiUnauthorized creates a ExportWhoamiUnauthorized with default headers values
+func NewExportWhoamiUnauthorized() *ExportWhoamiUnauthorized {
+	return &ExportWhoamiUnauthorized{}
+}
+
+/* ExportWhoamiUnauthorized describes a response with status code 401, with default header values.
+
+genericError
+*/
+type ExportWhoamiUnauthorized struct {
+	Payload *models.GenericError
+}
+
+func (o *ExportWhoamiUnauthorized) Error() string {
+	return fmt.Sprintf("[GET /sessions/whoami/export][%d] exportWhoamiUnauthorized  %+v", 401, o.Payload)
+}
+func (o *ExportWhoamiUnauthorized) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExportWhoamiUnauthorized) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportWhoamiForbidden creates a ExportWhoamiForbidden with default headers values
+func NewExportWhoamiForbidden() *ExportWhoamiForbidden {
+	return &ExportWhoamiForbidden{}
+}
+
+/* ExportWhoamiForbidden describes a response with status code 403, with default header values.
+
+genericError
+*/
+type ExportWhoamiForbidden struct {
+	Payload *models.GenericError
+}
+
+func (o *ExportWhoamiForbidden) Error() string {
+	return fmt.Sprintf("[GET /sessions/whoami/export][%d] exportWhoamiForbidden  %+v", 403, o.Payload)
+}
+func (o *ExportWhoamiForbidden) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExportWhoamiForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportWhoamiInternalServerError creates a ExportWhoamiInternalServerError with default headers values
+func NewExportWhoamiInternalServerError() *ExportWhoamiInternalServerError {
+	return &ExportWhoamiInternalServerError{}
+}
+
+/* ExportWhoamiInternalServerError describes a response with status code 500, with default header values.
+
+genericError
+*/
+type ExportWhoamiInternalServerError struct {
+	Payload *models.GenericError
+}
+
+func (o *ExportWhoamiInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /sessions/whoami/export][%d] exportWhoamiInternalServerError  %+v", 500, o.Payload)
+}
+func (o *ExportWhoamiInternalServerError) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *ExportWhoamiInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
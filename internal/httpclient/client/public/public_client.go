@@ -32,18 +32,24 @@ type ClientOption func(*runtime.ClientOperation)
 type ClientService interface {
 	CompleteSelfServiceBrowserSettingsOIDCSettingsFlow(params *CompleteSelfServiceBrowserSettingsOIDCSettingsFlowParams, opts ...ClientOption) error
 
+	CompleteSelfServiceLoginFlowWithLDAPMethod(params *CompleteSelfServiceLoginFlowWithLDAPMethodParams, opts ...ClientOption) (*CompleteSelfServiceLoginFlowWithLDAPMethodOK, error)
+
 	CompleteSelfServiceLoginFlowWithPasswordMethod(params *CompleteSelfServiceLoginFlowWithPasswordMethodParams, opts ...ClientOption) (*CompleteSelfServiceLoginFlowWithPasswordMethodOK, error)
 
 	CompleteSelfServiceRecoveryFlowWithLinkMethod(params *CompleteSelfServiceRecoveryFlowWithLinkMethodParams, opts ...ClientOption) error
 
 	CompleteSelfServiceRegistrationFlowWithPasswordMethod(params *CompleteSelfServiceRegistrationFlowWithPasswordMethodParams, opts ...ClientOption) (*CompleteSelfServiceRegistrationFlowWithPasswordMethodOK, error)
 
+	CompleteSelfServiceSettingsFlowWithDeletionMethod(params *CompleteSelfServiceSettingsFlowWithDeletionMethodParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent, error)
+
 	CompleteSelfServiceSettingsFlowWithPasswordMethod(params *CompleteSelfServiceSettingsFlowWithPasswordMethodParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CompleteSelfServiceSettingsFlowWithPasswordMethodOK, error)
 
 	CompleteSelfServiceSettingsFlowWithProfileMethod(params *CompleteSelfServiceSettingsFlowWithProfileMethodParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CompleteSelfServiceSettingsFlowWithProfileMethodOK, error)
 
 	CompleteSelfServiceVerificationFlowWithLinkMethod(params *CompleteSelfServiceVerificationFlowWithLinkMethodParams, opts ...ClientOption) error
 
+	ExportWhoami(params *ExportWhoamiParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ExportWhoamiOK, error)
+
 	GetSchema(params *GetSchemaParams, opts ...ClientOption) (*GetSchemaOK, error)
 
 	GetSelfServiceError(params *GetSelfServiceErrorParams, opts ...ClientOption) (*GetSelfServiceErrorOK, error)
@@ -125,6 +131,59 @@ func (a *Client) CompleteSelfServiceBrowserSettingsOIDCSettingsFlow(params *Comp
 	return nil
 }
 
+/*
+  CompleteSelfServiceLoginFlowWithLDAPMethod completes login flow with l d a p method
+
+  Use this endpoint to complete a login flow by sending the user's directory username and password. The identity
+is created or updated from the user's directory entry before the session is issued. This endpoint behaves
+differently for API and browser flows.
+
+API flows expect `application/json` to be sent in the body and responds with
+HTTP 200 and a application/json body with the session token on success;
+HTTP 302 redirect to a fresh login flow if the original flow expired with the appropriate error messages set;
+HTTP 400 on form validation errors.
+
+Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
+a HTTP 302 redirect to the post/after login URL or the `return_to` value if it was set and if the login succeeded;
+a HTTP 302 redirect to the login UI URL with the flow ID containing the validation errors otherwise.
+
+More information can be found at [ORY Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+*/
+func (a *Client) CompleteSelfServiceLoginFlowWithLDAPMethod(params *CompleteSelfServiceLoginFlowWithLDAPMethodParams, opts ...ClientOption) (*CompleteSelfServiceLoginFlowWithLDAPMethodOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCompleteSelfServiceLoginFlowWithLDAPMethodParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "completeSelfServiceLoginFlowWithLDAPMethod",
+		Method:             "POST",
+		PathPattern:        "/self-service/login/methods/ldap",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &CompleteSelfServiceLoginFlowWithLDAPMethodReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CompleteSelfServiceLoginFlowWithLDAPMethodOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for completeSelfServiceLoginFlowWithLDAPMethod: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   CompleteSelfServiceLoginFlowWithPasswordMethod completes login flow with username email password method
 
@@ -278,6 +337,62 @@ func (a *Client) CompleteSelfServiceRegistrationFlowWithPasswordMethod(params *C
 	panic(msg)
 }
 
+/*
+  CompleteSelfServiceSettingsFlowWithDeletionMethod completes settings flow with account deletion method
+
+  Use this endpoint to delete the identity of the session. All sessions of the identity are revoked. If
+`selfservice.methods.deletion.config.grace_period` is set, the identity is deactivated and purged once the
+grace period ended. This endpoint behaves differently for API and browser flows.
+
+API-initiated flows expect `application/json` to be sent in the body and respond with
+HTTP 204 if the identity was deleted;
+HTTP 401 when the endpoint is called without a valid session token.
+HTTP 403 when `selfservice.flows.settings.privileged_session_max_age` was reached.
+Implies that the user needs to re-authenticate.
+
+Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
+a HTTP 302 redirect to the post/after settings URL or the `return_to` value if it was set and if the flow succeeded;
+a HTTP 302 redirect to the Settings UI URL with the flow ID containing the errors otherwise.
+a HTTP 302 redirect to the login endpoint when `selfservice.flows.settings.privileged_session_max_age` was reached.
+
+More information can be found at [ORY Kratos User Settings & Profile Management Documentation](../self-service/flows/user-settings).
+*/
+func (a *Client) CompleteSelfServiceSettingsFlowWithDeletionMethod(params *CompleteSelfServiceSettingsFlowWithDeletionMethodParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCompleteSelfServiceSettingsFlowWithDeletionMethodParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "completeSelfServiceSettingsFlowWithDeletionMethod",
+		Method:             "POST",
+		PathPattern:        "/self-service/settings/methods/deletion",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &CompleteSelfServiceSettingsFlowWithDeletionMethodReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CompleteSelfServiceSettingsFlowWithDeletionMethodNoContent)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for completeSelfServiceSettingsFlowWithDeletionMethod: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   CompleteSelfServiceSettingsFlowWithPasswordMethod completes settings flow with username email password method
 
@@ -440,6 +555,53 @@ func (a *Client) CompleteSelfServiceVerificationFlowWithLinkMethod(params *Compl
 	return nil
 }
 
+/*
+  ExportWhoami exports the personal data of the current HTTP session s identity
+
+  Uses the HTTP Headers in the GET request to determine who is authenticated, and returns all data stored about
+that identity in one JSON document: its traits and addresses, the OpenID Connect providers it is linked with,
+its sessions including the devices they were issued to, and its recent audit events. Credentials and the
+identity's admin metadata are never exported.
+
+If the identity signed in with an expired password, this endpoint returns 403 until the password was changed
+using the settings flow.
+*/
+func (a *Client) ExportWhoami(params *ExportWhoamiParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ExportWhoamiOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExportWhoamiParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "exportWhoami",
+		Method:             "GET",
+		PathPattern:        "/sessions/whoami/export",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &ExportWhoamiReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExportWhoamiOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for exportWhoami: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetSchema Get a Traits Schema Definition
 */
@@ -35,6 +35,12 @@ func (o *WhoamiReader) ReadResponse(response runtime.ClientResponse, consumer ru
 			return nil, err
 		}
 		return nil, result
+	case 403:
+		result := NewWhoamiForbidden()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewWhoamiInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -110,6 +116,38 @@ func (o *WhoamiUnauthorized) readResponse(response runtime.ClientResponse, consu
 	return nil
 }
 
+// NewWhoamiForbidden creates a WhoamiForbidden with default headers values
+func NewWhoamiForbidden() *WhoamiForbidden {
+	return &WhoamiForbidden{}
+}
+
+/* WhoamiForbidden describes a response with status code 403, with default header values.
+
+genericError
+*/
+type WhoamiForbidden struct {
+	Payload *models.GenericError
+}
+
+func (o *WhoamiForbidden) Error() string {
+	return fmt.Sprintf("[GET /sessions/whoami][%d] whoamiForbidden  %+v", 403, o.Payload)
+}
+func (o *WhoamiForbidden) GetPayload() *models.GenericError {
+	return o.Payload
+}
+
+func (o *WhoamiForbidden) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.GenericError)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewWhoamiInternalServerError creates a WhoamiInternalServerError with default headers values
 func NewWhoamiInternalServerError() *WhoamiInternalServerError {
 	return &WhoamiInternalServerError{}
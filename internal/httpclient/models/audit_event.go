@@ -0,0 +1,325 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// AuditEvent Event is a security relevant event, for example a login or an update of an identity using the admin API.
+//
+// swagger:model auditEvent
+type AuditEvent struct {
+
+	// actor
+	// Required: true
+	Actor *AuditEventActor `json:"actor"`
+
+	// CreatedAt is the time (UTC) when the event occurred.
+	// Required: true
+	// Format: date-time
+	CreatedAt *strfmt.DateTime `json:"created_at"`
+
+	// Error is the reason why the action failed.
+	Error string `json:"error,omitempty"`
+
+	// id
+	// Required: true
+	// Format: uuid4
+	ID *UUID `json:"id"`
+
+	// identity id
+	// Format: uuid4
+	IdentityID UUID `json:"identity_id,omitempty"`
+
+	// IPAddress is the IP address of the client which caused the event.
+	IPAddress string `json:"ip_address,omitempty"`
+
+	// Method is the self-service method (e.g. `password`) which caused the event.
+	Method string `json:"method,omitempty"`
+
+	// outcome
+	// Required: true
+	Outcome *AuditEventOutcome `json:"outcome"`
+
+	// type
+	// Required: true
+	Type *AuditEventType `json:"type"`
+
+	// UserAgent is the user agent of the client which caused the event.
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// Validate validates this audit event
+func (m *AuditEvent) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateActor(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCreatedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIdentityID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateOutcome(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AuditEvent) validateActor(formats strfmt.Registry) error {
+
+	if err := validate.Required("actor", "body", m.Actor); err != nil {
+		return err
+	}
+
+	if err := validate.Required("actor", "body", m.Actor); err != nil {
+		return err
+	}
+
+	if m.Actor != nil {
+		if err := m.Actor.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("actor")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) validateCreatedAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("created_at", "body", m.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("created_at", "body", "date-time", m.CreatedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) validateID(formats strfmt.Registry) error {
+
+	if err := validate.Required("id", "body", m.ID); err != nil {
+		return err
+	}
+
+	if err := validate.Required("id", "body", m.ID); err != nil {
+		return err
+	}
+
+	if m.ID != nil {
+		if err := m.ID.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("id")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) validateIdentityID(formats strfmt.Registry) error {
+	if swag.IsZero(m.IdentityID) { // not required
+		return nil
+	}
+
+	if err := m.IdentityID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("identity_id")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) validateOutcome(formats strfmt.Registry) error {
+
+	if err := validate.Required("outcome", "body", m.Outcome); err != nil {
+		return err
+	}
+
+	if err := validate.Required("outcome", "body", m.Outcome); err != nil {
+		return err
+	}
+
+	if m.Outcome != nil {
+		if err := m.Outcome.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("outcome")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) validateType(formats strfmt.Registry) error {
+
+	if err := validate.Required("type", "body", m.Type); err != nil {
+		return err
+	}
+
+	if err := validate.Required("type", "body", m.Type); err != nil {
+		return err
+	}
+
+	if m.Type != nil {
+		if err := m.Type.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("type")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this audit event based on the context it is used
+func (m *AuditEvent) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateActor(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateID(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateIdentityID(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateOutcome(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateType(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AuditEvent) contextValidateActor(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Actor != nil {
+		if err := m.Actor.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("actor")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) contextValidateID(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.ID != nil {
+		if err := m.ID.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("id")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) contextValidateIdentityID(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := m.IdentityID.ContextValidate(ctx, formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("identity_id")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) contextValidateOutcome(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Outcome != nil {
+		if err := m.Outcome.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("outcome")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEvent) contextValidateType(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Type != nil {
+		if err := m.Type.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("type")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AuditEvent) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AuditEvent) UnmarshalBinary(b []byte) error {
+	var res AuditEvent
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
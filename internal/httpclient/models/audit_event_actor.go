@@ -0,0 +1,27 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// AuditEventActor Actor is who caused the event.
+//
+// swagger:model auditEventActor
+type AuditEventActor string
+
+// Validate validates this audit event actor
+func (m AuditEventActor) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this audit event actor based on context it is used
+func (m AuditEventActor) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
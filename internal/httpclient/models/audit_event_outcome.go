@@ -0,0 +1,27 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// AuditEventOutcome Outcome is whether the action which caused the event succeeded.
+//
+// swagger:model auditEventOutcome
+type AuditEventOutcome string
+
+// Validate validates this audit event outcome
+func (m AuditEventOutcome) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this audit event outcome based on context it is used
+func (m AuditEventOutcome) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
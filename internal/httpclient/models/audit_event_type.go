@@ -0,0 +1,27 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// AuditEventType EventType is the type of a security relevant event.
+//
+// swagger:model auditEventType
+type AuditEventType string
+
+// Validate validates this audit event type
+func (m AuditEventType) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this audit event type based on context it is used
+func (m AuditEventType) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
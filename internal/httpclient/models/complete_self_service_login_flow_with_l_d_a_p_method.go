@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// CompleteSelfServiceLoginFlowWithLDAPMethod CompleteSelfServiceLoginFlowWithLDAPMethod complete self service login flow with l d a p method
+//
+// swagger:model CompleteSelfServiceLoginFlowWithLDAPMethod
+type CompleteSelfServiceLoginFlowWithLDAPMethod struct {
+
+	// Sending the anti-csrf token is only required for browser login flows.
+	CsrfToken string `json:"csrf_token,omitempty"`
+
+	// Identifier is the directory username of the user trying to log in.
+	Identifier string `json:"identifier,omitempty"`
+
+	// The user's directory password.
+	Password string `json:"password,omitempty"`
+}
+
+// Validate validates this complete self service login flow with l d a p method
+func (m *CompleteSelfServiceLoginFlowWithLDAPMethod) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this complete self service login flow with l d a p method based on context it is used
+func (m *CompleteSelfServiceLoginFlowWithLDAPMethod) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CompleteSelfServiceLoginFlowWithLDAPMethod) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CompleteSelfServiceLoginFlowWithLDAPMethod) UnmarshalBinary(b []byte) error {
+	var res CompleteSelfServiceLoginFlowWithLDAPMethod
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -17,6 +17,9 @@ import (
 // swagger:model CompleteSelfServiceLoginFlowWithPasswordMethod
 type CompleteSelfServiceLoginFlowWithPasswordMethod struct {
 
+	// The CAPTCHA response token is only required if the login flow asks for a CAPTCHA challenge.
+	CaptchaToken string `json:"captcha_token,omitempty"`
+
 	// Sending the anti-csrf token is only required for browser login flows.
 	CsrfToken string `json:"csrf_token,omitempty"`
 
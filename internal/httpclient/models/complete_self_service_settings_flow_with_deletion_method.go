@@ -0,0 +1,52 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod CompleteSelfServiceSettingsFlowWithDeletionMethod complete self service settings flow with deletion method
+//
+// swagger:model CompleteSelfServiceSettingsFlowWithDeletionMethod
+type CompleteSelfServiceSettingsFlowWithDeletionMethod struct {
+
+	// CSRFToken is the anti-CSRF token
+	//
+	// type: string
+	CsrfToken string `json:"csrf_token,omitempty"`
+}
+
+// Validate validates this complete self service settings flow with deletion method
+func (m *CompleteSelfServiceSettingsFlowWithDeletionMethod) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this complete self service settings flow with deletion method based on context it is used
+func (m *CompleteSelfServiceSettingsFlowWithDeletionMethod) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CompleteSelfServiceSettingsFlowWithDeletionMethod) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CompleteSelfServiceSettingsFlowWithDeletionMethod) UnmarshalBinary(b []byte) error {
+	var res CompleteSelfServiceSettingsFlowWithDeletionMethod
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
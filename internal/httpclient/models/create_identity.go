@@ -14,15 +14,24 @@ import (
 	"github.com/go-openapi/validate"
 )
 
-// CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity create identity
+// CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity CreateIdentity create identity
 //
 // swagger:model CreateIdentity
 type CreateIdentity struct {
 
+	// MetadataAdmin contains arbitrary metadata which is only visible using the admin API.
+	MetadataAdmin interface{} `json:"metadata_admin,omitempty"`
+
+	// MetadataPublic contains arbitrary metadata which is visible to the identity itself.
+	MetadataPublic interface{} `json:"metadata_public,omitempty"`
+
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits.
 	// Required: true
 	SchemaID *string `json:"schema_id"`
 
+	// state
+	State IdentityState `json:"state,omitempty"`
+
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 	// in a self-service manner. The input will always be validated against the JSON Schema defined
 	// in `schema_url`.
@@ -38,6 +47,10 @@ func (m *CreateIdentity) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateState(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTraits(formats); err != nil {
 		res = append(res, err)
 	}
@@ -57,6 +70,21 @@ func (m *CreateIdentity) validateSchemaID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *CreateIdentity) validateState(formats strfmt.Registry) error {
+	if swag.IsZero(m.State) { // not required
+		return nil
+	}
+
+	if err := m.State.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("state")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *CreateIdentity) validateTraits(formats strfmt.Registry) error {
 
 	if m.Traits == nil {
@@ -66,8 +94,29 @@ func (m *CreateIdentity) validateTraits(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this create identity based on context it is used
+// ContextValidate validate this create identity based on the context it is used
 func (m *CreateIdentity) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateState(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *CreateIdentity) contextValidateState(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := m.State.ContextValidate(ctx, formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("state")
+		}
+		return err
+	}
+
 	return nil
 }
 
@@ -20,11 +20,23 @@ import (
 // swagger:model Identity
 type Identity struct {
 
+	// DeletionScheduledAt is set if the identity deleted itself and the deletion is pending until the
+	// grace period ends. The identity is inactive until then. Setting its state to active cancels the
+	// deletion.
+	// Format: date-time
+	DeletionScheduledAt *strfmt.DateTime `json:"deletion_scheduled_at,omitempty"`
+
 	// id
 	// Required: true
 	// Format: uuid4
 	ID *UUID `json:"id"`
 
+	// metadata admin
+	MetadataAdmin NullJSONRawMessage `json:"metadata_admin,omitempty"`
+
+	// metadata public
+	MetadataPublic NullJSONRawMessage `json:"metadata_public,omitempty"`
+
 	// RecoveryAddresses contains all the addresses that can be used to recover an identity.
 	RecoveryAddresses []*RecoveryAddress `json:"recovery_addresses,omitempty"`
 
@@ -38,6 +50,14 @@ type Identity struct {
 	// Required: true
 	SchemaURL *string `json:"schema_url"`
 
+	// state
+	// Required: true
+	State *IdentityState `json:"state"`
+
+	// StateChangedAt contains the last time when the identity's state changed.
+	// Format: date-time
+	StateChangedAt *strfmt.DateTime `json:"state_changed_at,omitempty"`
+
 	// traits
 	// Required: true
 	Traits Traits `json:"traits"`
@@ -50,6 +70,10 @@ type Identity struct {
 func (m *Identity) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDeletionScheduledAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -66,6 +90,14 @@ func (m *Identity) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateState(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStateChangedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTraits(formats); err != nil {
 		res = append(res, err)
 	}
@@ -80,6 +112,18 @@ func (m *Identity) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Identity) validateDeletionScheduledAt(formats strfmt.Registry) error {
+	if swag.IsZero(m.DeletionScheduledAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("deletion_scheduled_at", "body", "date-time", m.DeletionScheduledAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Identity) validateID(formats strfmt.Registry) error {
 
 	if err := validate.Required("id", "body", m.ID); err != nil {
@@ -144,6 +188,40 @@ func (m *Identity) validateSchemaURL(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Identity) validateState(formats strfmt.Registry) error {
+
+	if err := validate.Required("state", "body", m.State); err != nil {
+		return err
+	}
+
+	if err := validate.Required("state", "body", m.State); err != nil {
+		return err
+	}
+
+	if m.State != nil {
+		if err := m.State.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("state")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Identity) validateStateChangedAt(formats strfmt.Registry) error {
+	if swag.IsZero(m.StateChangedAt) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("state_changed_at", "body", "date-time", m.StateChangedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *Identity) validateTraits(formats strfmt.Registry) error {
 
 	if m.Traits == nil {
@@ -189,6 +267,10 @@ func (m *Identity) ContextValidate(ctx context.Context, formats strfmt.Registry)
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateState(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateVerifiableAddresses(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -231,6 +313,20 @@ func (m *Identity) contextValidateRecoveryAddresses(ctx context.Context, formats
 	return nil
 }
 
+func (m *Identity) contextValidateState(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.State != nil {
+		if err := m.State.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("state")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Identity) contextValidateVerifiableAddresses(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.VerifiableAddresses); i++ {
@@ -0,0 +1,291 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IdentityExport Export contains all data stored about an identity, except for its credentials.
+//
+// swagger:model identityExport
+type IdentityExport struct {
+
+	// AuditEvents contains the identity's most recent audit events, newest first.
+	// Required: true
+	AuditEvents []*AuditEvent `json:"audit_events"`
+
+	// ExportedAt is the time the export was created at.
+	// Required: true
+	// Format: date-time
+	ExportedAt *strfmt.DateTime `json:"exported_at"`
+
+	// identity
+	// Required: true
+	Identity *Identity `json:"identity"`
+
+	// OIDCProviders contains the OpenID Connect providers the identity is linked with.
+	// Required: true
+	OidcProviders []*IdentityExportedOIDCProvider `json:"oidc_providers"`
+
+	// Sessions contains the identity's sessions, newest first.
+	// Required: true
+	Sessions []*IdentityExportedSession `json:"sessions"`
+}
+
+// Validate validates this identity export
+func (m *IdentityExport) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAuditEvents(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExportedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIdentity(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateOidcProviders(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSessions(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IdentityExport) validateAuditEvents(formats strfmt.Registry) error {
+
+	if err := validate.Required("audit_events", "body", m.AuditEvents); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.AuditEvents); i++ {
+		if swag.IsZero(m.AuditEvents[i]) { // not required
+			continue
+		}
+
+		if m.AuditEvents[i] != nil {
+			if err := m.AuditEvents[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("audit_events" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) validateExportedAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("exported_at", "body", m.ExportedAt); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("exported_at", "body", "date-time", m.ExportedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) validateIdentity(formats strfmt.Registry) error {
+
+	if err := validate.Required("identity", "body", m.Identity); err != nil {
+		return err
+	}
+
+	if m.Identity != nil {
+		if err := m.Identity.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("identity")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) validateOidcProviders(formats strfmt.Registry) error {
+
+	if err := validate.Required("oidc_providers", "body", m.OidcProviders); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.OidcProviders); i++ {
+		if swag.IsZero(m.OidcProviders[i]) { // not required
+			continue
+		}
+
+		if m.OidcProviders[i] != nil {
+			if err := m.OidcProviders[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("oidc_providers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) validateSessions(formats strfmt.Registry) error {
+
+	if err := validate.Required("sessions", "body", m.Sessions); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Sessions); i++ {
+		if swag.IsZero(m.Sessions[i]) { // not required
+			continue
+		}
+
+		if m.Sessions[i] != nil {
+			if err := m.Sessions[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("sessions" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this identity export based on the context it is used
+func (m *IdentityExport) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAuditEvents(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateIdentity(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateOidcProviders(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSessions(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IdentityExport) contextValidateAuditEvents(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.AuditEvents); i++ {
+
+		if m.AuditEvents[i] != nil {
+			if err := m.AuditEvents[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("audit_events" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) contextValidateIdentity(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Identity != nil {
+		if err := m.Identity.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("identity")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) contextValidateOidcProviders(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.OidcProviders); i++ {
+
+		if m.OidcProviders[i] != nil {
+			if err := m.OidcProviders[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("oidc_providers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *IdentityExport) contextValidateSessions(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Sessions); i++ {
+
+		if m.Sessions[i] != nil {
+			if err := m.Sessions[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("sessions" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IdentityExport) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IdentityExport) UnmarshalBinary(b []byte) error {
+	var res IdentityExport
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,88 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IdentityExportedOIDCProvider ExportedOIDCProvider is an OpenID Connect provider an identity is linked with.
+//
+// swagger:model identityExportedOIDCProvider
+type IdentityExportedOIDCProvider struct {
+
+	// provider
+	// Required: true
+	Provider *string `json:"provider"`
+
+	// subject
+	// Required: true
+	Subject *string `json:"subject"`
+}
+
+// Validate validates this identity exported o ID c provider
+func (m *IdentityExportedOIDCProvider) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateProvider(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSubject(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IdentityExportedOIDCProvider) validateProvider(formats strfmt.Registry) error {
+
+	if err := validate.Required("provider", "body", m.Provider); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IdentityExportedOIDCProvider) validateSubject(formats strfmt.Registry) error {
+
+	if err := validate.Required("subject", "body", m.Subject); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this identity exported o ID c provider based on context it is used
+func (m *IdentityExportedOIDCProvider) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IdentityExportedOIDCProvider) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IdentityExportedOIDCProvider) UnmarshalBinary(b []byte) error {
+	var res IdentityExportedOIDCProvider
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
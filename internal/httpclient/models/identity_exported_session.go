@@ -0,0 +1,197 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IdentityExportedSession ExportedSession is a session of an identity and the device it was issued to.
+//
+// swagger:model identityExportedSession
+type IdentityExportedSession struct {
+
+	// active
+	// Required: true
+	Active *bool `json:"active"`
+
+	// authenticated at
+	// Required: true
+	// Format: date-time
+	AuthenticatedAt *strfmt.DateTime `json:"authenticated_at"`
+
+	// expires at
+	// Required: true
+	// Format: date-time
+	ExpiresAt *strfmt.DateTime `json:"expires_at"`
+
+	// id
+	// Required: true
+	// Format: uuid4
+	ID *UUID `json:"id"`
+
+	// IPAddress is the IP address of the client which the session was issued to.
+	IPAddress string `json:"ip_address,omitempty"`
+
+	// issued at
+	// Required: true
+	// Format: date-time
+	IssuedAt *strfmt.DateTime `json:"issued_at"`
+
+	// UserAgent is the user agent of the client which the session was issued to.
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// Validate validates this identity exported session
+func (m *IdentityExportedSession) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateActive(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAuthenticatedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExpiresAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIssuedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IdentityExportedSession) validateActive(formats strfmt.Registry) error {
+
+	if err := validate.Required("active", "body", m.Active); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IdentityExportedSession) validateAuthenticatedAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("authenticated_at", "body", m.AuthenticatedAt); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("authenticated_at", "body", "date-time", m.AuthenticatedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IdentityExportedSession) validateExpiresAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("expires_at", "body", m.ExpiresAt); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("expires_at", "body", "date-time", m.ExpiresAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IdentityExportedSession) validateID(formats strfmt.Registry) error {
+
+	if err := validate.Required("id", "body", m.ID); err != nil {
+		return err
+	}
+
+	if err := validate.Required("id", "body", m.ID); err != nil {
+		return err
+	}
+
+	if m.ID != nil {
+		if err := m.ID.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("id")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *IdentityExportedSession) validateIssuedAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("issued_at", "body", m.IssuedAt); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("issued_at", "body", "date-time", m.IssuedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this identity exported session based on the context it is used
+func (m *IdentityExportedSession) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateID(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IdentityExportedSession) contextValidateID(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.ID != nil {
+		if err := m.ID.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("id")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IdentityExportedSession) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IdentityExportedSession) UnmarshalBinary(b []byte) error {
+	var res IdentityExportedSession
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
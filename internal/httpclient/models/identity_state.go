@@ -0,0 +1,27 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// IdentityState State represents the state of an identity.
+//
+// swagger:model identityState
+type IdentityState string
+
+// Validate validates this identity state
+func (m IdentityState) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this identity state based on context it is used
+func (m IdentityState) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
@@ -0,0 +1,144 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// JSONPatch JSONPatch is a single RFC 6902 JSON Patch operation.
+//
+// swagger:model jsonPatch
+type JSONPatch struct {
+
+	// A JSON-pointer, only used by the move and copy operations
+	From string `json:"from,omitempty"`
+
+	// The operation to be performed
+	// Example: replace
+	// Required: true
+	// Enum: [add remove replace move copy test]
+	Op *string `json:"op"`
+
+	// A JSON-pointer
+	// Example: /traits/email
+	// Required: true
+	Path *string `json:"path"`
+
+	// The value to be used within the operations
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Validate validates this json patch
+func (m *JSONPatch) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateOp(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePath(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var jsonPatchTypeOpPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["add","remove","replace","move","copy","test"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		jsonPatchTypeOpPropEnum = append(jsonPatchTypeOpPropEnum, v)
+	}
+}
+
+const (
+
+	// JSONPatchOpAdd captures enum value "add"
+	JSONPatchOpAdd string = "add"
+
+	// JSONPatchOpRemove captures enum value "remove"
+	JSONPatchOpRemove string = "remove"
+
+	// JSONPatchOpReplace captures enum value "replace"
+	JSONPatchOpReplace string = "replace"
+
+	// JSONPatchOpMove captures enum value "move"
+	JSONPatchOpMove string = "move"
+
+	// JSONPatchOpCopy captures enum value "copy"
+	JSONPatchOpCopy string = "copy"
+
+	// JSONPatchOpTest captures enum value "test"
+	JSONPatchOpTest string = "test"
+)
+
+// prop value enum
+func (m *JSONPatch) validateOpEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, jsonPatchTypeOpPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *JSONPatch) validateOp(formats strfmt.Registry) error {
+
+	if err := validate.Required("op", "body", m.Op); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateOpEnum("op", "body", *m.Op); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *JSONPatch) validatePath(formats strfmt.Registry) error {
+
+	if err := validate.Required("path", "body", m.Path); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this json patch based on context it is used
+func (m *JSONPatch) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *JSONPatch) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *JSONPatch) UnmarshalBinary(b []byte) error {
+	var res JSONPatch
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,158 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// OidcProvider Configuration is the configuration of an OpenID Connect Provider.
+//
+// swagger:model oidcProvider
+type OidcProvider struct {
+
+	// IDTokenAudiences are additional audiences which are accepted when an ID token is submitted in an API flow,
+	// for example the client IDs of the iOS and Android apps. The ClientID is always accepted.
+	AdditionalIDTokenAudiences []string `json:"additional_id_token_audiences"`
+
+	// ApplePrivateKey is the PEM encoded "Sign in with Apple" private key which is used to sign the client secret
+	// and must be set when `provider` is set to `apple`.
+	ApplePrivateKey string `json:"apple_private_key,omitempty"`
+
+	// ApplePrivateKeyID is the ID of the "Sign in with Apple" private key and must be set when `provider` is set
+	// to `apple`.
+	ApplePrivateKeyID string `json:"apple_private_key_id,omitempty"`
+
+	// AppleTeamID is the Apple Developer Team ID and must be set when `provider` is set to `apple`.
+	AppleTeamID string `json:"apple_team_id,omitempty"`
+
+	// AuthURL is the authorize url, typically something like: https://example.org/oauth2/auth
+	// Should only be used when the OAuth2 / OpenID Connect server is not supporting OpenID Connect Discovery and when
+	// `provider` is set to `generic`.
+	AuthURL string `json:"auth_url,omitempty"`
+
+	// BackChannelLogout accepts OpenID Connect Back-Channel Logout tokens from this provider and revokes the ORY
+	// Kratos sessions which were created from the provider's session which ended.
+	BackchannelLogout bool `json:"backchannel_logout,omitempty"`
+
+	// ClientID is the application's Client ID.
+	ClientID string `json:"client_id,omitempty"`
+
+	// ClientSecret is the application's secret. It is not used when `provider` is set to `apple` because Apple
+	// expects a client secret which is signed with ApplePrivateKey instead.
+	ClientSecret string `json:"client_secret,omitempty"`
+
+	// EndSessionOnLogout redirects the browser to the provider's end_session_endpoint when someone who signed in
+	// with this provider signs out of ORY Kratos, ending the provider's session as well.
+	EndSessionOnLogout bool `json:"end_session_on_logout,omitempty"`
+
+	// ID is the provider's ID
+	ID string `json:"id,omitempty"`
+
+	// IssuerURL is the OpenID Connect Server URL. You can leave this empty if `provider` is not set to `generic`,
+	// `auth0`, or `keycloak`. If set, neither `auth_url` nor `token_url` are required.
+	//
+	// For `auth0` this is the tenant's domain, for example `https://example.eu.auth0.com/`, and for `keycloak` the
+	// realm's URL, for example `https://keycloak.example.org/auth/realms/example`.
+	IssuerURL string `json:"issuer_url,omitempty"`
+
+	// LinkPolicy controls whether this provider is linked to an existing identity when the provider asserts a
+	// verified email address which matches a verified address of that identity. Can be either `none` (default)
+	// or `verified_email`.
+	LinkPolicy string `json:"link_policy,omitempty"`
+
+	// LoginMapper optionally specifies a separate Jsonnet code snippet which is used instead of Mapper when
+	// UpdateTraitsOnLogin is enabled.
+	//
+	// It can be either a URL (file://, http(s)://, base64://) or an inline JSONNet code snippet.
+	LoginMapperURL string `json:"login_mapper_url,omitempty"`
+
+	// Mapper specifies the JSONNet code snippet which uses the OpenID Connect Provider's data (e.g. GitHub or Google
+	// profile information) to hydrate the identity's data.
+	//
+	// It can be either a URL (file://, http(s)://, base64://) or an inline JSONNet code snippet.
+	MapperURL string `json:"mapper_url,omitempty"`
+
+	// Nonce sends a random nonce with the authorization request and requires the ID token to include the same
+	// nonce. Only has an effect for OpenID Connect providers.
+	Nonce bool `json:"nonce,omitempty"`
+
+	// PKCE enables Proof Key for Code Exchange (RFC 7636) with the S256 code challenge method. The code verifier is
+	// kept in the continuity container and sent with the token request. Some providers require PKCE even for
+	// confidential clients.
+	Pkce bool `json:"pkce,omitempty"`
+
+	// Provider is either "generic" for a generic OAuth 2.0 / OpenID Connect Provider or one of:
+	// - generic
+	// - google
+	// - github
+	// - gitlab
+	// - microsoft
+	// - discord
+	// - slack
+	// - facebook
+	// - apple
+	// - auth0
+	// - keycloak
+	// - linkedin
+	// - spotify
+	// - twitch
+	Provider string `json:"provider,omitempty"`
+
+	// RequestedClaims string encoded json object that specifies claims and optionally their properties which should be
+	// included in the id_token or returned from the UserInfo Endpoint.
+	//
+	// More information: https://openid.net/specs/openid-connect-core-1_0.html#ClaimsParameter
+	RequestedClaims interface{} `json:"requested_claims,omitempty"`
+
+	// Scope specifies optional requested permissions.
+	Scope []string `json:"scope"`
+
+	// Tenant is the Azure AD Tenant to use for authentication, and must be set when `provider` is set to `microsoft`.
+	// Can be either `common`, `organizations`, `consumers` for a multitenant application or a specific tenant like
+	// `8eaef023-2b34-4da1-9baa-8bc8c9d6a490` or `contoso.onmicrosoft.com`.
+	Tenant string `json:"tenant,omitempty"`
+
+	// TokenURL is the token url, typically something like: https://example.org/oauth2/token
+	// Should only be used when the OAuth2 / OpenID Connect server is not supporting OpenID Connect Discovery and when
+	// `provider` is set to `generic`.
+	TokenURL string `json:"token_url,omitempty"`
+
+	// UpdateTraitsOnLogin runs the Jsonnet mapper on every sign in with this provider and merges the resulting
+	// traits into the identity's traits, keeping the identity in sync with the provider's profile.
+	UpdateTraitsOnLogin bool `json:"update_traits_on_login,omitempty"`
+}
+
+// Validate validates this oidc provider
+func (m *OidcProvider) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this oidc provider based on context it is used
+func (m *OidcProvider) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *OidcProvider) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *OidcProvider) UnmarshalBinary(b []byte) error {
+	var res OidcProvider
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
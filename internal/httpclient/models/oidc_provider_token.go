@@ -0,0 +1,115 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// OidcProviderToken ProviderToken is the upstream OAuth2 token of an OpenID Connect Provider which is linked to an identity.
+//
+// swagger:model oidcProviderToken
+type OidcProviderToken struct {
+
+	// AccessToken is the upstream OAuth2 access token. It is empty if the provider did not issue one,
+	// for example when signing in with an ID token.
+	AccessToken string `json:"access_token,omitempty"`
+
+	// Expiry is the time at which the access token expires. It is empty if the access token does not expire.
+	// Format: date-time
+	Expiry strfmt.DateTime `json:"expiry,omitempty"`
+
+	// Provider is the ID of the OpenID Connect Provider.
+	// Required: true
+	Provider *string `json:"provider"`
+
+	// Subject is the identity's subject at the OpenID Connect Provider.
+	// Required: true
+	Subject *string `json:"subject"`
+
+	// TokenType is the type of the access token, typically "bearer".
+	TokenType string `json:"token_type,omitempty"`
+}
+
+// Validate validates this oidc provider token
+func (m *OidcProviderToken) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateExpiry(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateProvider(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSubject(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *OidcProviderToken) validateExpiry(formats strfmt.Registry) error {
+	if swag.IsZero(m.Expiry) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("expiry", "body", "date-time", m.Expiry.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *OidcProviderToken) validateProvider(formats strfmt.Registry) error {
+
+	if err := validate.Required("provider", "body", m.Provider); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *OidcProviderToken) validateSubject(formats strfmt.Registry) error {
+
+	if err := validate.Required("subject", "body", m.Subject); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this oidc provider token based on context it is used
+func (m *OidcProviderToken) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *OidcProviderToken) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *OidcProviderToken) UnmarshalBinary(b []byte) error {
+	var res OidcProviderToken
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -45,6 +45,10 @@ type Session struct {
 	// Required: true
 	// Format: date-time
 	IssuedAt *strfmt.DateTime `json:"issued_at"`
+
+	// PasswordChangeRequired is true if the identity signed in with an expired password. The session can only be
+	// used for changing the password until the password was changed.
+	PasswordChangeRequired bool `json:"password_change_required,omitempty"`
 }
 
 // Validate validates this session
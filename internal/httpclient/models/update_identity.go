@@ -13,15 +13,26 @@ import (
 	"github.com/go-openapi/swag"
 )
 
-// UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity update identity
+// UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity UpdateIdentity update identity
 //
 // swagger:model UpdateIdentity
 type UpdateIdentity struct {
 
+	// MetadataAdmin contains arbitrary metadata which is only visible using the admin API. If omitted,
+	// the existing admin metadata will be removed.
+	MetadataAdmin interface{} `json:"metadata_admin,omitempty"`
+
+	// MetadataPublic contains arbitrary metadata which is visible to the identity itself. If omitted,
+	// the existing public metadata will be removed.
+	MetadataPublic interface{} `json:"metadata_public,omitempty"`
+
 	// SchemaID is the ID of the JSON Schema to be used for validating the identity's traits. If set
 	// will update the Identity's SchemaID.
 	SchemaID string `json:"schema_id,omitempty"`
 
+	// state
+	State IdentityState `json:"state,omitempty"`
+
 	// Traits represent an identity's traits. The identity is able to create, modify, and delete traits
 	// in a self-service manner. The input will always be validated against the JSON Schema defined
 	// in `schema_id`.
@@ -33,6 +44,10 @@ type UpdateIdentity struct {
 func (m *UpdateIdentity) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateState(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTraits(formats); err != nil {
 		res = append(res, err)
 	}
@@ -43,6 +58,21 @@ func (m *UpdateIdentity) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateIdentity) validateState(formats strfmt.Registry) error {
+	if swag.IsZero(m.State) { // not required
+		return nil
+	}
+
+	if err := m.State.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("state")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdateIdentity) validateTraits(formats strfmt.Registry) error {
 
 	if m.Traits == nil {
@@ -52,8 +82,29 @@ func (m *UpdateIdentity) validateTraits(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this update identity based on context it is used
+// ContextValidate validate this update identity based on the context it is used
 func (m *UpdateIdentity) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateState(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *UpdateIdentity) contextValidateState(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := m.State.ContextValidate(ctx, formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("state")
+		}
+		return err
+	}
+
 	return nil
 }
 
@@ -6,8 +6,10 @@ import (
 
 	"github.com/gobuffalo/pop/v5"
 
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/errorx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
@@ -16,8 +18,12 @@ import (
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 )
 
 func CleanSQL(t *testing.T, c *pop.Connection) {
@@ -46,6 +52,13 @@ func CleanSQL(t *testing.T, c *pop.Connection) {
 
 		new(errorx.ErrorContainer).TableName(ctx),
 
+		new(password.LoginAttempt).TableName(ctx),
+		new(captcha.Failure).TableName(ctx),
+		new(oidc.StoredConfiguration).TableName(ctx),
+		new(ratelimit.Hit).TableName(ctx),
+		new(audit.Event).TableName(ctx),
+		new(stream.Event).TableName(ctx),
+
 		new(session.Session).TableName(ctx),
 		new(identity.CredentialIdentifierCollection).TableName(ctx),
 		new(identity.CredentialsCollection).TableName(ctx),
@@ -7,17 +7,23 @@ import (
 
 	"github.com/ory/x/popx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 )
 
 type Provider interface {
@@ -27,6 +33,7 @@ type Provider interface {
 type Persister interface {
 	continuity.Persister
 	identity.PrivilegedPool
+	identity.ExportedSessionPersister
 	registration.FlowPersister
 	login.FlowPersister
 	settings.FlowPersister
@@ -37,6 +44,12 @@ type Persister interface {
 	recovery.FlowPersister
 	link.RecoveryTokenPersister
 	link.VerificationTokenPersister
+	password.LoginAttemptPersister
+	captcha.FailurePersister
+	oidc.ProviderPersister
+	ratelimit.HitPersister
+	audit.EventPersister
+	stream.OutboxPersister
 
 	Close(context.Context) error
 	Ping() error
@@ -0,0 +1 @@
+INSERT INTO "_identities_tmp" (id, schema_id, traits, created_at, updated_at, metadata_public) SELECT id, schema_id, traits, created_at, updated_at, metadata_public FROM "identities";
\ No newline at end of file
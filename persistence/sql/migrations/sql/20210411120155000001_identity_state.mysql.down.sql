@@ -0,0 +1 @@
+ALTER TABLE `identities` DROP COLUMN `state_changed_at`;
\ No newline at end of file
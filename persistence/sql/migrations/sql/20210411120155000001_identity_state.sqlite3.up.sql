@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "state_changed_at" DATETIME;
\ No newline at end of file
@@ -0,0 +1,2 @@
+
+DROP TABLE "identities";
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO "_identities_tmp" (id, schema_id, traits, created_at, updated_at, metadata_public, metadata_admin, state) SELECT id, schema_id, traits, created_at, updated_at, metadata_public, metadata_admin, state FROM "identities";
\ No newline at end of file
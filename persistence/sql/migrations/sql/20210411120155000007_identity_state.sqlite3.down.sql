@@ -0,0 +1,10 @@
+CREATE TABLE "_identities_tmp" (
+"id" TEXT PRIMARY KEY,
+"schema_id" TEXT NOT NULL,
+"traits" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"metadata_public" TEXT,
+"metadata_admin" TEXT,
+"state" TEXT NOT NULL DEFAULT 'active'
+);
\ No newline at end of file
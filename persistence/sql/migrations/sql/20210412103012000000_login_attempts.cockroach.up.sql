@@ -0,0 +1,8 @@
+CREATE TABLE "selfservice_login_attempts" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"identifier" VARCHAR (255) NOT NULL,
+"ip_address" VARCHAR (64) NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL
+);
\ No newline at end of file
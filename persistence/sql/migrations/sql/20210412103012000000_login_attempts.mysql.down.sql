@@ -0,0 +1 @@
+DROP TABLE `selfservice_login_attempts`;
\ No newline at end of file
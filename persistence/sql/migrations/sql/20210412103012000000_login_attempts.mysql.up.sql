@@ -0,0 +1,8 @@
+CREATE TABLE `selfservice_login_attempts` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`identifier` VARCHAR (255) NOT NULL,
+`ip_address` VARCHAR (64) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL
+) ENGINE=InnoDB;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE "selfservice_login_attempts" (
+"id" TEXT PRIMARY KEY,
+"identifier" TEXT NOT NULL,
+"ip_address" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
\ No newline at end of file
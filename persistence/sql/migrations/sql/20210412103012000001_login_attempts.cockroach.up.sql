@@ -0,0 +1 @@
+CREATE INDEX "selfservice_login_attempts_identifier_idx" ON "selfservice_login_attempts" (identifier, created_at);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX `selfservice_login_attempts_ip_address_idx` ON `selfservice_login_attempts` (`ip_address`, `created_at`);
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE `selfservice_captcha_failures` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`flow` VARCHAR (32) NOT NULL,
+`ip_address` VARCHAR (64) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL
+) ENGINE=InnoDB;
\ No newline at end of file
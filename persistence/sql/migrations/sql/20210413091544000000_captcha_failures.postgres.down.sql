@@ -0,0 +1 @@
+DROP TABLE "selfservice_captcha_failures";
\ No newline at end of file
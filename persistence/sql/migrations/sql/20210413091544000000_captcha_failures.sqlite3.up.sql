@@ -0,0 +1,7 @@
+CREATE TABLE "selfservice_captcha_failures" (
+"id" TEXT PRIMARY KEY,
+"flow" TEXT NOT NULL,
+"ip_address" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
\ No newline at end of file
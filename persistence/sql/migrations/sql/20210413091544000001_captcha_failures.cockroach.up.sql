@@ -0,0 +1 @@
+CREATE INDEX "selfservice_captcha_failures_flow_ip_address_idx" ON "selfservice_captcha_failures" (flow, ip_address, created_at);
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT '2bd5fb68-62a8-4a4e-9b7b-5b3a4f0bd4a3', 'saml' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'saml');
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO identity_credential_types (id, name) SELECT 'b9f47cd0-ffa1-4c6a-b15d-3697a4fb3912', 'ldap' WHERE NOT EXISTS ( SELECT * FROM identity_credential_types WHERE name = 'ldap');
\ No newline at end of file
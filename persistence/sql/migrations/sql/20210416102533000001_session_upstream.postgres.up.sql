@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "upstream_subject" VARCHAR (255) NOT NULL DEFAULT '';
\ No newline at end of file
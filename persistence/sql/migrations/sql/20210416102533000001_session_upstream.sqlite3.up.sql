@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "upstream_subject" TEXT NOT NULL DEFAULT '';
\ No newline at end of file
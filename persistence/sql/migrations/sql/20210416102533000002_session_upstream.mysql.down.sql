@@ -0,0 +1 @@
+ALTER TABLE `sessions` DROP COLUMN `upstream_session_id`;
\ No newline at end of file
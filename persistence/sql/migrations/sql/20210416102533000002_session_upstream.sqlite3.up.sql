@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "upstream_session_id" TEXT NOT NULL DEFAULT '';
\ No newline at end of file
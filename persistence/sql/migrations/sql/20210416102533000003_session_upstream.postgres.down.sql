@@ -0,0 +1 @@
+ALTER TABLE "sessions" DROP COLUMN "upstream_id_token";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "upstream_id_token" TEXT;
\ No newline at end of file
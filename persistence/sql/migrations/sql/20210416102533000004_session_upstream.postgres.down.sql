@@ -0,0 +1 @@
+DROP INDEX "sessions_upstream_idx";
\ No newline at end of file
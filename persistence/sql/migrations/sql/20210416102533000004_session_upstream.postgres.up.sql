@@ -0,0 +1 @@
+CREATE INDEX "sessions_upstream_idx" ON "sessions" (upstream_provider, upstream_subject);
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE "selfservice_oidc_providers" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"provider_id" VARCHAR (255) NOT NULL,
+"config" json NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL
+);
\ No newline at end of file
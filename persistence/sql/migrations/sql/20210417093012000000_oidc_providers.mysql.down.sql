@@ -0,0 +1 @@
+DROP TABLE `selfservice_oidc_providers`;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE "selfservice_oidc_providers" (
+"id" TEXT PRIMARY KEY,
+"provider_id" TEXT NOT NULL,
+"config" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX `selfservice_oidc_providers_provider_id_idx` ON `selfservice_oidc_providers` (`provider_id`);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `sessions` DROP COLUMN `password_change_required`;
\ No newline at end of file
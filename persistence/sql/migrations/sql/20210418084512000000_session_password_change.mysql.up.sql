@@ -0,0 +1 @@
+ALTER TABLE `sessions` ADD COLUMN `password_change_required` bool NOT NULL DEFAULT false;
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO "_sessions_tmp" (id, issued_at, expires_at, authenticated_at, identity_id, created_at, updated_at, token, active, upstream_provider, upstream_subject, upstream_session_id, upstream_id_token) SELECT id, issued_at, expires_at, authenticated_at, identity_id, created_at, updated_at, token, active, upstream_provider, upstream_subject, upstream_session_id, upstream_id_token FROM "sessions";
\ No newline at end of file
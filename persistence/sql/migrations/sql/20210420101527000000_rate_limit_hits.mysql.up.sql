@@ -0,0 +1,7 @@
+CREATE TABLE `selfservice_rate_limit_hits` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`rate_limit_key` VARCHAR (128) NOT NULL,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL
+) ENGINE=InnoDB;
\ No newline at end of file
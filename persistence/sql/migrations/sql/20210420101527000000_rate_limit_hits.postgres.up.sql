@@ -0,0 +1,7 @@
+CREATE TABLE "selfservice_rate_limit_hits" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"rate_limit_key" VARCHAR (128) NOT NULL,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL
+);
\ No newline at end of file
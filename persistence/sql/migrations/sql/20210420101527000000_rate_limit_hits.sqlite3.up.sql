@@ -0,0 +1,6 @@
+CREATE TABLE "selfservice_rate_limit_hits" (
+"id" TEXT PRIMARY KEY,
+"rate_limit_key" TEXT NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX "selfservice_rate_limit_hits_key_idx" ON "selfservice_rate_limit_hits" (rate_limit_key, created_at);
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE "audit_events" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"type" VARCHAR (64) NOT NULL,
+"actor" VARCHAR (32) NOT NULL,
+"identity_id" UUID,
+"method" VARCHAR (32) NOT NULL,
+"ip_address" VARCHAR (64) NOT NULL,
+"user_agent" text NOT NULL,
+"outcome" VARCHAR (16) NOT NULL,
+"error" text,
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "audit_events";
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE "audit_events" (
+"id" TEXT PRIMARY KEY,
+"type" TEXT NOT NULL,
+"actor" TEXT NOT NULL,
+"identity_id" char(36),
+"method" TEXT NOT NULL,
+"ip_address" TEXT NOT NULL,
+"user_agent" TEXT NOT NULL,
+"outcome" TEXT NOT NULL,
+"error" TEXT,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
\ No newline at end of file
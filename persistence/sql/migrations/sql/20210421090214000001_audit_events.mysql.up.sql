@@ -0,0 +1 @@
+CREATE INDEX `audit_events_identity_id_idx` ON `audit_events` (`identity_id`, `created_at`);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX `audit_events_created_at_idx` ON `audit_events` (`created_at`);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE "stream_events" (
+"id" UUID NOT NULL,
+PRIMARY KEY("id"),
+"type" VARCHAR (64) NOT NULL,
+"identity_id" UUID NOT NULL,
+"session_id" UUID,
+"data" json NOT NULL,
+"status" VARCHAR (16) NOT NULL,
+"attempts" int NOT NULL DEFAULT '0',
+"created_at" timestamp NOT NULL,
+"updated_at" timestamp NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE `stream_events` (
+`id` char(36) NOT NULL,
+PRIMARY KEY(`id`),
+`type` VARCHAR (64) NOT NULL,
+`identity_id` char(36) NOT NULL,
+`session_id` char(36),
+`data` JSON NOT NULL,
+`status` VARCHAR (16) NOT NULL,
+`attempts` INTEGER NOT NULL DEFAULT 0,
+`created_at` DATETIME NOT NULL,
+`updated_at` DATETIME NOT NULL
+) ENGINE=InnoDB;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE "stream_events" (
+"id" TEXT PRIMARY KEY,
+"type" TEXT NOT NULL,
+"identity_id" char(36) NOT NULL,
+"session_id" char(36),
+"data" TEXT NOT NULL,
+"status" TEXT NOT NULL,
+"attempts" INTEGER NOT NULL DEFAULT '0',
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL
+);
\ No newline at end of file
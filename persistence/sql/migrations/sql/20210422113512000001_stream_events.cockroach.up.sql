@@ -0,0 +1 @@
+CREATE INDEX "stream_events_status_created_at_idx" ON "stream_events" (status, created_at);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "user_agent" VARCHAR (512) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "sessions" ADD COLUMN "user_agent" TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE "_sessions_tmp" (
+"id" TEXT PRIMARY KEY,
+"issued_at" DATETIME NOT NULL DEFAULT 'CURRENT_TIMESTAMP',
+"expires_at" DATETIME NOT NULL,
+"authenticated_at" DATETIME NOT NULL,
+"identity_id" char(36) NOT NULL,
+"created_at" DATETIME NOT NULL,
+"updated_at" DATETIME NOT NULL,
+"token" TEXT,
+"active" NUMERIC DEFAULT 'false',
+"upstream_provider" TEXT NOT NULL DEFAULT '',
+"upstream_subject" TEXT NOT NULL DEFAULT '',
+"upstream_session_id" TEXT NOT NULL DEFAULT '',
+"upstream_id_token" TEXT,
+"password_change_required" bool NOT NULL DEFAULT 'false',
+FOREIGN KEY (identity_id) REFERENCES identities (id) ON UPDATE NO ACTION ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "sessions_upstream_idx";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "identities" ADD COLUMN "deletion_scheduled_at" timestamp;
\ No newline at end of file
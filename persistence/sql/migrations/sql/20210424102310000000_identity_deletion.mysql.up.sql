@@ -0,0 +1 @@
+ALTER TABLE `identities` ADD COLUMN `deletion_scheduled_at` DATETIME;
\ No newline at end of file
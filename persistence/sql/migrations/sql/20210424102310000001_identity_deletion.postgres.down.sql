@@ -0,0 +1 @@
+DROP INDEX "identities_deletion_scheduled_at_idx";
\ No newline at end of file
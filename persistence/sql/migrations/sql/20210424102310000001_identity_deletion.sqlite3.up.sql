@@ -0,0 +1 @@
+CREATE INDEX "identities_deletion_scheduled_at_idx" ON "identities" (deletion_scheduled_at);
\ No newline at end of file
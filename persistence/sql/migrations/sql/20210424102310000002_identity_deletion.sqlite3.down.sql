@@ -0,0 +1 @@
+INSERT INTO "_identities_tmp" (id, schema_id, traits, created_at, updated_at, metadata_public, metadata_admin, state, state_changed_at) SELECT id, schema_id, traits, created_at, updated_at, metadata_public, metadata_admin, state, state_changed_at FROM "identities";
\ No newline at end of file
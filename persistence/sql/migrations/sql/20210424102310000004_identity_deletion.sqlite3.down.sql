@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "identities_deletion_scheduled_at_idx";
\ No newline at end of file
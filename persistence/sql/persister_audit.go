@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/audit"
+)
+
+var _ audit.EventPersister = new(Persister)
+
+func (p *Persister) CreateAuditEvent(ctx context.Context, e *audit.Event) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(e))
+}
+
+func (p *Persister) ListAuditEvents(ctx context.Context, f audit.Filter, page, perPage int) ([]audit.Event, error) {
+	events := make([]audit.Event, 0)
+	if err := p.filterAuditEvents(ctx, f).Paginate(page, perPage).Order("created_at DESC").All(&events); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return events, nil
+}
+
+func (p *Persister) CountAuditEvents(ctx context.Context, f audit.Filter) (int64, error) {
+	count, err := p.filterAuditEvents(ctx, f).Count(new(audit.Event))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	return int64(count), nil
+}
+
+func (p *Persister) filterAuditEvents(ctx context.Context, f audit.Filter) *pop.Query {
+	q := p.GetConnection(ctx).Q()
+	if f.IdentityID != uuid.Nil {
+		q = q.Where("identity_id = ?", f.IdentityID)
+	}
+	if len(f.Type) > 0 {
+		q = q.Where("type = ?", f.Type)
+	}
+	return q
+}
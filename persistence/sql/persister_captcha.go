@@ -0,0 +1,27 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/selfservice/captcha"
+)
+
+var _ captcha.FailurePersister = new(Persister)
+
+func (p *Persister) CreateCaptchaFailure(ctx context.Context, failure *captcha.Failure) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(failure))
+}
+
+func (p *Persister) CountCaptchaFailures(ctx context.Context, flow, ip string, since time.Time) (int, error) {
+	count, err := p.GetConnection(ctx).
+		Where("flow = ? AND ip_address = ? AND created_at > ?", flow, ip, since.UTC()).
+		Count(new(captcha.Failure))
+	if err != nil {
+		return 0, sqlcon.HandleError(err)
+	}
+
+	return count, nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/ory/x/sqlcon"
 
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/stream"
 )
 
 var _ identity.Pool = new(Persister)
@@ -183,6 +184,10 @@ func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) er
 		i.Traits = identity.Traits("{}")
 	}
 
+	if i.State == "" {
+		i.State = identity.StateActive
+	}
+
 	if err := p.injectTraitsSchemaURL(ctx, i); err != nil {
 		return err
 	}
@@ -204,7 +209,16 @@ func (p *Persister) CreateIdentity(ctx context.Context, i *identity.Identity) er
 			return sqlcon.HandleError(err)
 		}
 
-		return p.createIdentityCredentials(ctx, i)
+		if err := p.createIdentityCredentials(ctx, i); err != nil {
+			return err
+		}
+
+		e, err := stream.NewIdentityEvent(stream.EventTypeIdentityCreated, i)
+		if err != nil {
+			return err
+		}
+
+		return p.addStreamEvent(ctx, e)
 	})
 }
 
@@ -226,6 +240,23 @@ func (p *Persister) ListIdentities(ctx context.Context, page, perPage int) ([]id
 	return is, nil
 }
 
+func (p *Persister) ListIdentitiesScheduledForDeletion(ctx context.Context, before time.Time, limit int) ([]identity.Identity, error) {
+	is := make([]identity.Identity, 0)
+	if err := p.GetConnection(ctx).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at < ?", before).
+		Order("deletion_scheduled_at ASC").Limit(limit).All(&is); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	for k := range is {
+		if err := p.injectTraitsSchemaURL(ctx, &(is[k])); err != nil {
+			return nil, err
+		}
+	}
+
+	return is, nil
+}
+
 func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) error {
 	if err := p.validateIdentity(ctx, i); err != nil {
 		return err
@@ -263,20 +294,37 @@ func (p *Persister) UpdateIdentity(ctx context.Context, i *identity.Identity) er
 			return err
 		}
 
-		return p.createIdentityCredentials(ctx, i)
+		if err := p.createIdentityCredentials(ctx, i); err != nil {
+			return err
+		}
+
+		e, err := stream.NewIdentityEvent(stream.EventTypeIdentityUpdated, i)
+		if err != nil {
+			return err
+		}
+
+		return p.addStreamEvent(ctx, e)
 	}))
 }
 
 func (p *Persister) DeleteIdentity(ctx context.Context, id uuid.UUID) error {
-	/* #nosec G201 TableName is static */
-	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ?", new(identity.Identity).TableName(ctx)), id).ExecWithCount()
-	if err != nil {
-		return sqlcon.HandleError(err)
-	}
-	if count == 0 {
-		return sqlcon.ErrNoRows
-	}
-	return nil
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		/* #nosec G201 TableName is static */
+		count, err := tx.RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ?", new(identity.Identity).TableName(ctx)), id).ExecWithCount()
+		if err != nil {
+			return sqlcon.HandleError(err)
+		}
+		if count == 0 {
+			return sqlcon.ErrNoRows
+		}
+
+		e, err := stream.NewIdentityDeletedEvent(id)
+		if err != nil {
+			return err
+		}
+
+		return p.addStreamEvent(ctx, e)
+	})
 }
 
 func (p *Persister) GetIdentity(ctx context.Context, id uuid.UUID) (*identity.Identity, error) {
@@ -379,7 +427,27 @@ func (p *Persister) VerifyAddress(ctx context.Context, code string) error {
 }
 
 func (p *Persister) UpdateVerifiableAddress(ctx context.Context, address *identity.VerifiableAddress) error {
-	return sqlcon.HandleError(p.GetConnection(ctx).Update(address))
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		if err := tx.Update(address); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		if !p.r.Config(ctx).EventStreamEnabled() {
+			return nil
+		}
+
+		i, err := p.GetIdentity(ctx, address.IdentityID)
+		if err != nil {
+			return err
+		}
+
+		e, err := stream.NewIdentityEvent(stream.EventTypeIdentityUpdated, i)
+		if err != nil {
+			return err
+		}
+
+		return p.addStreamEvent(ctx, e)
+	})
 }
 
 func (p *Persister) validateIdentity(ctx context.Context, i *identity.Identity) error {
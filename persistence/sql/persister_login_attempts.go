@@ -0,0 +1,53 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/selfservice/strategy/password"
+)
+
+var _ password.LoginAttemptPersister = new(Persister)
+
+func (p *Persister) CreateLoginAttempt(ctx context.Context, attempt *password.LoginAttempt) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(attempt))
+}
+
+func (p *Persister) ListLoginAttemptsByIdentifier(ctx context.Context, identifier string, since time.Time, limit int) ([]password.LoginAttempt, error) {
+	return p.listLoginAttempts(ctx, "identifier", identifier, since, limit)
+}
+
+func (p *Persister) ListLoginAttemptsByIPAddress(ctx context.Context, ip string, since time.Time, limit int) ([]password.LoginAttempt, error) {
+	return p.listLoginAttempts(ctx, "ip_address", ip, since, limit)
+}
+
+func (p *Persister) listLoginAttempts(ctx context.Context, column, value string, since time.Time, limit int) ([]password.LoginAttempt, error) {
+	var attempts []password.LoginAttempt
+	if err := p.GetConnection(ctx).
+		Where(column+" = ? AND created_at > ?", value, since.UTC()).
+		Order("created_at DESC").
+		Limit(limit).
+		All(&attempts); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return attempts, nil
+}
+
+func (p *Persister) DeleteLoginAttemptsByIdentifier(ctx context.Context, identifiers ...string) error {
+	if len(identifiers) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(identifiers))
+	for k, identifier := range identifiers {
+		args[k] = identifier
+	}
+
+	/* #nosec G201 TableName is static */
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE identifier IN (%s)", new(password.LoginAttempt).TableName(ctx), strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")), args...).Exec())
+}
@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+)
+
+var _ oidc.ProviderPersister = new(Persister)
+
+func (p *Persister) CreateOIDCProvider(ctx context.Context, c *oidc.StoredConfiguration) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(c))
+}
+
+func (p *Persister) UpdateOIDCProvider(ctx context.Context, c *oidc.StoredConfiguration) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).Update(c))
+}
+
+func (p *Persister) GetOIDCProvider(ctx context.Context, providerID string) (*oidc.StoredConfiguration, error) {
+	var c oidc.StoredConfiguration
+	if err := p.GetConnection(ctx).Where("provider_id = ?", providerID).First(&c); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return &c, nil
+}
+
+func (p *Persister) ListOIDCProviders(ctx context.Context) ([]oidc.StoredConfiguration, error) {
+	var cs []oidc.StoredConfiguration
+	if err := p.GetConnection(ctx).Order("provider_id ASC").All(&cs); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return cs, nil
+}
+
+func (p *Persister) DeleteOIDCProvider(ctx context.Context, providerID string) error {
+	/* #nosec G201 TableName is static */
+	count, err := p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE provider_id = ?", new(oidc.StoredConfiguration).TableName(ctx)), providerID).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+	if count == 0 {
+		return sqlcon.ErrNoRows
+	}
+	return nil
+}
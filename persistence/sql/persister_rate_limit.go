@@ -0,0 +1,34 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/selfservice/ratelimit"
+)
+
+var _ ratelimit.HitPersister = new(Persister)
+
+func (p *Persister) CreateRateLimitHit(ctx context.Context, hit *ratelimit.Hit) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(hit))
+}
+
+func (p *Persister) ListRateLimitHits(ctx context.Context, key string, since time.Time, limit int) ([]ratelimit.Hit, error) {
+	var hits []ratelimit.Hit
+	if err := p.GetConnection(ctx).
+		Where("rate_limit_key = ? AND created_at > ?", key, since.UTC()).
+		Order("created_at DESC").
+		Limit(limit).
+		All(&hits); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	return hits, nil
+}
+
+func (p *Persister) DeleteRateLimitHits(ctx context.Context, key string, before time.Time) error {
+	return sqlcon.HandleError(p.GetConnection(ctx).RawQuery(fmt.Sprintf("DELETE FROM %s WHERE rate_limit_key = ? AND created_at <= ?", new(ratelimit.Hit).TableName(ctx)), key, before.UTC()).Exec())
+}
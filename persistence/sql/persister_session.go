@@ -3,14 +3,20 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/identity"
 
+	"github.com/gobuffalo/pop/v5"
 	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
 
+	"github.com/ory/herodot"
 	"github.com/ory/x/sqlcon"
 
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 )
 
 var _ session.Persister = new(Persister)
@@ -32,22 +38,52 @@ func (p *Persister) GetSession(ctx context.Context, sid uuid.UUID) (*session.Ses
 }
 
 func (p *Persister) CreateSession(ctx context.Context, s *session.Session) error {
-	return p.GetConnection(ctx).Create(s) // This must not be eager or identities will be created / updated
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		if err := tx.Create(s); err != nil { // This must not be eager or identities will be created / updated
+			return err
+		}
+
+		e, err := stream.NewSessionEvent(stream.EventTypeSessionCreated, s)
+		if err != nil {
+			return err
+		}
+
+		return p.addStreamEvent(ctx, e)
+	})
 }
 
 func (p *Persister) DeleteSession(ctx context.Context, sid uuid.UUID) error {
-	return p.GetConnection(ctx).Destroy(&session.Session{ID: sid}) // This must not be eager or identities will be created / updated
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		revoked, err := p.findActiveSessions(ctx, "id = ?", sid)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Destroy(&session.Session{ID: sid}); err != nil { // This must not be eager or identities will be created / updated
+			return err
+		}
+
+		return p.addSessionsRevokedEvents(ctx, revoked)
+	})
 }
 
 func (p *Persister) DeleteSessionsByIdentity(ctx context.Context, identityID uuid.UUID) error {
-	// #nosec G201
-	if err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"DELETE FROM %s WHERE identity_id = ?",
-		corp.ContextualizeTableName(ctx, "sessions"),
-	), identityID).Exec(); err != nil {
-		return sqlcon.HandleError(err)
-	}
-	return nil
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		revoked, err := p.findActiveSessions(ctx, "identity_id = ?", identityID)
+		if err != nil {
+			return err
+		}
+
+		// #nosec G201
+		if err := tx.RawQuery(fmt.Sprintf(
+			"DELETE FROM %s WHERE identity_id = ?",
+			corp.ContextualizeTableName(ctx, "sessions"),
+		), identityID).Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return p.addSessionsRevokedEvents(ctx, revoked)
+	})
 }
 
 func (p *Persister) GetSessionByToken(ctx context.Context, token string) (*session.Session, error) {
@@ -67,23 +103,155 @@ func (p *Persister) GetSessionByToken(ctx context.Context, token string) (*sessi
 }
 
 func (p *Persister) DeleteSessionByToken(ctx context.Context, token string) error {
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		revoked, err := p.findActiveSessions(ctx, "token = ?", token)
+		if err != nil {
+			return err
+		}
+
+		// #nosec G201
+		if err := tx.RawQuery(fmt.Sprintf(
+			"DELETE FROM %s WHERE token = ?",
+			corp.ContextualizeTableName(ctx, "sessions"),
+		), token).Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return p.addSessionsRevokedEvents(ctx, revoked)
+	})
+}
+
+func (p *Persister) RevokeSessionByToken(ctx context.Context, token string) error {
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		revoked, err := p.findActiveSessions(ctx, "token = ?", token)
+		if err != nil {
+			return err
+		}
+
+		// #nosec G201
+		if err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET active = false WHERE token = ?",
+			corp.ContextualizeTableName(ctx, "sessions"),
+		), token).Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return p.addSessionsRevokedEvents(ctx, revoked)
+	})
+}
+
+func (p *Persister) RevokeSessionsByUpstream(ctx context.Context, provider, subject, sessionID string) error {
+	if len(provider) == 0 || (len(subject) == 0 && len(sessionID) == 0) {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The upstream provider and either the subject or the session ID must be set."))
+	}
+
+	where, args := []string{"upstream_provider = ?"}, []interface{}{provider}
+	if len(subject) > 0 {
+		where, args = append(where, "upstream_subject = ?"), append(args, subject)
+	}
+	if len(sessionID) > 0 {
+		where, args = append(where, "upstream_session_id = ?"), append(args, sessionID)
+	}
+
+	return p.Transaction(ctx, func(ctx context.Context, tx *pop.Connection) error {
+		revoked, err := p.findActiveSessions(ctx, strings.Join(where, " AND "), args...)
+		if err != nil {
+			return err
+		}
+
+		// #nosec G201
+		if err := tx.RawQuery(fmt.Sprintf(
+			"UPDATE %s SET active = false WHERE %s",
+			corp.ContextualizeTableName(ctx, "sessions"),
+			strings.Join(where, " AND "),
+		), args...).Exec(); err != nil {
+			return sqlcon.HandleError(err)
+		}
+
+		return p.addSessionsRevokedEvents(ctx, revoked)
+	})
+}
+
+func (p *Persister) ClearPasswordChangeRequired(ctx context.Context, identityID uuid.UUID) error {
 	// #nosec G201
 	if err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"DELETE FROM %s WHERE token = ?",
+		"UPDATE %s SET password_change_required = false WHERE identity_id = ?",
 		corp.ContextualizeTableName(ctx, "sessions"),
-	), token).Exec(); err != nil {
+	), identityID).Exec(); err != nil {
 		return sqlcon.HandleError(err)
 	}
 	return nil
 }
 
-func (p *Persister) RevokeSessionByToken(ctx context.Context, token string) error {
-	// #nosec G201
-	if err := p.GetConnection(ctx).RawQuery(fmt.Sprintf(
-		"UPDATE %s SET active = false WHERE token = ?",
-		corp.ContextualizeTableName(ctx, "sessions"),
-	), token).Exec(); err != nil {
-		return sqlcon.HandleError(err)
+func (p *Persister) ListSessionsByIdentity(ctx context.Context, identityID uuid.UUID) ([]session.Session, error) {
+	var ss []session.Session
+	if err := p.GetConnection(ctx).Where("identity_id = ?", identityID).Order("created_at DESC").All(&ss); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return ss, nil
+}
+
+func (p *Persister) HasSessionWithUserAgent(ctx context.Context, identityID, exclude uuid.UUID, userAgent string) (bool, error) {
+	q := p.GetConnection(ctx).Where("identity_id = ? AND id <> ? AND user_agent <> ''", identityID, exclude)
+	if len(userAgent) > 0 {
+		q = q.Where("user_agent = ?", userAgent)
+	}
+
+	has, err := q.Exists(new(session.Session))
+	if err != nil {
+		return false, sqlcon.HandleError(err)
+	}
+	return has, nil
+}
+
+func (p *Persister) ListExportedSessions(ctx context.Context, identityID uuid.UUID) ([]identity.ExportedSession, error) {
+	ss, err := p.ListSessionsByIdentity(ctx, identityID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]identity.ExportedSession, len(ss))
+	for k, s := range ss {
+		exported[k] = identity.ExportedSession{
+			ID:              s.ID,
+			Active:          s.Active,
+			IssuedAt:        s.IssuedAt,
+			ExpiresAt:       s.ExpiresAt,
+			AuthenticatedAt: s.AuthenticatedAt,
+			IPAddress:       s.IPAddress,
+			UserAgent:       s.UserAgent,
+		}
+	}
+	return exported, nil
+}
+
+// findActiveSessions returns the active sessions matching the condition, which are about to be revoked. It returns
+// no sessions if the event stream is disabled.
+func (p *Persister) findActiveSessions(ctx context.Context, where string, args ...interface{}) ([]session.Session, error) {
+	if !p.r.Config(ctx).EventStreamEnabled() {
+		return nil, nil
+	}
+
+	var ss []session.Session
+	if err := p.GetConnection(ctx).Where(where, args...).Where("active = ?", true).All(&ss); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+	return ss, nil
+}
+
+func (p *Persister) addSessionsRevokedEvents(ctx context.Context, revoked []session.Session) error {
+	for k := range revoked {
+		s := revoked[k]
+		s.Active = false
+
+		e, err := stream.NewSessionEvent(stream.EventTypeSessionRevoked, &s)
+		if err != nil {
+			return err
+		}
+
+		if err := p.addStreamEvent(ctx, e); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/stream"
+)
+
+var _ stream.OutboxPersister = new(Persister)
+
+// addStreamEvent adds the event to the outbox if the event stream is enabled. It must be called in the
+// transaction which makes the change so that the event is stored if and only if the change is.
+func (p *Persister) addStreamEvent(ctx context.Context, e *stream.Event) error {
+	if !p.r.Config(ctx).EventStreamEnabled() {
+		return nil
+	}
+
+	return sqlcon.HandleError(p.GetConnection(ctx).Create(e))
+}
+
+func (p *Persister) NextStreamEvents(ctx context.Context, limit uint8) ([]stream.Event, error) {
+	var events []stream.Event
+	if err := p.GetConnection(ctx).
+		Where("status = ?", stream.EventStatusPending).
+		Order("created_at ASC").Limit(int(limit)).All(&events); err != nil {
+		return nil, sqlcon.HandleError(err)
+	}
+
+	if len(events) == 0 {
+		return nil, errors.WithStack(stream.ErrOutboxEmpty)
+	}
+
+	return events, nil
+}
+
+func (p *Persister) SetStreamEventDelivered(ctx context.Context, id uuid.UUID) error {
+	return p.updateStreamEvent(ctx, id, "status = ?", stream.EventStatusDelivered)
+}
+
+func (p *Persister) SetStreamEventFailed(ctx context.Context, id uuid.UUID) error {
+	return p.updateStreamEvent(ctx, id, "status = ?", stream.EventStatusFailed)
+}
+
+func (p *Persister) IncrementStreamEventAttempts(ctx context.Context, id uuid.UUID) error {
+	return p.updateStreamEvent(ctx, id, "attempts = attempts + 1")
+}
+
+func (p *Persister) updateStreamEvent(ctx context.Context, id uuid.UUID, set string, args ...interface{}) error {
+	count, err := p.GetConnection(ctx).RawQuery(
+		// #nosec G201
+		fmt.Sprintf(
+			"UPDATE %s SET %s WHERE id = ?",
+			corp.ContextualizeTableName(ctx, "stream_events"), set,
+		), append(args, id)...).ExecWithCount()
+	if err != nil {
+		return sqlcon.HandleError(err)
+	}
+
+	if count == 0 {
+		return errors.WithStack(sqlcon.ErrNoRows)
+	}
+
+	return nil
+}
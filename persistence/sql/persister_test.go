@@ -24,6 +24,8 @@ import (
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/strategy/link"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/x"
 
 	"github.com/gobuffalo/pop/v5"
@@ -35,14 +37,18 @@ import (
 	// "github.com/ory/x/sqlcon/dockertest"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/stream"
 )
 
 var sqlite = fmt.Sprintf("sqlite3://%s.sqlite?_fk=true&mode=rwc", filepath.Join(os.TempDir(), uuid.New().String()))
@@ -201,6 +207,30 @@ func TestPersister(t *testing.T) {
 				pop.SetLogger(pl(t))
 				continuity.TestPersister(ctx, p)(t)
 			})
+			t.Run("contract=password.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				password.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=captcha.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				captcha.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=oidc.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				oidc.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=ratelimit.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				ratelimit.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=audit.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				audit.TestPersister(ctx, p)(t)
+			})
+			t.Run("contract=stream.TestPersister", func(t *testing.T) {
+				pop.SetLogger(pl(t))
+				stream.TestPersister(ctx, conf, p)(t)
+			})
 		})
 	}
 }
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -57,6 +58,16 @@ func (r *ValidationErrorContextPasswordPolicyViolation) AddContext(_, _ string)
 func (r *ValidationErrorContextPasswordPolicyViolation) FinishInstanceContext() {}
 
 func NewPasswordPolicyViolationError(instancePtr string, reason string) error {
+	return newPasswordPolicyViolationError(instancePtr, reason, text.NewErrorValidationPasswordPolicyViolation(reason))
+}
+
+// NewPasswordPolicyRuleViolationError is like NewPasswordPolicyViolationError but uses the message of the
+// violated password policy rule, which allows the UI to explain the violation.
+func NewPasswordPolicyRuleViolationError(instancePtr string, message *text.Message) error {
+	return newPasswordPolicyViolationError(instancePtr, message.Text, message)
+}
+
+func newPasswordPolicyViolationError(instancePtr string, reason string, message *text.Message) error {
 	return errors.WithStack(&ValidationError{
 		ValidationError: &jsonschema.ValidationError{
 			Message:     fmt.Sprintf("the password does not fulfill the password policy because: %s", reason),
@@ -65,7 +76,7 @@ func NewPasswordPolicyViolationError(instancePtr string, reason string) error {
 				Reason: reason,
 			},
 		},
-		Messages: new(text.Messages).Add(text.NewErrorValidationPasswordPolicyViolation(reason)),
+		Messages: new(text.Messages).Add(message),
 	})
 }
 
@@ -102,3 +113,71 @@ func NewDuplicateCredentialsError() error {
 		Messages: new(text.Messages).Add(text.NewErrorValidationDuplicateCredentials()),
 	})
 }
+
+type ValidationErrorContextIdentityInactiveError struct{}
+
+func (r *ValidationErrorContextIdentityInactiveError) AddContext(_, _ string) {}
+
+func (r *ValidationErrorContextIdentityInactiveError) FinishInstanceContext() {}
+
+func NewIdentityInactiveError() error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the account is inactive and can not be used to sign in`,
+			InstancePtr: "#/",
+			Context:     &ValidationErrorContextIdentityInactiveError{},
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationIdentityInactive()),
+	})
+}
+
+type ValidationErrorContextLoginLockedOutError struct{}
+
+func (r *ValidationErrorContextLoginLockedOutError) AddContext(_, _ string) {}
+
+func (r *ValidationErrorContextLoginLockedOutError) FinishInstanceContext() {}
+
+func NewLoginLockedOutError(until time.Time) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `too many failed login attempts, please try again later`,
+			InstancePtr: "#/",
+			Context:     &ValidationErrorContextLoginLockedOutError{},
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationLoginLockedOut(until)),
+	})
+}
+
+type ValidationErrorContextCaptchaInvalidError struct{}
+
+func (r *ValidationErrorContextCaptchaInvalidError) AddContext(_, _ string) {}
+
+func (r *ValidationErrorContextCaptchaInvalidError) FinishInstanceContext() {}
+
+func NewCaptchaInvalidError(instancePtr string) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `the CAPTCHA challenge was not completed or is invalid`,
+			InstancePtr: instancePtr,
+			Context:     &ValidationErrorContextCaptchaInvalidError{},
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationCaptchaInvalid()),
+	})
+}
+
+type ValidationErrorContextRateLimitExceededError struct{}
+
+func (r *ValidationErrorContextRateLimitExceededError) AddContext(_, _ string) {}
+
+func (r *ValidationErrorContextRateLimitExceededError) FinishInstanceContext() {}
+
+func NewRateLimitExceededError(until time.Time) error {
+	return errors.WithStack(&ValidationError{
+		ValidationError: &jsonschema.ValidationError{
+			Message:     `too many requests, please try again later`,
+			InstancePtr: "#/",
+			Context:     &ValidationErrorContextRateLimitExceededError{},
+		},
+		Messages: new(text.Messages).Add(text.NewErrorValidationRateLimitExceeded(until)),
+	})
+}
@@ -0,0 +1,181 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// FieldName is the name of the form field which carries the CAPTCHA response token.
+	FieldName = "captcha_token"
+
+	FlowLogin        = "login"
+	FlowRegistration = "registration"
+	FlowRecovery     = "recovery"
+)
+
+type (
+	// Provider verifies CAPTCHA response tokens.
+	Provider interface {
+		// ID returns the provider's ID (e.g. "siteverify").
+		ID() string
+
+		// SiteKey returns the public site key which is required by the UI to render the CAPTCHA challenge.
+		SiteKey() string
+
+		// Verify returns true if the CAPTCHA response token is valid. If the token could not be verified
+		// (e.g. because the provider is not reachable) an error is returned.
+		Verify(ctx context.Context, token, remoteIP string) (bool, error)
+	}
+
+	// Failure is a failed attempt to complete a flow which is protected by a CAPTCHA.
+	Failure struct {
+		ID        uuid.UUID `json:"id" db:"id" faker:"-"`
+		Flow      string    `json:"flow" db:"flow"`
+		IPAddress string    `json:"ip_address" db:"ip_address"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	}
+
+	managerDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		FailurePersistenceProvider
+	}
+
+	ManagementProvider interface {
+		CaptchaManager() *Manager
+	}
+
+	Manager struct {
+		d managerDependencies
+		c *http.Client
+	}
+)
+
+func (Failure) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_captcha_failures")
+}
+
+func NewFailure(flow, ip string) *Failure {
+	return &Failure{
+		ID:        x.NewUUID(),
+		Flow:      flow,
+		IPAddress: ip,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func NewManager(d managerDependencies, c *http.Client) *Manager {
+	return &Manager{d: d, c: c}
+}
+
+func (m *Manager) provider(ctx context.Context) (Provider, error) {
+	c := m.d.Config(ctx).SelfServiceCaptcha()
+	switch c.Provider {
+	case "siteverify":
+		return NewProviderSiteVerify(c.Config, m.c)
+	}
+	return nil, errors.Errorf("unknown CAPTCHA provider %q", c.Provider)
+}
+
+// isRisky returns true if the request is unlikely to come from a regular browser or app.
+func isRisky(r *http.Request) bool {
+	return len(r.Header.Get("User-Agent")) == 0
+}
+
+// IsRequired returns true if the request needs to solve a CAPTCHA challenge to complete the given flow.
+func (m *Manager) IsRequired(r *http.Request, flow string) (bool, error) {
+	c := m.d.Config(r.Context()).SelfServiceFlowCaptcha(flow)
+	if !c.Enabled {
+		return false, nil
+	}
+
+	if c.AfterFailures == 0 || (c.ChallengeRiskyRequests && isRisky(r)) {
+		return true, nil
+	}
+
+	since := time.Now().UTC().Add(-m.d.Config(r.Context()).SelfServiceCaptcha().FailureWindow)
+	failures, err := m.d.CaptchaFailurePersister().CountCaptchaFailures(r.Context(), flow, x.ClientIP(r), since)
+	if err != nil {
+		return false, err
+	}
+
+	return failures >= c.AfterFailures, nil
+}
+
+// PopulateChallenge adds the CAPTCHA challenge field to the form if the request needs to solve
+// a CAPTCHA challenge to complete the given flow.
+func (m *Manager) PopulateChallenge(r *http.Request, flow string, f form.FieldSetter) error {
+	if required, err := m.IsRequired(r, flow); err != nil {
+		return err
+	} else if !required {
+		return nil
+	}
+
+	p, err := m.provider(r.Context())
+	if err != nil {
+		return err
+	}
+
+	f.SetField(form.Field{
+		Name:     FieldName,
+		Type:     "captcha",
+		Required: true,
+		Messages: new(text.Messages).Add(text.NewInfoSelfServiceCaptchaChallenge(p.ID(), p.SiteKey())),
+	})
+	return nil
+}
+
+// Verify verifies the CAPTCHA response token if the request needs to solve a CAPTCHA challenge to
+// complete the given flow. It must be called before the strategy processes the request.
+func (m *Manager) Verify(r *http.Request, flow, token string) error {
+	if required, err := m.IsRequired(r, flow); err != nil {
+		return err
+	} else if !required {
+		return nil
+	}
+
+	if len(token) == 0 {
+		return errors.WithStack(schema.NewCaptchaInvalidError("#/" + FieldName))
+	}
+
+	p, err := m.provider(r.Context())
+	if err != nil {
+		return err
+	}
+
+	valid, err := p.Verify(r.Context(), token, x.ClientIP(r))
+	if err != nil {
+		return err
+	} else if !valid {
+		return errors.WithStack(schema.NewCaptchaInvalidError("#/" + FieldName))
+	}
+
+	return nil
+}
+
+// RegisterFailure records a failed attempt to complete the given flow from the request's IP address.
+func (m *Manager) RegisterFailure(r *http.Request, flow string) error {
+	if c := m.d.Config(r.Context()).SelfServiceFlowCaptcha(flow); !c.Enabled || c.AfterFailures == 0 {
+		return nil
+	}
+
+	return m.d.CaptchaFailurePersister().CreateCaptchaFailure(r.Context(), NewFailure(flow, x.ClientIP(r)))
+}
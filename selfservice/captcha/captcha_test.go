@@ -0,0 +1,171 @@
+package captcha_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/captcha"
+	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+func newSiteVerifyServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": r.PostForm.Get("response") == "valid",
+		}))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestManager(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	ts := newSiteVerifyServer(t)
+	conf.MustSet(config.ViperKeySelfServiceCaptchaConfig, map[string]interface{}{
+		"verify_url": ts.URL,
+		"site_key":   "site-key",
+		"secret":     "secret",
+	})
+
+	var setFlowConfig = func(t *testing.T, enabled bool, afterFailures int, risky bool) {
+		key := fmt.Sprintf("selfservice.flows.%s.captcha", captcha.FlowLogin)
+		conf.MustSet(key+".enabled", enabled)
+		conf.MustSet(key+".after_failures", afterFailures)
+		conf.MustSet(key+".challenge_risky_requests", risky)
+	}
+
+	var newRequest = func(ip, userAgent string) *http.Request {
+		r := httptest.NewRequest("POST", "/", nil)
+		r.RemoteAddr = ip + ":1234"
+		if len(userAgent) > 0 {
+			r.Header.Set("User-Agent", userAgent)
+		}
+		return r
+	}
+
+	var getField = func(f *form.HTMLForm) *form.Field {
+		for k := range f.Fields {
+			if f.Fields[k].Name == captcha.FieldName {
+				return &f.Fields[k]
+			}
+		}
+		return nil
+	}
+
+	var isRequired = func(t *testing.T, r *http.Request) bool {
+		required, err := reg.CaptchaManager().IsRequired(r, captcha.FlowLogin)
+		require.NoError(t, err)
+		return required
+	}
+
+	t.Run("method=IsRequired", func(t *testing.T) {
+		t.Run("case=disabled", func(t *testing.T) {
+			setFlowConfig(t, false, 0, true)
+			assert.False(t, isRequired(t, newRequest(x.NewUUID().String(), "")))
+		})
+
+		t.Run("case=always", func(t *testing.T) {
+			setFlowConfig(t, true, 0, false)
+			assert.True(t, isRequired(t, newRequest(x.NewUUID().String(), "agent")))
+		})
+
+		t.Run("case=risky requests", func(t *testing.T) {
+			setFlowConfig(t, true, 2, true)
+			assert.True(t, isRequired(t, newRequest(x.NewUUID().String(), "")))
+			assert.False(t, isRequired(t, newRequest(x.NewUUID().String(), "agent")))
+
+			setFlowConfig(t, true, 2, false)
+			assert.False(t, isRequired(t, newRequest(x.NewUUID().String(), "")))
+		})
+
+		t.Run("case=after failures", func(t *testing.T) {
+			setFlowConfig(t, true, 2, true)
+			ip := x.NewUUID().String()
+
+			for k := 0; k < 2; k++ {
+				assert.False(t, isRequired(t, newRequest(ip, "agent")))
+				require.NoError(t, reg.CaptchaManager().RegisterFailure(newRequest(ip, "agent"), captcha.FlowLogin))
+			}
+
+			assert.True(t, isRequired(t, newRequest(ip, "agent")))
+			assert.False(t, isRequired(t, newRequest(x.NewUUID().String(), "agent")))
+
+			required, err := reg.CaptchaManager().IsRequired(newRequest(ip, "agent"), captcha.FlowRegistration)
+			require.NoError(t, err)
+			assert.False(t, required)
+		})
+	})
+
+	t.Run("method=PopulateChallenge", func(t *testing.T) {
+		setFlowConfig(t, true, 0, true)
+
+		f := form.NewHTMLForm("")
+		require.NoError(t, reg.CaptchaManager().PopulateChallenge(newRequest(x.NewUUID().String(), "agent"), captcha.FlowLogin, f))
+
+		field := getField(f)
+		require.NotNil(t, field)
+		assert.Equal(t, "captcha", field.Type)
+		assert.True(t, field.Required)
+		require.Len(t, field.Messages, 1)
+		assert.Equal(t, text.InfoSelfServiceCaptchaChallenge, field.Messages[0].ID)
+		assert.Equal(t, "site-key", gjson.GetBytes(field.Messages[0].Context, "site_key").String())
+
+		setFlowConfig(t, false, 0, true)
+		f = form.NewHTMLForm("")
+		require.NoError(t, reg.CaptchaManager().PopulateChallenge(newRequest(x.NewUUID().String(), "agent"), captcha.FlowLogin, f))
+		assert.Nil(t, getField(f))
+	})
+
+	t.Run("method=Verify", func(t *testing.T) {
+		setFlowConfig(t, true, 0, true)
+		r := newRequest(x.NewUUID().String(), "agent")
+
+		require.NoError(t, reg.CaptchaManager().Verify(r, captcha.FlowLogin, "valid"))
+
+		for _, token := range []string{"", "invalid"} {
+			err := reg.CaptchaManager().Verify(r, captcha.FlowLogin, token)
+			require.Error(t, err)
+			var ve *schema.ValidationError
+			require.ErrorAs(t, err, &ve, "%+v", err)
+			assert.Equal(t, "#/"+captcha.FieldName, ve.InstancePtr)
+		}
+
+		setFlowConfig(t, false, 0, true)
+		require.NoError(t, reg.CaptchaManager().Verify(r, captcha.FlowLogin, ""))
+	})
+}
+
+func TestProviderSiteVerify(t *testing.T) {
+	ts := newSiteVerifyServer(t)
+
+	_, err := captcha.NewProviderSiteVerify(json.RawMessage(`{}`), http.DefaultClient)
+	require.Error(t, err)
+
+	p, err := captcha.NewProviderSiteVerify(json.RawMessage(fmt.Sprintf(`{"verify_url":%q,"site_key":"site-key","secret":"secret"}`, ts.URL)), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, "siteverify", p.ID())
+	assert.Equal(t, "site-key", p.SiteKey())
+
+	valid, err := p.Verify(context.Background(), "valid", "127.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = p.Verify(context.Background(), "invalid", "127.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
@@ -0,0 +1,48 @@
+package captcha
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/x"
+)
+
+type (
+	FailurePersister interface {
+		CreateCaptchaFailure(ctx context.Context, failure *Failure) error
+		CountCaptchaFailures(ctx context.Context, flow, ip string, since time.Time) (int, error)
+	}
+
+	FailurePersistenceProvider interface {
+		CaptchaFailurePersister() FailurePersister
+	}
+)
+
+func TestPersister(ctx context.Context, p FailurePersister) func(t *testing.T) {
+	var createFailure = func(t *testing.T, flow, ip string, ago time.Duration) {
+		f := NewFailure(flow, ip)
+		f.CreatedAt = f.CreatedAt.Add(-ago).Truncate(time.Second)
+		require.NoError(t, p.CreateCaptchaFailure(ctx, f))
+	}
+
+	return func(t *testing.T) {
+		t.Run("case=count failures", func(t *testing.T) {
+			ip := x.NewUUID().String()
+			createFailure(t, FlowLogin, ip, 0)
+			createFailure(t, FlowLogin, ip, time.Minute)
+			createFailure(t, FlowLogin, ip, time.Hour)
+			createFailure(t, FlowRegistration, ip, 0)
+			createFailure(t, FlowLogin, x.NewUUID().String(), 0)
+
+			for flow, expected := range map[string]int{FlowLogin: 2, FlowRegistration: 1, FlowRecovery: 0} {
+				actual, err := p.CountCaptchaFailures(ctx, flow, ip, time.Now().Add(-time.Minute*30))
+				require.NoError(t, err)
+				assert.Equal(t, expected, actual, flow)
+			}
+		})
+	}
+}
@@ -57,6 +57,7 @@ func (u FlowMethods) TableName(ctx context.Context) string {
 // swagger:ignore
 type FlowMethodConfigurator interface {
 	form.ErrorParser
+	form.FieldSetter
 	form.ValueSetter
 	form.Resetter
 	form.MessageResetter
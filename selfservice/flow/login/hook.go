@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
@@ -15,6 +19,10 @@ import (
 	"github.com/ory/kratos/x"
 )
 
+// routeSettingsBrowserFlow is the settings flow's settings.RouteInitBrowserFlow which can not be imported here
+// because the settings flow depends on the login flow.
+const routeSettingsBrowserFlow = "/self-service/settings/browser"
+
 type (
 	PreHookExecutor interface {
 		ExecuteLoginPreHook(w http.ResponseWriter, r *http.Request, a *Flow) error
@@ -37,6 +45,7 @@ type (
 		session.PersistenceProvider
 		x.WriterProvider
 		x.LoggingProvider
+		audit.RecorderProvider
 
 		HooksProvider
 	}
@@ -60,8 +69,18 @@ func NewHookExecutor(d executorDependencies) *HookExecutor {
 	return &HookExecutor{d: d}
 }
 
-func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity) error {
-	s := session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC()).Declassify()
+func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity, opts ...session.Option) error {
+	err := e.postLoginHook(w, r, ct, a, i, opts...)
+	e.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeLogin, ct.String(), i.ID, err))
+	return err
+}
+
+func (e *HookExecutor) postLoginHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity, opts ...session.Option) error {
+	s := session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC())
+	session.WithDevice(r)(s)
+	for _, opt := range opts {
+		opt(s)
+	}
 
 	e.d.Logger().
 		WithRequest(r).
@@ -104,7 +123,7 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, ct
 			WithField("identity_id", i.ID).
 			Info("Identity authenticated successfully and was issued an ORY Kratos Session Token.")
 
-		e.d.Writer().Write(w, r, &APIFlowResponse{Session: s, Token: s.Token})
+		e.d.Writer().Write(w, r, &APIFlowResponse{Session: s.Declassify(), Token: s.Token})
 		return nil
 	}
 
@@ -117,10 +136,28 @@ func (e *HookExecutor) PostLoginHook(w http.ResponseWriter, r *http.Request, ct
 		WithField("identity_id", i.ID).
 		WithField("session_id", s.ID).
 		Info("Identity authenticated successfully and was issued an ORY Kratos Session Cookie.")
+
+	if s.PasswordChangeRequired && !x.IsJSONRequest(r) {
+		// The password has expired, which is why the browser continues with the settings flow instead of
+		// returning to the application.
+		http.Redirect(w, r, e.passwordChangeURL(r, a).String(), http.StatusFound)
+		return nil
+	}
+
 	return x.SecureContentNegotiationRedirection(w, r, s.Declassify(), a.RequestURL,
 		e.d.Writer(), e.d.Config(r.Context()), x.SecureRedirectOverrideDefaultReturnTo(e.d.Config(r.Context()).SelfServiceFlowLoginReturnTo(ct.String())))
 }
 
+// passwordChangeURL returns the URL of the settings flow and keeps the login flow's return_to URL so that the
+// browser is returned to it after the password was changed.
+func (e *HookExecutor) passwordChangeURL(r *http.Request, a *Flow) *url.URL {
+	settingsURL := urlx.AppendPaths(e.d.Config(r.Context()).SelfPublicURL(r), routeSettingsBrowserFlow)
+	if requestURL, err := url.Parse(a.RequestURL); err == nil && len(requestURL.Query().Get("return_to")) > 0 {
+		return urlx.CopyWithQuery(settingsURL, url.Values{"return_to": {requestURL.Query().Get("return_to")}})
+	}
+	return settingsURL
+}
+
 func (e *HookExecutor) PreLoginHook(w http.ResponseWriter, r *http.Request, a *Flow) error {
 	for _, executor := range e.d.PreLoginHooks(r.Context()) {
 		if err := executor.ExecuteLoginPreHook(w, r, a); err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/gobuffalo/httptest"
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 
 	"github.com/ory/kratos/driver/config"
@@ -17,9 +18,17 @@ import (
 	"github.com/ory/kratos/internal/testhelpers"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
+type postHookFunc func(w http.ResponseWriter, r *http.Request, a *login.Flow, s *session.Session) error
+
+func (f postHookFunc) ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, a *login.Flow, s *session.Session) error {
+	return f(w, r, a, s)
+}
+
 func TestLoginExecutor(t *testing.T) {
 	for _, strategy := range []string{
 		identity.CredentialsTypePassword.String(),
@@ -42,8 +51,11 @@ func TestLoginExecutor(t *testing.T) {
 				router.GET("/login/post", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 					a := login.NewFlow(time.Minute, "", r, ft)
 					a.RequestURL = x.RequestURL(r).String()
+					i := testhelpers.SelfServiceHookFakeIdentity(t)
+					i.MetadataAdmin = []byte(`{"crm_id":"foo"}`)
+					require.NoError(t, reg.IdentityManager().Create(r.Context(), i))
 					testhelpers.SelfServiceHookLoginErrorHandler(t, w, r,
-						reg.LoginHookExecutor().PostLoginHook(w, r, identity.CredentialsType(strategy), a, testhelpers.SelfServiceHookCreateFakeIdentity(t, reg)))
+						reg.LoginHookExecutor().PostLoginHook(w, r, identity.CredentialsType(strategy), a, i))
 				})
 
 				ts := httptest.NewServer(router)
@@ -133,6 +145,33 @@ func TestLoginExecutor(t *testing.T) {
 					assert.EqualValues(t, http.StatusOK, res.StatusCode)
 					assert.NotEmpty(t, gjson.Get(body, "session.identity.id"))
 				})
+
+				t.Run("case=pass admin metadata to hooks but not to the client", func(t *testing.T) {
+					t.Cleanup(testhelpers.SelfServiceHookConfigReset(t, conf))
+
+					var metadata []byte
+					reg.WithHooks(map[string]func(config.SelfServiceHook) interface{}{
+						"capture": func(config.SelfServiceHook) interface{} {
+							return postHookFunc(func(_ http.ResponseWriter, _ *http.Request, _ *login.Flow, s *session.Session) error {
+								metadata = s.Identity.MetadataAdmin
+								return nil
+							})
+						},
+					})
+					t.Cleanup(func() {
+						reg.WithHooks(map[string]func(config.SelfServiceHook) interface{}{
+							"err": func(c config.SelfServiceHook) interface{} {
+								return &hook.Error{Config: c.Config}
+							},
+						})
+					})
+					viperSetPost(t, conf, strategy, []config.SelfServiceHook{{Name: "capture"}})
+
+					res, body := makeRequestPost(t, newServer(t, flow.TypeAPI), true, url.Values{})
+					assert.EqualValues(t, http.StatusOK, res.StatusCode)
+					assert.JSONEq(t, `{"crm_id":"foo"}`, string(metadata))
+					assert.False(t, gjson.Get(body, "session.identity.metadata_admin").Exists(), "%s", body)
+				})
 			})
 
 			t.Run("type=api", func(t *testing.T) {
@@ -16,6 +16,10 @@ type Strategy interface {
 	PopulateLoginMethod(r *http.Request, sr *Flow) error
 }
 
+type AdminHandler interface {
+	RegisterAdminLoginRoutes(admin *x.RouterAdmin)
+}
+
 type Strategies []Strategy
 
 func (s Strategies) Strategy(id identity.CredentialsType) (Strategy, error) {
@@ -44,6 +48,14 @@ func (s Strategies) RegisterPublicRoutes(r *x.RouterPublic) {
 	}
 }
 
+func (s Strategies) RegisterAdminRoutes(r *x.RouterAdmin) {
+	for _, ss := range s {
+		if h, ok := ss.(AdminHandler); ok {
+			h.RegisterAdminLoginRoutes(r)
+		}
+	}
+}
+
 type StrategyProvider interface {
 	LoginStrategies(ctx context.Context) Strategies
 }
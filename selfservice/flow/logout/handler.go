@@ -3,11 +3,13 @@ package logout
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/julienschmidt/httprouter"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
@@ -22,6 +24,8 @@ type (
 		session.ManagementProvider
 		errorx.ManagementProvider
 		config.Provider
+		x.LoggingProvider
+		login.StrategyProvider
 	}
 	HandlerProvider interface {
 		LogoutHandler() *Handler
@@ -29,6 +33,14 @@ type (
 	Handler struct {
 		d handlerDependencies
 	}
+
+	// UpstreamLogoutStrategy is implemented by strategies which sign in with an upstream identity provider and
+	// can end the upstream session when the ORY Kratos session ends.
+	UpstreamLogoutStrategy interface {
+		// UpstreamLogoutURL returns the URL which ends the upstream session the ORY Kratos session was created
+		// from and then redirects to returnTo, or nil if there is no upstream session to end.
+		UpstreamLogoutURL(r *http.Request, s *session.Session, returnTo *url.URL) (*url.URL, error)
+	}
 )
 
 func NewHandler(d handlerDependencies) *Handler {
@@ -61,6 +73,10 @@ func (h *Handler) RegisterPublicRoutes(router *x.RouterPublic) {
 func (h *Handler) logout(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	_ = h.d.CSRFHandler().RegenerateToken(w, r)
 
+	// The session is fetched before it is purged because it might have been created from an upstream session
+	// which should end as well. Sessions requiring a password change can be logged out as well.
+	sess, _ := h.d.SessionManager().FetchFromRequest(session.WithPasswordChangeAllowed(r.Context()), r)
+
 	if err := h.d.SessionManager().PurgeFromRequest(r.Context(), w, r); err != nil {
 		h.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
 		return
@@ -77,5 +93,35 @@ func (h *Handler) logout(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
+	if sess != nil {
+		if upstream := h.upstreamLogoutURL(r, sess, ret); upstream != nil {
+			ret = upstream
+		}
+	}
+
 	http.Redirect(w, r, ret.String(), http.StatusFound)
 }
+
+// upstreamLogoutURL asks the strategies whether the session's upstream session should end as well. Errors are
+// logged only because the ORY Kratos session has already ended.
+func (h *Handler) upstreamLogoutURL(r *http.Request, s *session.Session, returnTo *url.URL) *url.URL {
+	for _, strategy := range h.d.LoginStrategies(r.Context()) {
+		upstream, ok := strategy.(UpstreamLogoutStrategy)
+		if !ok {
+			continue
+		}
+
+		u, err := upstream.UpstreamLogoutURL(r, s, returnTo)
+		if err != nil {
+			h.d.Logger().
+				WithRequest(r).
+				WithError(err).
+				WithField("session_id", s.ID).
+				Warn("Unable to end the upstream session, redirecting without ending it.")
+			continue
+		} else if u != nil {
+			return u
+		}
+	}
+	return nil
+}
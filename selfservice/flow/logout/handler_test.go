@@ -2,10 +2,12 @@ package logout_test
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gobuffalo/httptest"
 	"github.com/julienschmidt/httprouter"
@@ -17,6 +19,7 @@ import (
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/internal/testhelpers"
 	"github.com/ory/kratos/selfservice/flow/logout"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 	"github.com/ory/nosurf"
@@ -108,4 +111,64 @@ func TestLogoutHandler(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, returnToURL, res.Request.URL.String())
 	})
+
+	t.Run("case=ends the upstream session", func(t *testing.T) {
+		var endSession url.Values
+		var upstream *httptest.Server
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/.well-known/openid-configuration":
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"issuer":                 upstream.URL,
+					"authorization_endpoint": upstream.URL + "/authorize",
+					"token_endpoint":         upstream.URL + "/token",
+					"jwks_uri":               upstream.URL + "/jwks",
+					"end_session_endpoint":   upstream.URL + "/logout",
+				})
+			case "/logout":
+				endSession = r.URL.Query()
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer upstream.Close()
+
+		conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeOIDC), map[string]interface{}{
+			"enabled": true,
+			"config": &oidc.ConfigurationCollection{Providers: []oidc.Configuration{{
+				ID:                 "upstream",
+				Provider:           "generic",
+				ClientID:           "client",
+				ClientSecret:       "secret",
+				IssuerURL:          upstream.URL,
+				EndSessionOnLogout: true,
+			}}},
+		})
+
+		router.GET("/set-upstream", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(r.Context(), i))
+
+			idToken, err := reg.Cipher().Encrypt(r.Context(), []byte("id-token"))
+			require.NoError(t, err)
+
+			s := session.NewActiveSession(i, conf, time.Now().UTC())
+			session.WithUpstream("upstream", "subject", "sid", idToken)(s)
+			require.NoError(t, reg.SessionManager().CreateAndIssueCookie(r.Context(), w, r, s))
+			w.WriteHeader(http.StatusOK)
+		})
+
+		client := testhelpers.NewClientWithCookies(t)
+		testhelpers.MockHydrateCookieClient(t, client, ts.URL+"/set-upstream")
+
+		res, err := client.Get(ts.URL + logout.RouteBrowser)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, upstream.URL+"/logout", res.Request.URL.Scheme+"://"+res.Request.URL.Host+res.Request.URL.Path)
+		assert.Equal(t, "client", endSession.Get("client_id"))
+		assert.Equal(t, "id-token", endSession.Get("id_token_hint"))
+		assert.Equal(t, redirTS.URL, endSession.Get("post_logout_redirect_uri"))
+	})
 }
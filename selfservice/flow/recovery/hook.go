@@ -0,0 +1,74 @@
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	PostHookExecutor interface {
+		ExecutePostRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error
+	}
+	PostHookExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error
+
+	HooksProvider interface {
+		PostRecoveryHooks(ctx context.Context) []PostHookExecutor
+	}
+)
+
+type (
+	executorDependencies interface {
+		x.LoggingProvider
+
+		HooksProvider
+	}
+	HookExecutor struct {
+		d executorDependencies
+	}
+	HookExecutorProvider interface {
+		RecoveryExecutor() *HookExecutor
+	}
+)
+
+func (f PostHookExecutorFunc) ExecutePostRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error {
+	return f(w, r, a, s)
+}
+
+func PostHookExecutorNames(e []PostHookExecutor) []string {
+	names := make([]string, len(e))
+	for k, ee := range e {
+		names[k] = fmt.Sprintf("%T", ee)
+	}
+	return names
+}
+
+func NewHookExecutor(d executorDependencies) *HookExecutor {
+	return &HookExecutor{d: d}
+}
+
+// PostRecoveryHook runs the post recovery hooks after the recovered identity was issued a session.
+func (e *HookExecutor) PostRecoveryHook(w http.ResponseWriter, r *http.Request, a *Flow, s *session.Session) error {
+	e.d.Logger().
+		WithRequest(r).
+		WithField("identity_id", s.IdentityID).
+		Debug("Running ExecutePostRecoveryHooks.")
+	for k, executor := range e.d.PostRecoveryHooks(r.Context()) {
+		if err := executor.ExecutePostRecoveryHook(w, r, a, s); err != nil {
+			return err
+		}
+
+		e.d.Logger().
+			WithRequest(r).
+			WithField("executor", fmt.Sprintf("%T", executor)).
+			WithField("executor_position", k).
+			WithField("executors", PostHookExecutorNames(e.d.PostRecoveryHooks(r.Context()))).
+			WithField("identity_id", s.IdentityID).
+			Debug("ExecutePostRecoveryHook completed successfully.")
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ory/x/sqlcon"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
@@ -68,6 +69,7 @@ type (
 		HooksProvider
 		x.LoggingProvider
 		x.WriterProvider
+		audit.RecorderProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -81,7 +83,13 @@ func NewHookExecutor(d executorDependencies) *HookExecutor {
 	return &HookExecutor{d: d}
 }
 
-func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity) error {
+func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity, opts ...session.Option) error {
+	err := e.postRegistrationHook(w, r, ct, a, i, opts...)
+	e.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeRegistration, ct.String(), i.ID, err))
+	return err
+}
+
+func (e *HookExecutor) postRegistrationHook(w http.ResponseWriter, r *http.Request, ct identity.CredentialsType, a *Flow, i *identity.Identity, opts ...session.Option) error {
 	e.d.Logger().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
@@ -129,6 +137,11 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		Info("A new identity has registered using self-service registration.")
 
 	s := session.NewActiveSession(i, e.d.Config(r.Context()), time.Now().UTC())
+	session.WithDevice(r)(s)
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	e.d.Logger().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
@@ -166,7 +179,7 @@ func (e *HookExecutor) PostRegistrationHook(w http.ResponseWriter, r *http.Reque
 		Debug("Post registration execution hooks completed successfully.")
 
 	if a.Type == flow.TypeAPI {
-		e.d.Writer().Write(w, r, &APIFlowResponse{Identity: i})
+		e.d.Writer().Write(w, r, &APIFlowResponse{Identity: i.Declassify()})
 		return nil
 	}
 
@@ -158,9 +158,11 @@ func (s *ErrorHandler) WriteFlowError(
 
 	updatedFlow, innerErr := s.d.SettingsFlowPersister().GetSettingsFlow(r.Context(), f.ID)
 	if innerErr != nil {
-		s.forward(w, r, updatedFlow, innerErr)
+		s.forward(w, r, f, innerErr)
+		return
 	}
 
+	updatedFlow.Identity = updatedFlow.Identity.Declassify()
 	s.d.Writer().WriteCode(w, r, x.RecoverStatusCode(err, http.StatusBadRequest), updatedFlow)
 }
 
@@ -73,13 +73,15 @@ func NewHandler(d handlerDependencies) *Handler {
 func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 	h.d.CSRFHandler().IgnorePath(RouteInitAPIFlow)
 
-	public.GET(RouteInitBrowserFlow, h.d.SessionHandler().IsAuthenticated(h.initBrowserFlow, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	// The settings flow is used to change an expired password, which is why it accepts sessions requiring a
+	// password change. All settings strategies except for the password strategy still reject them.
+	public.GET(RouteInitBrowserFlow, session.AllowPasswordChange(h.d.SessionHandler().IsAuthenticated(h.initBrowserFlow, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		http.Redirect(w, r, h.d.Config(r.Context()).SelfServiceFlowLoginUI().String(), http.StatusFound)
-	}))
+	})))
 
-	public.GET(RouteInitAPIFlow, h.d.SessionHandler().IsAuthenticated(h.initApiFlow, nil))
+	public.GET(RouteInitAPIFlow, session.AllowPasswordChange(h.d.SessionHandler().IsAuthenticated(h.initApiFlow, nil)))
 
-	public.GET(RouteGetFlow, h.d.SessionHandler().IsAuthenticated(h.fetchPublicFlow, OnUnauthenticated(h.d)))
+	public.GET(RouteGetFlow, session.AllowPasswordChange(h.d.SessionHandler().IsAuthenticated(h.fetchPublicFlow, OnUnauthenticated(h.d))))
 }
 
 func (h *Handler) RegisterAdminRoutes(admin *x.RouterAdmin) {
@@ -148,6 +150,7 @@ func (h *Handler) initApiFlow(w http.ResponseWriter, r *http.Request, _ httprout
 		return
 	}
 
+	f.Identity = f.Identity.Declassify()
 	h.d.Writer().Write(w, r, f)
 }
 
@@ -270,6 +273,9 @@ func (h *Handler) fetchFlow(w http.ResponseWriter, r *http.Request, checkSession
 		if pr.IdentityID != sess.Identity.ID {
 			return errors.WithStack(herodot.ErrForbidden.WithReasonf("The request was made for another identity and has been blocked for security reasons."))
 		}
+
+		// Admin metadata must never be exposed through the public API.
+		pr.Identity = pr.Identity.Declassify()
 	}
 
 	if pr.ExpiresAt.Before(time.Now().UTC()) {
@@ -8,13 +8,16 @@ import (
 
 	"github.com/ory/kratos/schema"
 	"github.com/ory/x/sqlcon"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -27,13 +30,21 @@ type (
 		ExecuteSettingsPostPersistHook(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error
 	}
 	PostHookPostPersistExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error
-	HooksProvider                   interface {
+	PostHookDeletionExecutor        interface {
+		ExecuteSettingsDeletionHook(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error
+	}
+	PostHookDeletionExecutorFunc func(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error
+	HooksProvider                interface {
 		PostSettingsPrePersistHooks(ctx context.Context, settingsType string) []PostHookPrePersistExecutor
 		PostSettingsPostPersistHooks(ctx context.Context, settingsType string) []PostHookPostPersistExecutor
+		PostSettingsDeletionHooks(ctx context.Context) []PostHookDeletionExecutor
 	}
 	executorDependencies interface {
 		identity.ManagementProvider
 		identity.ValidationProvider
+		identity.PrivilegedPoolProvider
+		session.ManagementProvider
+		session.PersistenceProvider
 		config.Provider
 
 		HooksProvider
@@ -41,6 +52,7 @@ type (
 
 		x.LoggingProvider
 		x.WriterProvider
+		audit.RecorderProvider
 	}
 	HookExecutor struct {
 		d executorDependencies
@@ -58,6 +70,10 @@ func (f PostHookPostPersistExecutorFunc) ExecuteSettingsPostPersistHook(w http.R
 	return f(w, r, a, s)
 }
 
+func (f PostHookDeletionExecutorFunc) ExecuteSettingsDeletionHook(w http.ResponseWriter, r *http.Request, a *Flow, s *identity.Identity) error {
+	return f(w, r, a, s)
+}
+
 func PostHookPostPersistExecutorNames(e []PostHookPostPersistExecutor) []string {
 	names := make([]string, len(e))
 	for k, ee := range e {
@@ -74,6 +90,14 @@ func PostHookPrePersistExecutorNames(e []PostHookPrePersistExecutor) []string {
 	return names
 }
 
+func PostHookDeletionExecutorNames(e []PostHookDeletionExecutor) []string {
+	names := make([]string, len(e))
+	for k, ee := range e {
+		names[k] = fmt.Sprintf("%T", ee)
+	}
+	return names
+}
+
 func NewHookExecutor(d executorDependencies) *HookExecutor {
 	return &HookExecutor{d: d}
 }
@@ -91,6 +115,12 @@ func WithCallback(cb func(ctxUpdate *UpdateContext) error) func(o *postSettingsH
 }
 
 func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request, settingsType string, ctxUpdate *UpdateContext, i *identity.Identity, opts ...PostSettingsHookOption) error {
+	err := e.postSettingsHook(w, r, settingsType, ctxUpdate, i, opts...)
+	e.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeSettings, settingsType, i.ID, err))
+	return err
+}
+
+func (e *HookExecutor) postSettingsHook(w http.ResponseWriter, r *http.Request, settingsType string, ctxUpdate *UpdateContext, i *identity.Identity, opts ...PostSettingsHookOption) error {
 	e.d.Logger().
 		WithRequest(r).
 		WithField("identity_id", i.ID).
@@ -146,6 +176,7 @@ func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request,
 
 	ctxUpdate.Session.Identity = i
 	ctxUpdate.Flow.State = StateSuccess
+	ctxUpdate.Flow.Active = sqlxx.NullString(settingsType)
 	if config.cb != nil {
 		if err := config.cb(ctxUpdate); err != nil {
 			return err
@@ -197,7 +228,8 @@ func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request,
 			return err
 		}
 
-		e.d.Writer().Write(w, r, &APIFlowResponse{Flow: updatedFlow, Identity: i})
+		updatedFlow.Identity = updatedFlow.Identity.Declassify()
+		e.d.Writer().Write(w, r, &APIFlowResponse{Flow: updatedFlow, Identity: i.Declassify()})
 		return nil
 	}
 
@@ -206,3 +238,91 @@ func (e *HookExecutor) PostSettingsHook(w http.ResponseWriter, r *http.Request,
 			e.d.Config(r.Context()).SelfServiceFlowSettingsReturnTo(settingsType,
 				ctxUpdate.Flow.AppendTo(e.d.Config(r.Context()).SelfServiceFlowSettingsUI()))))
 }
+
+// PostDeletionHook deletes the identity of the session, or deactivates it and schedules its deletion if a grace
+// period is configured. All sessions of the identity are revoked before the deletion hooks run.
+func (e *HookExecutor) PostDeletionHook(w http.ResponseWriter, r *http.Request, settingsType string, ctxUpdate *UpdateContext) error {
+	err := e.postDeletionHook(w, r, settingsType, ctxUpdate)
+	e.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeIdentityDeleted, settingsType, ctxUpdate.Session.IdentityID, err))
+	return err
+}
+
+func (e *HookExecutor) postDeletionHook(w http.ResponseWriter, r *http.Request, settingsType string, ctxUpdate *UpdateContext) error {
+	i, err := e.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.IdentityID)
+	if err != nil {
+		return err
+	}
+
+	if err := e.d.SessionManager().PurgeFromRequest(r.Context(), w, r); err != nil {
+		return err
+	}
+
+	if err := e.d.SessionPersister().DeleteSessionsByIdentity(r.Context(), i.ID); err != nil {
+		return err
+	}
+
+	if grace := e.d.Config(r.Context()).SelfServiceDeletionGracePeriod(); grace > 0 {
+		scheduledAt := time.Now().UTC().Add(grace)
+		i.State = identity.StateInactive
+		i.DeletionScheduledAt = &scheduledAt
+		if err := e.d.IdentityManager().Update(r.Context(), i, identity.ManagerAllowWriteProtectedTraits); err != nil {
+			return err
+		}
+
+		e.d.Audit().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			WithField("deletion_scheduled_at", scheduledAt).
+			Info("An identity has scheduled its deletion using self-service settings.")
+	} else {
+		if err := e.d.PrivilegedIdentityPool().DeleteIdentity(r.Context(), i.ID); err != nil {
+			return err
+		}
+
+		e.d.Audit().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			Info("An identity has deleted itself using self-service settings.")
+	}
+
+	for k, executor := range e.d.PostSettingsDeletionHooks(r.Context()) {
+		logFields := logrus.Fields{
+			"executor":          fmt.Sprintf("%T", executor),
+			"executor_position": k,
+			"executors":         PostHookDeletionExecutorNames(e.d.PostSettingsDeletionHooks(r.Context())),
+			"identity_id":       i.ID,
+			"flow_method":       settingsType,
+		}
+
+		if err := executor.ExecuteSettingsDeletionHook(w, r, ctxUpdate.Flow, i); err != nil {
+			if errors.Is(err, ErrHookAbortRequest) {
+				e.d.Logger().WithRequest(r).WithFields(logFields).
+					Debug("A ExecuteSettingsDeletionHook hook aborted early.")
+				return nil
+			}
+			return err
+		}
+
+		e.d.Logger().WithRequest(r).WithFields(logFields).Debug("ExecuteSettingsDeletionHook completed successfully.")
+	}
+
+	if ctxUpdate.Flow.Type == flow.TypeAPI || x.IsJSONRequest(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	returnTo, err := x.SecureRedirectTo(r, e.d.Config(r.Context()).SelfServiceBrowserDefaultReturnTo(),
+		x.SecureRedirectUseSourceURL(ctxUpdate.Flow.RequestURL),
+		x.SecureRedirectAllowURLs(e.d.Config(r.Context()).SelfServiceBrowserWhitelistedReturnToDomains()),
+		x.SecureRedirectAllowSelfServiceURLs(e.d.Config(r.Context()).SelfPublicURL(r)),
+		x.SecureRedirectOverrideDefaultReturnTo(
+			e.d.Config(r.Context()).SelfServiceFlowSettingsReturnTo(settingsType,
+				e.d.Config(r.Context()).SelfServiceBrowserDefaultReturnTo())),
+	)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, r, returnTo.String(), http.StatusFound)
+	return nil
+}
@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	StrategyProfile = "profile"
+	StrategyProfile  = "profile"
+	StrategyDeletion = "deletion"
 )
 
 var pkgName = reflect.TypeOf(Strategies{}).PkgPath()
@@ -39,6 +39,7 @@ type Field struct {
 	// - datetime-local
 	// - number
 	// - submit
+	// - captcha
 	// required: true
 	Type string `json:"type"`
 
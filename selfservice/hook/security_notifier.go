@@ -0,0 +1,143 @@
+package hook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+var _ settings.PostHookPostPersistExecutor = new(SecurityNotifier)
+var _ recovery.PostHookExecutor = new(SecurityNotifier)
+var _ login.PostHookExecutor = new(SecurityNotifier)
+var _ oidc.LinkNotifier = new(SecurityNotifier)
+
+type (
+	securityNotifierDependencies interface {
+		courier.Provider
+		config.Provider
+		session.PersistenceProvider
+		x.LoggingProvider
+	}
+	// SecurityNotifier sends emails to the identity's addresses when security relevant changes happen, if the
+	// notification type is enabled.
+	SecurityNotifier struct {
+		r securityNotifierDependencies
+	}
+)
+
+func NewSecurityNotifier(r securityNotifierDependencies) *SecurityNotifier {
+	return &SecurityNotifier{r: r}
+}
+
+func (e *SecurityNotifier) ExecuteSettingsPostPersistHook(_ http.ResponseWriter, r *http.Request, a *settings.Flow, i *identity.Identity) error {
+	switch a.Active.String() {
+	case identity.CredentialsTypePassword.String():
+		e.notify(r, i, template.SecurityNotificationPasswordChanged, "")
+	case identity.CredentialsTypeOIDC.String():
+		if provider := oidc.LinkedProviderFromContext(r.Context()); len(provider) > 0 {
+			e.notify(r, i, template.SecurityNotificationOIDCLinked, provider)
+		}
+	}
+	return nil
+}
+
+func (e *SecurityNotifier) ExecutePostRecoveryHook(_ http.ResponseWriter, r *http.Request, _ *recovery.Flow, s *session.Session) error {
+	e.notify(r, s.Identity, template.SecurityNotificationRecoveryCompleted, "")
+	return nil
+}
+
+// ExecuteLoginPostHook sends a notification if the identity signs in with a user agent which none of its
+// previous sessions used. Sessions which were issued before user agents were recorded are ignored, which is why
+// nothing is sent if no previous session has a known user agent.
+func (e *SecurityNotifier) ExecuteLoginPostHook(_ http.ResponseWriter, r *http.Request, _ *login.Flow, s *session.Session) error {
+	if !e.r.Config(r.Context()).SelfServiceSecurityNotificationEnabled(string(template.SecurityNotificationLoginNewDevice)) {
+		return nil
+	}
+
+	known, err := e.r.SessionPersister().HasSessionWithUserAgent(r.Context(), s.IdentityID, s.ID, "")
+	if err != nil {
+		return err
+	} else if !known {
+		return nil
+	}
+
+	if len(s.UserAgent) > 0 {
+		seen, err := e.r.SessionPersister().HasSessionWithUserAgent(r.Context(), s.IdentityID, s.ID, s.UserAgent)
+		if err != nil {
+			return err
+		} else if seen {
+			return nil
+		}
+	}
+
+	e.notify(r, s.Identity, template.SecurityNotificationLoginNewDevice, "")
+	return nil
+}
+
+// NotifyProviderLinked sends a notification if an OpenID Connect provider was linked to the identity after it
+// signed in with its existing credentials.
+func (e *SecurityNotifier) NotifyProviderLinked(r *http.Request, i *identity.Identity, provider string) {
+	e.notify(r, i, template.SecurityNotificationOIDCLinked, provider)
+}
+
+// notify queues the notification for each of the identity's email addresses. A notification which can not be
+// queued must not fail the action which caused it, which is why errors are logged instead of returned.
+func (e *SecurityNotifier) notify(r *http.Request, i *identity.Identity, t template.SecurityNotificationType, provider string) {
+	if i == nil || !e.r.Config(r.Context()).SelfServiceSecurityNotificationEnabled(string(t)) {
+		return
+	}
+
+	for _, to := range e.recipients(i) {
+		if _, err := e.r.Courier(r.Context()).QueueEmail(r.Context(), template.NewSecurityNotification(e.r.Config(r.Context()), t, &template.SecurityNotificationModel{
+			To:         to,
+			IPAddress:  x.ClientIP(r),
+			UserAgent:  r.UserAgent(),
+			OccurredAt: time.Now().UTC(),
+			Provider:   provider,
+		})); err != nil {
+			e.r.Logger().
+				WithRequest(r).
+				WithError(err).
+				WithField("identity_id", i.ID).
+				WithField("notification", t).
+				Error("Unable to queue security notification email.")
+			continue
+		}
+
+		e.r.Logger().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			WithField("notification", t).
+			Debug("Queued security notification email.")
+	}
+}
+
+// recipients returns the identity's email recovery addresses, or its email verifiable addresses if it has no
+// recovery addresses.
+func (e *SecurityNotifier) recipients(i *identity.Identity) (to []string) {
+	for _, a := range i.RecoveryAddresses {
+		if a.Via == identity.RecoveryAddressTypeEmail {
+			to = append(to, a.Value)
+		}
+	}
+	if len(to) > 0 {
+		return to
+	}
+
+	for _, a := range i.VerifiableAddresses {
+		if a.Via == identity.VerifiableAddressTypeEmail {
+			to = append(to, a.Value)
+		}
+	}
+	return to
+}
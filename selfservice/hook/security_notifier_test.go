@@ -0,0 +1,155 @@
+package hook_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/courier"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/hook"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/sqlxx"
+)
+
+func TestSecurityNotifier(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/verify.schema.json")
+	conf.MustSet(config.ViperKeyPublicBaseURL, "https://www.ory.sh/")
+	conf.MustSet(config.ViperKeyCourierSMTPURL, "smtp://foo@bar@dev.null/")
+
+	var enable = func(t *testing.T, n template.SecurityNotificationType) {
+		key := config.ViperKeySelfServiceSecurityNotifications + "." + string(n) + ".enabled"
+		conf.MustSet(key, true)
+		t.Cleanup(func() {
+			conf.MustSet(key, false)
+		})
+	}
+
+	var newRequest = func(ctx context.Context, userAgent string) *http.Request {
+		r := httptest.NewRequest("POST", "/", nil).WithContext(ctx)
+		r.Header.Set("User-Agent", userAgent)
+		return r
+	}
+
+	var createIdentity = func(t *testing.T) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"emails":["` + x.NewUUID().String() + `@ory.sh","` + x.NewUUID().String() + `@ory.sh"]}`)
+		require.NoError(t, reg.IdentityManager().Create(ctx, i))
+		return i
+	}
+
+	var messages = func(t *testing.T) []courier.Message {
+		var result []courier.Message
+		for {
+			ms, err := reg.CourierPersister().NextMessages(ctx, 10)
+			if errors.Is(err, courier.ErrQueueEmpty) {
+				return result
+			}
+			require.NoError(t, err)
+			for _, m := range ms {
+				require.NoError(t, reg.CourierPersister().SetMessageStatus(ctx, m.ID, courier.MessageStatusSent))
+			}
+			result = append(result, ms...)
+		}
+	}
+
+	var createSession = func(t *testing.T, i *identity.Identity, userAgent string) *session.Session {
+		s := session.NewActiveSession(i, conf, time.Now().UTC())
+		s.UserAgent = userAgent
+		require.NoError(t, reg.SessionPersister().CreateSession(ctx, s))
+		return s
+	}
+
+	h := hook.NewSecurityNotifier(reg)
+
+	t.Run("case=should not send anything if disabled", func(t *testing.T) {
+		i := createIdentity(t)
+		f := &settings.Flow{Active: sqlxx.NullString(identity.CredentialsTypePassword)}
+		require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest(ctx, "Mozilla/5.0"), f, i))
+		require.NoError(t, h.ExecutePostRecoveryHook(httptest.NewRecorder(), newRequest(ctx, "Mozilla/5.0"), nil, &session.Session{Identity: i}))
+		assert.Empty(t, messages(t))
+	})
+
+	t.Run("case=should send password changed notification", func(t *testing.T) {
+		enable(t, template.SecurityNotificationPasswordChanged)
+		i := createIdentity(t)
+
+		f := &settings.Flow{Active: sqlxx.NullString(identity.CredentialsTypePassword)}
+		require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest(ctx, "Mozilla/5.0"), f, i))
+
+		f = &settings.Flow{Active: "profile"}
+		require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest(ctx, "Mozilla/5.0"), f, i))
+
+		actual := messages(t)
+		require.Len(t, actual, 2)
+		assert.ElementsMatch(t, []string{i.VerifiableAddresses[0].Value, i.VerifiableAddresses[1].Value}, []string{actual[0].Recipient, actual[1].Recipient})
+		assert.Contains(t, actual[0].Subject, "password")
+		assert.Contains(t, actual[0].Body, "Mozilla/5.0")
+		assert.Contains(t, actual[0].Body, "192.0.2.1")
+	})
+
+	t.Run("case=should send oidc linked notification", func(t *testing.T) {
+		enable(t, template.SecurityNotificationOIDCLinked)
+		i := createIdentity(t)
+
+		f := &settings.Flow{Active: sqlxx.NullString(identity.CredentialsTypeOIDC)}
+		require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest(ctx, "Mozilla/5.0"), f, i))
+		assert.Empty(t, messages(t), "unlinking a provider must not send a notification")
+
+		require.NoError(t, h.ExecuteSettingsPostPersistHook(httptest.NewRecorder(), newRequest(oidc.WithLinkedProvider(ctx, "github"), "Mozilla/5.0"), f, i))
+		actual := messages(t)
+		require.Len(t, actual, 2)
+		assert.Contains(t, actual[0].Body, "github")
+	})
+
+	t.Run("case=should send recovery completed notification", func(t *testing.T) {
+		enable(t, template.SecurityNotificationRecoveryCompleted)
+		i := createIdentity(t)
+
+		require.NoError(t, h.ExecutePostRecoveryHook(httptest.NewRecorder(), newRequest(ctx, "Mozilla/5.0"), nil, &session.Session{Identity: i}))
+		actual := messages(t)
+		require.Len(t, actual, 2)
+		assert.Contains(t, actual[0].Body, "Mozilla/5.0")
+	})
+
+	t.Run("case=should send login from new device notification", func(t *testing.T) {
+		enable(t, template.SecurityNotificationLoginNewDevice)
+		i := createIdentity(t)
+
+		var login = func(t *testing.T, userAgent string) {
+			s := session.NewActiveSession(i, conf, time.Now().UTC())
+			s.UserAgent = userAgent
+			require.NoError(t, h.ExecuteLoginPostHook(httptest.NewRecorder(), newRequest(ctx, userAgent), nil, s))
+		}
+
+		login(t, "Mozilla/5.0")
+		assert.Empty(t, messages(t), "the first login must not send a notification")
+
+		createSession(t, i, "")
+		login(t, "Mozilla/5.0")
+		assert.Empty(t, messages(t), "sessions with unknown user agents must be ignored")
+
+		createSession(t, i, "Mozilla/5.0")
+		login(t, "Mozilla/5.0")
+		assert.Empty(t, messages(t), "known devices must not send a notification")
+
+		login(t, "curl/7.64.1")
+		actual := messages(t)
+		require.Len(t, actual, 2)
+		assert.Contains(t, actual[0].Body, "curl/7.64.1")
+	})
+}
@@ -41,6 +41,7 @@ func (e *SessionIssuer) ExecutePostRegistrationPostPersistHook(w http.ResponseWr
 	}
 
 	if a.Type == flow.TypeAPI {
+		s.Declassify()
 		e.r.Writer().Write(w, r, &registration.APIFlowResponse{
 			Session: s, Token: s.Token,
 			Identity: s.Identity,
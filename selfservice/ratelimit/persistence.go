@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/x"
+)
+
+type (
+	HitPersister interface {
+		CreateRateLimitHit(ctx context.Context, hit *Hit) error
+
+		// ListRateLimitHits returns at most limit hits of the key which were created after the given time, newest first.
+		ListRateLimitHits(ctx context.Context, key string, since time.Time, limit int) ([]Hit, error)
+
+		// DeleteRateLimitHits deletes the hits of the key which were created before the given time.
+		DeleteRateLimitHits(ctx context.Context, key string, before time.Time) error
+	}
+
+	HitPersistenceProvider interface {
+		RateLimitHitPersister() HitPersister
+	}
+)
+
+func TestPersister(ctx context.Context, p HitPersister) func(t *testing.T) {
+	var createHit = func(t *testing.T, key string, ago time.Duration) *Hit {
+		h := NewHit(key)
+		h.CreatedAt = h.CreatedAt.Add(-ago).Truncate(time.Second)
+		require.NoError(t, p.CreateRateLimitHit(ctx, h))
+		return h
+	}
+
+	return func(t *testing.T) {
+		t.Run("case=list hits", func(t *testing.T) {
+			key := x.NewUUID().String()
+			createHit(t, key, time.Hour)
+			second := createHit(t, key, time.Minute*2)
+			first := createHit(t, key, time.Minute)
+			createHit(t, x.NewUUID().String(), 0)
+
+			actual, err := p.ListRateLimitHits(ctx, key, time.Now().Add(-time.Minute*30), 10)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			assert.Equal(t, first.ID, actual[0].ID)
+			assert.Equal(t, second.ID, actual[1].ID)
+
+			actual, err = p.ListRateLimitHits(ctx, key, time.Now().Add(-time.Minute*30), 1)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, first.ID, actual[0].ID)
+		})
+
+		t.Run("case=delete hits", func(t *testing.T) {
+			key := x.NewUUID().String()
+			other := x.NewUUID().String()
+			createHit(t, key, time.Hour)
+			createHit(t, key, 0)
+			createHit(t, other, time.Hour)
+
+			require.NoError(t, p.DeleteRateLimitHits(ctx, key, time.Now().Add(-time.Minute*30)))
+
+			actual, err := p.ListRateLimitHits(ctx, key, time.Now().Add(-time.Hour*2), 10)
+			require.NoError(t, err)
+			assert.Len(t, actual, 1)
+
+			actual, err = p.ListRateLimitHits(ctx, other, time.Now().Add(-time.Hour*2), 10)
+			require.NoError(t, err)
+			assert.Len(t, actual, 1)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/httpx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/recovery"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/flow/verification"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	FlowLogin        = "login"
+	FlowRegistration = "registration"
+	FlowSettings     = "settings"
+	FlowRecovery     = "recovery"
+	FlowVerification = "verification"
+)
+
+// identifierFields are the submitted fields which identify the account a request is made for. The first
+// non-empty field is used.
+var identifierFields = []string{"identifier", "email", "traits.email"}
+
+// maxBodySize is the maximum size of request bodies which are read to find the submitted identifier.
+const maxBodySize = 1 << 20
+
+type (
+	limiterDependencies interface {
+		config.Provider
+		x.WriterProvider
+		errorx.ManagementProvider
+		HitPersistenceProvider
+
+		login.FlowPersistenceProvider
+		login.ErrorHandlerProvider
+		registration.FlowPersistenceProvider
+		registration.ErrorHandlerProvider
+		settings.FlowPersistenceProvider
+		settings.ErrorHandlerProvider
+		recovery.FlowPersistenceProvider
+		recovery.ErrorHandlerProvider
+		verification.FlowPersistenceProvider
+		verification.ErrorHandlerProvider
+	}
+
+	ManagementProvider interface {
+		RateLimiter() *Limiter
+	}
+
+	// Limiter limits how often self-service flows can be submitted per IP address and per identifier.
+	Limiter struct {
+		d      limiterDependencies
+		memory *MemoryStore
+	}
+
+	// ExceededError is returned if a request exceeds a rate limit. It wraps a validation error so that
+	// the flow's form shows a message, and responds with 429 Too Many Requests.
+	ExceededError struct {
+		error
+		until time.Time
+	}
+)
+
+func NewLimiter(d limiterDependencies) *Limiter {
+	return &Limiter{d: d, memory: NewMemoryStore()}
+}
+
+func (e *ExceededError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+func (e *ExceededError) Unwrap() error {
+	return e.error
+}
+
+// RetryAfter returns the time at which the next request will be allowed.
+func (e *ExceededError) RetryAfter() time.Time {
+	return e.until
+}
+
+func (l *Limiter) store(ctx context.Context) Store {
+	if l.d.Config(ctx).SelfServiceRateLimitStore() == "sql" {
+		return NewSQLStore(l.d.RateLimitHitPersister())
+	}
+	return l.memory
+}
+
+// Handle wraps the handler which completes the given flow (e.g. "login") with the given method
+// (e.g. "password"). POST requests exceeding the rate limits are rejected using the flow's
+// error handler, which means that browser flows show an error message in the form.
+func (l *Limiter) Handle(flow, method string, wrap httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if r.Method != http.MethodPost {
+			wrap(w, r, ps)
+			return
+		}
+
+		if err := l.Check(r, flow, method); err != nil {
+			l.writeError(w, r, flow, method, err)
+			return
+		}
+
+		wrap(w, r, ps)
+	}
+}
+
+// Check records the request and returns an ExceededError if the request exceeds the rate limits
+// of the flow and method. Requests are counted per client IP address as returned by x.ClientIP, which
+// only uses the X-Forwarded-For header of trusted proxies.
+func (l *Limiter) Check(r *http.Request, flow, method string) error {
+	c := l.d.Config(r.Context()).SelfServiceFlowRateLimit(flow, method)
+	if !c.Enabled {
+		return nil
+	}
+
+	if err := l.take(r.Context(), c.PerIP, "ip", flow, method, x.ClientIP(r)); err != nil {
+		return err
+	}
+
+	identifier, err := submittedIdentifier(r)
+	if err != nil {
+		return err
+	} else if len(identifier) == 0 {
+		return nil
+	}
+
+	return l.take(r.Context(), c.PerIdentifier, "identifier", flow, method, identifier)
+}
+
+func (l *Limiter) take(ctx context.Context, rule config.RateLimit, kind, flow, method, value string) error {
+	if rule.Limit == 0 {
+		return nil
+	}
+
+	allowed, until, err := l.store(ctx).Take(ctx, key(kind, flow, method, value), rule.Limit, rule.Window)
+	if err != nil {
+		return err
+	} else if !allowed {
+		return errors.WithStack(&ExceededError{error: schema.NewRateLimitExceededError(until), until: until})
+	}
+
+	return nil
+}
+
+// key hashes the rate limit key so that stores do not contain identifiers or IP addresses.
+func key(kind, flow, method, value string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join([]string{kind, flow, method, value}, "\x00"))))
+}
+
+// submittedIdentifier returns the normalized identifier or email address submitted in the request body.
+// The body is restored so that it can be decoded by the wrapped handler.
+func submittedIdentifier(r *http.Request) (string, error) {
+	if r.Body == nil {
+		return "", nil
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to read the request body: %s", err))
+	} else if len(body) > maxBodySize {
+		return "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("The request body must not be larger than %d bytes.", maxBodySize))
+	}
+	_ = r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var get func(field string) string
+	if httpx.HasContentType(r, "application/json") {
+		get = func(field string) string {
+			return gjson.GetBytes(body, field).String()
+		}
+	} else {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			// The wrapped handler reports malformed bodies.
+			return "", nil
+		}
+		get = values.Get
+	}
+
+	for _, field := range identifierFields {
+		if value := strings.ToLower(strings.TrimSpace(get(field))); len(value) > 0 {
+			return value, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (l *Limiter) writeError(w http.ResponseWriter, r *http.Request, flow, method string, err error) {
+	if e := new(ExceededError); errors.As(err, &e) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(e.RetryAfter()).Seconds()))))
+	}
+
+	ctx := r.Context()
+	id := x.ParseUUID(r.URL.Query().Get("flow"))
+	switch flow {
+	case FlowLogin:
+		f, _ := l.d.LoginFlowPersister().GetLoginFlow(ctx, id)
+		l.d.LoginFlowErrorHandler().WriteFlowError(w, r, identity.CredentialsType(method), f, err)
+	case FlowRegistration:
+		f, _ := l.d.RegistrationFlowPersister().GetRegistrationFlow(ctx, id)
+		l.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, identity.CredentialsType(method), f, err)
+	case FlowSettings:
+		f, _ := l.d.SettingsFlowPersister().GetSettingsFlow(ctx, id)
+		var i *identity.Identity
+		if f != nil {
+			i = f.Identity
+		}
+		l.d.SettingsFlowErrorHandler().WriteFlowError(w, r, method, f, i, err)
+	case FlowRecovery:
+		f, _ := l.d.RecoveryFlowPersister().GetRecoveryFlow(ctx, id)
+		l.d.RecoveryFlowErrorHandler().WriteFlowError(w, r, method, f, err)
+	case FlowVerification:
+		f, _ := l.d.VerificationFlowPersister().GetVerificationFlow(ctx, id)
+		l.d.VerificationFlowErrorHandler().WriteFlowError(w, r, method, f, err)
+	default:
+		if x.IsJSONRequest(r) {
+			l.d.Writer().WriteError(w, r, err)
+			return
+		}
+		l.d.SelfServiceErrorManager().Forward(ctx, w, r, err)
+	}
+}
@@ -0,0 +1,210 @@
+package ratelimit_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/ratelimit"
+	"github.com/ory/kratos/x"
+)
+
+func TestStore(t *testing.T) {
+	_, reg := internal.NewFastRegistryWithMocks(t)
+	ctx := context.Background()
+
+	for name, s := range map[string]ratelimit.Store{
+		"memory": ratelimit.NewMemoryStore(),
+		"sql":    ratelimit.NewSQLStore(reg.RateLimitHitPersister()),
+	} {
+		t.Run("store="+name, func(t *testing.T) {
+			t.Run("case=should limit requests within the window", func(t *testing.T) {
+				key := x.NewUUID().String()
+				for k := 0; k < 2; k++ {
+					allowed, _, err := s.Take(ctx, key, 2, time.Hour)
+					require.NoError(t, err)
+					assert.True(t, allowed)
+				}
+
+				allowed, until, err := s.Take(ctx, key, 2, time.Hour)
+				require.NoError(t, err)
+				assert.False(t, allowed)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), until, time.Minute)
+
+				allowed, _, err = s.Take(ctx, x.NewUUID().String(), 2, time.Hour)
+				require.NoError(t, err)
+				assert.True(t, allowed)
+			})
+
+			t.Run("case=should allow requests once the window passed", func(t *testing.T) {
+				key := x.NewUUID().String()
+				allowed, _, err := s.Take(ctx, key, 1, time.Second)
+				require.NoError(t, err)
+				assert.True(t, allowed)
+
+				allowed, _, err = s.Take(ctx, key, 1, time.Second)
+				require.NoError(t, err)
+				assert.False(t, allowed)
+
+				time.Sleep(time.Second + 100*time.Millisecond)
+
+				allowed, _, err = s.Take(ctx, key, 1, time.Second)
+				require.NoError(t, err)
+				assert.True(t, allowed)
+			})
+		})
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet("selfservice.flows.login.rate_limit.enabled", true)
+	conf.MustSet("selfservice.flows.login.rate_limit.per_ip.limit", 0)
+	conf.MustSet("selfservice.flows.login.rate_limit.per_identifier.limit", 1)
+	conf.MustSet("selfservice.flows.login.rate_limit.per_identifier.window", "1h")
+
+	var received []string
+	router := httprouter.New()
+	router.POST("/", reg.RateLimiter().Handle(ratelimit.FlowLogin, "password", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+
+	var postForm = func(t *testing.T, values url.Values) *http.Response {
+		req, err := http.NewRequest("POST", ts.URL, strings.NewReader(values.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+		return res
+	}
+
+	var postJSON = func(t *testing.T, body string) *http.Response {
+		req, err := http.NewRequest("POST", ts.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+		return res
+	}
+
+	t.Run("case=should pass the body to the handler", func(t *testing.T) {
+		received = nil
+		values := url.Values{"identifier": {x.NewUUID().String()}, "password": {"password"}}
+		assert.Equal(t, http.StatusNoContent, postForm(t, values).StatusCode)
+		assert.Equal(t, []string{values.Encode()}, received)
+	})
+
+	t.Run("case=should reject bodies which are too large", func(t *testing.T) {
+		received = nil
+		res := postJSON(t, `{"identifier":"`+strings.Repeat("a", 1<<20)+`"}`)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Empty(t, received)
+	})
+
+	t.Run("case=should limit form and JSON requests per identifier", func(t *testing.T) {
+		identifier := x.NewUUID().String()
+		assert.Equal(t, http.StatusNoContent, postForm(t, url.Values{"identifier": {identifier}}).StatusCode)
+
+		res := postJSON(t, `{"identifier":"`+strings.ToUpper(identifier)+`"}`)
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.NotEmpty(t, res.Header.Get("Retry-After"))
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.EqualValues(t, http.StatusTooManyRequests, gjson.GetBytes(body, "error.code").Int(), "%s", body)
+	})
+
+	t.Run("case=should use the email if no identifier was submitted", func(t *testing.T) {
+		email := x.NewUUID().String() + "@ory.sh"
+		assert.Equal(t, http.StatusNoContent, postJSON(t, `{"traits":{"email":"`+email+`"}}`).StatusCode)
+		assert.Equal(t, http.StatusTooManyRequests, postForm(t, url.Values{"traits.email": {email}}).StatusCode)
+	})
+
+	t.Run("case=should not limit requests if disabled", func(t *testing.T) {
+		conf.MustSet("selfservice.flows.login.rate_limit.methods.password.enabled", false)
+		t.Cleanup(func() {
+			conf.MustSet("selfservice.flows.login.rate_limit.methods.password.enabled", true)
+		})
+
+		identifier := x.NewUUID().String()
+		for k := 0; k < 3; k++ {
+			assert.Equal(t, http.StatusNoContent, postForm(t, url.Values{"identifier": {identifier}}).StatusCode)
+		}
+	})
+
+	t.Run("case=should limit requests per forwarded client IP", func(t *testing.T) {
+		conf.MustSet("selfservice.flows.login.rate_limit.per_ip.limit", 1)
+		conf.MustSet("selfservice.flows.login.rate_limit.per_identifier.limit", 0)
+		t.Cleanup(func() {
+			conf.MustSet("selfservice.flows.login.rate_limit.per_ip.limit", 0)
+			conf.MustSet("selfservice.flows.login.rate_limit.per_identifier.limit", 1)
+			conf.MustSet(config.ViperKeyPublicTrustedProxies, []string{})
+		})
+
+		mw := x.NewClientIPMiddleware(reg)
+		proxied := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mw(w, r, router.ServeHTTP)
+		}))
+		t.Cleanup(proxied.Close)
+
+		var postFrom = func(t *testing.T, clientIP string) int {
+			req, err := http.NewRequest("POST", proxied.URL, strings.NewReader(url.Values{"identifier": {x.NewUUID().String()}}.Encode()))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("X-Forwarded-For", clientIP)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			return res.StatusCode
+		}
+
+		t.Run("case=trusted proxy", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyPublicTrustedProxies, []string{"127.0.0.1"})
+
+			assert.Equal(t, http.StatusNoContent, postFrom(t, "192.0.2.1"))
+			assert.Equal(t, http.StatusTooManyRequests, postFrom(t, "192.0.2.1"))
+			assert.Equal(t, http.StatusNoContent, postFrom(t, "192.0.2.2"))
+			assert.Equal(t, http.StatusTooManyRequests, postFrom(t, "192.0.2.2"))
+		})
+
+		t.Run("case=untrusted proxy", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyPublicTrustedProxies, []string{})
+
+			// The header is ignored which is why all requests are counted for the proxy's IP address.
+			assert.Equal(t, http.StatusNoContent, postFrom(t, "192.0.2.3"))
+			assert.Equal(t, http.StatusTooManyRequests, postFrom(t, "192.0.2.4"))
+		})
+	})
+
+	t.Run("case=should count requests in the database", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceRateLimitStore, "sql")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceRateLimitStore, "memory")
+		})
+
+		identifier := x.NewUUID().String()
+		assert.Equal(t, http.StatusNoContent, postForm(t, url.Values{"identifier": {identifier}}).StatusCode)
+		assert.Equal(t, http.StatusTooManyRequests, postForm(t, url.Values{"identifier": {identifier}}).StatusCode)
+	})
+}
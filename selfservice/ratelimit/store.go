@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	// Store counts the requests which were made for a rate limit key.
+	Store interface {
+		// Take records a request for the key unless limit requests were already recorded for the key within the
+		// window. If the request was not recorded, it returns false and the time at which the next request will
+		// be allowed.
+		Take(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Time, error)
+	}
+
+	// Hit is a request which was counted by the SQL store.
+	Hit struct {
+		ID  uuid.UUID `json:"id" db:"id" faker:"-"`
+		Key string    `json:"key" db:"rate_limit_key"`
+
+		// CreatedAt is a helper struct field for gobuffalo.pop.
+		CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+		// UpdatedAt is a helper struct field for gobuffalo.pop.
+		UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+	}
+
+	// MemoryStore counts requests in memory. Each Ory Kratos instance counts its own requests.
+	MemoryStore struct {
+		sync.Mutex
+		keys  map[string]*memoryKey
+		swept time.Time
+	}
+
+	memoryKey struct {
+		hits   []time.Time
+		window time.Duration
+	}
+
+	sqlStore struct {
+		p HitPersister
+	}
+)
+
+func (Hit) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_rate_limit_hits")
+}
+
+func NewHit(key string) *Hit {
+	return &Hit{
+		ID:        x.NewUUID(),
+		Key:       key,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: map[string]*memoryKey{}}
+}
+
+// sweepInterval is how often the memory store removes keys without requests in their window.
+const sweepInterval = time.Minute
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit int, window time.Duration) (bool, time.Time, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now().UTC()
+	if now.After(s.swept.Add(sweepInterval)) {
+		for k, v := range s.keys {
+			if len(v.hits) == 0 || now.Sub(v.hits[len(v.hits)-1]) > v.window {
+				delete(s.keys, k)
+			}
+		}
+		s.swept = now
+	}
+
+	k, ok := s.keys[key]
+	if !ok {
+		k = new(memoryKey)
+		s.keys[key] = k
+	}
+
+	k.window = window
+	for len(k.hits) > 0 && !k.hits[0].After(now.Add(-window)) {
+		k.hits = k.hits[1:]
+	}
+
+	if len(k.hits) >= limit {
+		return false, k.hits[len(k.hits)-limit].Add(window), nil
+	}
+
+	k.hits = append(k.hits, now)
+	return true, time.Time{}, nil
+}
+
+// NewSQLStore returns a store which counts requests in the database. All Ory Kratos instances
+// using the same database share the counts.
+func NewSQLStore(p HitPersister) Store {
+	return &sqlStore{p: p}
+}
+
+func (s *sqlStore) Take(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Time, error) {
+	since := time.Now().UTC().Add(-window)
+	if err := s.p.DeleteRateLimitHits(ctx, key, since); err != nil {
+		return false, time.Time{}, err
+	}
+
+	hits, err := s.p.ListRateLimitHits(ctx, key, since, limit)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	if len(hits) >= limit {
+		return false, hits[len(hits)-1].CreatedAt.Add(window), nil
+	}
+
+	return true, time.Time{}, s.p.CreateRateLimitHit(ctx, NewHit(key))
+}
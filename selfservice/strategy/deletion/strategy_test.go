@@ -0,0 +1,212 @@
+package deletion_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos-client-go/models"
+	"github.com/ory/x/assertx"
+	"github.com/ory/x/httpx"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/strategy/deletion"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+func newIdentity() *identity.Identity {
+	return &identity.Identity{
+		ID:       x.NewUUID(),
+		Traits:   identity.Traits(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`),
+		SchemaID: config.DefaultIdentityTraitsSchemaID,
+	}
+}
+
+func TestSettings(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeySelfServiceBrowserDefaultReturnTo, "https://www.ory.sh/")
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/identity.schema.json")
+	testhelpers.StrategyEnable(t, conf, settings.StrategyDeletion, true)
+
+	_ = testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	_ = testhelpers.NewLoginUIWith401Response(t, conf)
+	conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "5m")
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+
+	rts := testhelpers.NewRedirTS(t, "", conf)
+	conf.MustSet(config.ViperKeySelfServiceSettingsAfter+"."+config.DefaultBrowserReturnURL, rts.URL+"/return-ts")
+
+	var submit = func(t *testing.T, isAPI bool, hc *http.Client) (string, *http.Response) {
+		var payload *models.SettingsFlow
+		if isAPI {
+			payload = testhelpers.InitializeSettingsFlowViaAPI(t, hc, publicTS).Payload
+		} else {
+			payload = testhelpers.InitializeSettingsFlowViaBrowser(t, hc, publicTS).Payload
+		}
+
+		time.Sleep(time.Millisecond * 10) // add a bit of delay to allow `1ns` to time out.
+
+		c := testhelpers.GetSettingsFlowMethodConfig(t, payload, settings.StrategyDeletion)
+		values := testhelpers.SDKFormFieldsToURLValues(c.Fields)
+		return testhelpers.SettingsMakeRequest(t, isAPI, c, hc, testhelpers.EncodeFormAsJSON(t, isAPI, values))
+	}
+
+	var newClient = func(t *testing.T, isAPI bool, i *identity.Identity) *http.Client {
+		if isAPI {
+			return testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, i)
+		}
+		return testhelpers.NewHTTPClientWithIdentitySessionCookie(t, reg, i)
+	}
+
+	var expectDeleted = func(t *testing.T, i *identity.Identity) {
+		_, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		assert.True(t, errors.Is(err, sqlcon.ErrNoRows), "%+v", err)
+	}
+
+	t.Run("description=not authorized to call endpoints without a session", func(t *testing.T) {
+		c := testhelpers.NewDebugClient(t)
+		t.Run("type=browser", func(t *testing.T) {
+			res, err := c.Do(httpx.MustNewRequest("POST", publicTS.URL+deletion.RouteSettings, strings.NewReader(url.Values{"foo": {"bar"}}.Encode()), "application/x-www-form-urlencoded"))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode, "%+v", res.Request)
+			assert.Contains(t, res.Request.URL.String(), conf.Source().String(config.ViperKeySelfServiceLoginUI))
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			res, err := c.Do(httpx.MustNewRequest("POST", publicTS.URL+deletion.RouteSettings, strings.NewReader(`{"foo":"bar"}`), "application/json"))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
+		})
+	})
+
+	t.Run("description=should require a privileged session", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "1ns")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceSettingsPrivilegedAuthenticationAfter, "5m")
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			i := newIdentity()
+			actual, res := submit(t, true, newClient(t, true, i))
+			assert.EqualValues(t, http.StatusForbidden, res.StatusCode, "%s", actual)
+			assertx.EqualAsJSON(t, settings.NewFlowNeedsReAuth(), json.RawMessage(gjson.Get(actual, "error").Raw))
+
+			_, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+			require.NoError(t, err)
+		})
+
+		t.Run("type=browser", func(t *testing.T) {
+			i := newIdentity()
+			_, res := submit(t, false, newClient(t, false, i))
+			assert.Contains(t, res.Request.URL.String(), conf.Source().String(config.ViperKeySelfServiceLoginUI))
+
+			_, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("description=should reject sessions which require a password change", func(t *testing.T) {
+		i := newIdentity()
+		s := session.NewActiveSession(i, testhelpers.NewSessionLifespanProvider(time.Hour), time.Now())
+		session.WithPasswordChangeRequired()(s)
+
+		actual, res := submit(t, true, testhelpers.NewHTTPClientWithSessionToken(t, reg, s))
+		assert.EqualValues(t, http.StatusForbidden, res.StatusCode, "%s", actual)
+		assert.True(t, gjson.Get(actual, "error.details.password_change_required").Bool(), "%s", actual)
+
+		_, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("description=should delete the identity and revoke all sessions", func(t *testing.T) {
+		t.Run("type=api", func(t *testing.T) {
+			i := newIdentity()
+			hc := newClient(t, true, i)
+			actual, res := submit(t, true, hc)
+			assert.EqualValues(t, http.StatusNoContent, res.StatusCode, "%s", actual)
+			expectDeleted(t, i)
+
+			sessions, err := reg.SessionPersister().ListSessionsByIdentity(ctx, i.ID)
+			require.NoError(t, err)
+			assert.Empty(t, sessions)
+		})
+
+		t.Run("type=browser", func(t *testing.T) {
+			i := newIdentity()
+			_, res := submit(t, false, newClient(t, false, i))
+			assert.EqualValues(t, rts.URL+"/return-ts", res.Request.URL.String())
+			expectDeleted(t, i)
+		})
+	})
+
+	t.Run("description=should schedule the deletion if a grace period is set", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyDeletionGracePeriod, "720h")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyDeletionGracePeriod, "0s")
+		})
+
+		i := newIdentity()
+		actual, res := submit(t, true, newClient(t, true, i))
+		assert.EqualValues(t, http.StatusNoContent, res.StatusCode, "%s", actual)
+
+		sessions, err := reg.SessionPersister().ListSessionsByIdentity(ctx, i.ID)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+
+		scheduled, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err)
+		assert.Equal(t, identity.StateInactive, scheduled.State)
+		require.NotNil(t, scheduled.DeletionScheduledAt)
+		assert.WithinDuration(t, time.Now().Add(720*time.Hour), *scheduled.DeletionScheduledAt, time.Minute)
+
+		require.NoError(t, reg.IdentityPurger().Purge(ctx, time.Now().UTC()))
+		_, err = reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, i.ID)
+		require.NoError(t, err, "the identity must not be purged before the grace period ended")
+
+		require.NoError(t, reg.IdentityPurger().Purge(ctx, time.Now().UTC().Add(721*time.Hour)))
+		expectDeleted(t, i)
+	})
+
+	t.Run("description=should execute the deletion hooks", func(t *testing.T) {
+		var deleted []identity.Identity
+		reg.WithHooks(map[string]func(config.SelfServiceHook) interface{}{
+			"test": func(config.SelfServiceHook) interface{} {
+				return settings.PostHookDeletionExecutorFunc(func(_ http.ResponseWriter, _ *http.Request, _ *settings.Flow, i *identity.Identity) error {
+					deleted = append(deleted, *i)
+					return nil
+				})
+			},
+		})
+		conf.MustSet(config.ViperKeySelfServiceSettingsAfter+"."+settings.StrategyDeletion+".hooks", []map[string]interface{}{{"hook": "test"}})
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceSettingsAfter+"."+settings.StrategyDeletion+".hooks", nil)
+			reg.WithHooks(nil)
+		})
+
+		i := newIdentity()
+		_, res := submit(t, true, newClient(t, true, i))
+		assert.EqualValues(t, http.StatusNoContent, res.StatusCode)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, i.ID, deleted[0].ID)
+	})
+}
@@ -0,0 +1,48 @@
+package ldap
+
+import (
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Configuration is the configuration of the LDAP method.
+type Configuration struct {
+	// URL is the LDAP server's URL, e.g. ldap://ldap.example.org:389 or ldaps://ldap.example.org:636.
+	URL string `json:"url"`
+
+	// StartTLS upgrades ldap:// connections to TLS.
+	StartTLS bool `json:"start_tls"`
+
+	// BindDN and BindPassword are the credentials of the service account used to search for the user's entry. If
+	// BindDN is empty, the search is performed anonymously.
+	BindDN       string `json:"bind_dn"`
+	BindPassword string `json:"bind_password"`
+
+	// BaseDN is the subtree in which users are searched.
+	BaseDN string `json:"base_dn"`
+
+	// UserFilter is the search filter which finds the user's entry. The placeholder {identifier} is replaced with
+	// the escaped identifier.
+	UserFilter string `json:"user_filter"`
+
+	// SubjectAttribute is the attribute which uniquely identifies the user's entry. If empty, the entry's DN
+	// is used.
+	SubjectAttribute string `json:"subject_attribute"`
+
+	// Mapper specifies the JSONNet code snippet which uses the directory entry's attributes to hydrate the
+	// identity's data.
+	//
+	// It can be either a URL (file://, http(s)://, base64://) or an inline JSONNet code snippet.
+	Mapper string `json:"mapper_url"`
+}
+
+const defaultUserFilter = "(uid={identifier})"
+
+func (c *Configuration) userFilter(identifier string) string {
+	filter := c.UserFilter
+	if filter == "" {
+		filter = defaultUserFilter
+	}
+	return strings.ReplaceAll(filter, "{identifier}", ldap.EscapeFilter(identifier))
+}
@@ -0,0 +1,148 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/schema"
+)
+
+// Claims contains the data of the user's directory entry which is made available to the Jsonnet mapper.
+type Claims struct {
+	// Subject uniquely identifies the directory entry. It is the value of the configured subject attribute or,
+	// if none is configured, the entry's lowercased DN.
+	Subject string `json:"sub"`
+
+	// DN is the entry's distinguished name.
+	DN string `json:"dn"`
+
+	// Attributes contains the entry's attributes.
+	Attributes map[string][]string `json:"attributes"`
+}
+
+func newClaims(c *Configuration, entry *ldap.Entry) (*Claims, error) {
+	claims := &Claims{
+		// DNs are case insensitive.
+		Subject:    strings.ToLower(entry.DN),
+		DN:         entry.DN,
+		Attributes: make(map[string][]string, len(entry.Attributes)),
+	}
+
+	for _, attr := range entry.Attributes {
+		claims.Attributes[attr.Name] = attributeValues(attr)
+	}
+
+	if c.SubjectAttribute != "" {
+		claims.Subject = ""
+		for _, attr := range entry.Attributes {
+			if strings.EqualFold(attr.Name, c.SubjectAttribute) && len(attr.ByteValues) > 0 {
+				claims.Subject = attributeValues(attr)[0]
+				break
+			}
+		}
+
+		if claims.Subject == "" {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf(`The LDAP directory entry does not have the subject attribute "%s".`, c.SubjectAttribute))
+		}
+	}
+
+	return claims, nil
+}
+
+func (s *Strategy) dial(c *Configuration) (*ldap.Conn, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the LDAP server URL: %s", err))
+	}
+
+	conn, err := ldap.DialURL(c.URL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to connect to the LDAP server.").WithDebug(err.Error()))
+	}
+
+	if c.StartTLS && u.Scheme == "ldap" {
+		if err := conn.StartTLS(&tls.Config{ServerName: u.Hostname()}); err != nil {
+			conn.Close()
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to establish a TLS connection to the LDAP server.").WithDebug(err.Error()))
+		}
+	}
+
+	return conn, nil
+}
+
+// authenticate looks up the user's directory entry and verifies the password by binding as that entry. It returns
+// an invalid credentials error if the entry does not exist or the password is wrong.
+func (s *Strategy) authenticate(c *Configuration, identifier, password string) (*Claims, error) {
+	// An empty password results in an unauthenticated bind which most servers accept.
+	if len(password) == 0 {
+		return nil, errors.WithStack(schema.NewInvalidCredentialsError())
+	}
+
+	conn, err := s.dial(c)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if c.BindDN != "" {
+		if err := conn.Bind(c.BindDN, c.BindPassword); err != nil {
+			return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to bind to the LDAP server using the service account.").WithDebug(err.Error()))
+		}
+	}
+
+	// Operational attributes such as entryUUID are only returned if they are requested explicitly.
+	attributes := []string{"*"}
+	if c.SubjectAttribute != "" {
+		attributes = append(attributes, c.SubjectAttribute)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		c.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		// We need at most two results to know that the filter is ambiguous.
+		2, 0, false,
+		c.userFilter(identifier), attributes, nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) || (err == nil && len(result.Entries) > 1) {
+		s.d.Logger().
+			WithField("ldap_filter", c.userFilter(identifier)).
+			Error("The LDAP user filter matched more than one directory entry. Please check your configuration!")
+		return nil, errors.WithStack(schema.NewInvalidCredentialsError())
+	} else if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, errors.WithStack(schema.NewInvalidCredentialsError())
+	} else if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to search the LDAP directory.").WithDebug(err.Error()))
+	} else if len(result.Entries) == 0 {
+		return nil, errors.WithStack(schema.NewInvalidCredentialsError())
+	}
+
+	entry := result.Entries[0]
+	if err := conn.Bind(entry.DN, password); ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, errors.WithStack(schema.NewInvalidCredentialsError())
+	} else if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to bind to the LDAP server.").WithDebug(err.Error()))
+	}
+
+	return newClaims(c, entry)
+}
+
+// attributeValues returns the attribute's values. Binary values such as Active Directory's objectGUID are
+// base64 encoded.
+func attributeValues(attr *ldap.EntryAttribute) []string {
+	values := make([]string, len(attr.ByteValues))
+	for k, v := range attr.ByteValues {
+		if utf8.Valid(v) {
+			values[k] = string(v)
+		} else {
+			values[k] = base64.StdEncoding.EncodeToString(v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,275 @@
+package ldap
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/ratelimit"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteLogin = "/self-service/login/methods/ldap"
+)
+
+func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
+	s.d.CSRFHandler().IgnorePath(RouteLogin)
+
+	wrappedHandleLogin := s.d.RateLimiter().Handle(ratelimit.FlowLogin, s.ID().String(), strategy.IsDisabled(s.d, s.ID().String(), s.handleLogin))
+	r.POST(RouteLogin, wrappedHandleLogin)
+}
+
+// handleLoginError records the failed login attempt in the audit log and writes the error. Requests without a
+// login flow are not login attempts, which is why they are not recorded.
+func (s *Strategy) handleLoginError(w http.ResponseWriter, r *http.Request, rr *login.Flow, payload *CompleteSelfServiceLoginFlowWithLDAPMethod, err error) {
+	if rr != nil {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeLogin, s.ID().String(), uuid.Nil, err))
+
+		if method, ok := rr.Methods[s.ID()]; ok {
+			method.Config.Reset()
+			if payload != nil {
+				method.Config.SetValue("identifier", payload.Identifier)
+			}
+			if rr.Type == flow.TypeBrowser {
+				method.Config.SetCSRF(s.d.GenerateCSRFToken(r))
+			}
+
+			rr.Methods[s.ID()] = method
+		}
+	}
+
+	s.d.LoginFlowErrorHandler().WriteFlowError(w, r, s.ID(), rr, err)
+}
+
+// nolint:deadcode,unused
+// swagger:parameters completeSelfServiceLoginFlowWithLDAPMethod
+type completeSelfServiceLoginFlowWithLDAPMethodParameters struct {
+	// The Flow ID
+	//
+	// required: true
+	// in: query
+	Flow string `json:"flow"`
+
+	// in: body
+	Body CompleteSelfServiceLoginFlowWithLDAPMethod
+}
+
+// swagger:route POST /self-service/login/methods/ldap public completeSelfServiceLoginFlowWithLDAPMethod
+//
+// Complete Login Flow with LDAP Method
+//
+// Use this endpoint to complete a login flow by sending the user's directory username and password. The identity
+// is created or updated from the user's directory entry before the session is issued. This endpoint behaves
+// differently for API and browser flows.
+//
+// API flows expect `application/json` to be sent in the body and responds with
+//   - HTTP 200 and a application/json body with the session token on success;
+//   - HTTP 302 redirect to a fresh login flow if the original flow expired with the appropriate error messages set;
+//   - HTTP 400 on form validation errors.
+//
+// Browser flows expect `application/x-www-form-urlencoded` to be sent in the body and responds with
+//   - a HTTP 302 redirect to the post/after login URL or the `return_to` value if it was set and if the login succeeded;
+//   - a HTTP 302 redirect to the login UI URL with the flow ID containing the validation errors otherwise.
+//
+// More information can be found at [ORY Kratos User Login and User Registration Documentation](https://www.ory.sh/docs/next/kratos/self-service/flows/user-login-user-registration).
+//
+//     Schemes: http, https
+//
+//     Consumes:
+//     - application/json
+//     - application/x-www-form-urlencoded
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: loginViaApiResponse
+//       302: emptyResponse
+//       400: loginFlow
+//       500: genericError
+func (s *Strategy) handleLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	rid := x.ParseUUID(r.URL.Query().Get("flow"))
+	if x.IsZeroUUID(rid) {
+		s.handleLoginError(w, r, nil, nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The flow query parameter is missing or invalid.")))
+		return
+	}
+
+	ar, err := s.d.LoginFlowPersister().GetLoginFlow(r.Context(), rid)
+	if err != nil {
+		s.handleLoginError(w, r, nil, nil, err)
+		return
+	}
+
+	var p CompleteSelfServiceLoginFlowWithLDAPMethod
+	if err := s.hd.Decode(r, &p, decoderx.MustHTTPRawJSONSchemaCompiler(loginSchema)); err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	if err := flow.VerifyRequest(r, ar.Type, s.d.Config(r.Context()).DisableAPIFlowEnforcement(), s.d.GenerateCSRFToken, p.CSRFToken); err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	if _, err := s.d.SessionManager().FetchFromRequest(r.Context(), r); err == nil && !ar.Forced {
+		if ar.Type == flow.TypeBrowser {
+			http.Redirect(w, r, s.d.Config(r.Context()).SelfServiceBrowserDefaultReturnTo().String(), http.StatusFound)
+			return
+		}
+
+		s.d.Writer().WriteError(w, r, errors.WithStack(login.ErrAlreadyLoggedIn))
+		return
+	}
+
+	if err := ar.Valid(); err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	c, err := s.Config(r.Context())
+	if err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	claims, err := s.authenticate(c, p.Identifier, p.Password)
+	if err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	i, err := s.provisionIdentity(r, c, claims)
+	if err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	if !i.IsActive() {
+		s.handleLoginError(w, r, ar, &p, errors.WithStack(schema.NewIdentityInactiveError()))
+		return
+	}
+
+	if err := s.d.LoginHookExecutor().PostLoginHook(w, r, s.ID(), ar, i); err != nil {
+		s.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
+		return
+	}
+}
+
+// provisionIdentity creates the identity for the directory entry if it does not exist yet. Otherwise, the
+// identity's traits and credentials are updated from the directory entry.
+func (s *Strategy) provisionIdentity(r *http.Request, c *Configuration, claims *Claims) (*identity.Identity, error) {
+	evaluated, err := strategy.EvaluateMapper(s.f, c.Mapper, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := gjson.Get(evaluated, "identity.traits")
+	if !mapped.IsObject() {
+		s.d.Logger().
+			WithRequest(r).
+			WithSensitiveField("ldap_claims", claims).
+			WithField("mapper_jsonnet_output", evaluated).
+			WithField("mapper_jsonnet_url", c.Mapper).
+			Error("LDAP Jsonnet mapper did not return an object for key identity.traits. Please check your Jsonnet code!")
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The LDAP Jsonnet mapper did not return an object for key identity.traits."))
+	}
+
+	var creds bytes.Buffer
+	if err := json.NewEncoder(&creds).Encode(CredentialsConfig{Subject: claims.Subject, DN: claims.DN}); err != nil {
+		return nil, errors.WithStack(x.PseudoPanic.
+			WithDebugf("Unable to encode LDAP credentials to JSON: %s", err))
+	}
+
+	i, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), claims.Subject)
+	if errors.Is(err, herodot.ErrNotFound) {
+		i = identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = []byte(mapped.Raw)
+		i.SetCredentials(s.ID(), identity.Credentials{
+			Type:        s.ID(),
+			Identifiers: []string{claims.Subject},
+			Config:      creds.Bytes(),
+		})
+
+		if err := s.d.IdentityManager().Create(r.Context(), i); err != nil {
+			return nil, err
+		}
+
+		s.d.Logger().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			Info("Created identity from LDAP directory entry.")
+		return i, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	i, err = s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), i.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	traits, changed, err := strategy.MergeTraits(i.Traits, json.RawMessage(mapped.Raw))
+	if err != nil {
+		return nil, err
+	}
+
+	cred, ok := i.GetCredentials(s.ID())
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The identity does not have LDAP credentials."))
+	}
+
+	if !changed && gjson.GetBytes(cred.Config, "dn").String() == claims.DN {
+		return i.CopyWithoutCredentials(), nil
+	}
+
+	i.Traits = traits
+	cred.Config = creds.Bytes()
+	i.SetCredentials(s.ID(), *cred)
+
+	// The directory is the source of truth for this identity, which is why protected traits such as verifiable
+	// addresses may be changed as well.
+	if err := s.d.IdentityManager().Update(r.Context(), i, identity.ManagerAllowWriteProtectedTraits); err != nil {
+		return nil, err
+	}
+
+	return i.CopyWithoutCredentials(), nil
+}
+
+func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
+	f := &form.HTMLForm{
+		Action: sr.AppendTo(urlx.AppendPaths(s.d.Config(r.Context()).SelfPublicURL(r), RouteLogin)).String(),
+		Method: "POST",
+		Fields: form.Fields{{
+			Name:     "identifier",
+			Type:     "text",
+			Required: true,
+		}, {
+			Name:     "password",
+			Type:     "password",
+			Required: true,
+		}}}
+	f.SetCSRF(s.d.GenerateCSRFToken(r))
+
+	sr.Methods[s.ID()] = &login.FlowMethod{
+		Method: s.ID(),
+		Config: &login.FlowMethodConfig{FlowMethodConfigurator: &FlowMethod{HTMLForm: f}}}
+	return nil
+}
@@ -0,0 +1,200 @@
+package ldap_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/ldap"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+func TestCompleteLogin(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	dir := newDirectory(t)
+
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeLDAP),
+		map[string]interface{}{"enabled": true, "config": &ldap.Configuration{
+			URL:              dir.url(),
+			BindDN:           testBindDN,
+			BindPassword:     testBindPassword,
+			BaseDN:           testBaseDN,
+			UserFilter:       "(&(objectClass=person)(|(uid={identifier})(mail={identifier})))",
+			SubjectAttribute: "entryUUID",
+			Mapper:           "file://./stub/ldap.jsonnet",
+		}})
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/login.schema.json")
+
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	errTS := testhelpers.NewErrorTestServer(t, reg)
+	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
+	redirTS := newReturnTs(t, reg)
+
+	conf.MustSet(config.ViperKeySelfServiceErrorUI, errTS.URL+"/error-ts")
+	conf.MustSet(config.ViperKeySelfServiceLoginUI, uiTS.URL+"/login-ts")
+
+	var addUser = func(uid, mail, name, password string) string {
+		subject := x.NewUUID().String()
+		dir.put("uid="+uid+","+testBaseDN, password, map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {uid},
+			"mail":        {mail},
+			"cn":          {name},
+			"entryUUID":   {subject},
+		})
+		return subject
+	}
+
+	var credentials = func(identifier, password string) func(v url.Values) {
+		return func(v url.Values) {
+			v.Set("identifier", identifier)
+			v.Set("password", password)
+		}
+	}
+
+	var expectInvalidCredentials = func(t *testing.T, isAPI bool, values func(url.Values)) {
+		body := testhelpers.SubmitLoginForm(t, isAPI, nil, publicTS, values,
+			identity.CredentialsTypeLDAP, false,
+			testhelpers.ExpectStatusCode(isAPI, http.StatusBadRequest, http.StatusOK),
+			testhelpers.ExpectURL(isAPI, publicTS.URL+ldap.RouteLogin, conf.SelfServiceFlowLoginUI().String()))
+		assert.Equal(t, text.NewErrorValidationInvalidCredentials().Text, gjson.Get(body, "methods.ldap.config.messages.0.text").String(), "%s", body)
+
+		events, err := reg.AuditEventPersister().ListAuditEvents(ctx, audit.Filter{Type: audit.EventTypeLogin}, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, audit.OutcomeFailure, events[0].Outcome)
+		assert.Equal(t, identity.CredentialsTypeLDAP.String(), events[0].Method)
+	}
+
+	var expectSuccess = func(t *testing.T, isAPI bool, values func(url.Values)) gjson.Result {
+		body := testhelpers.SubmitLoginForm(t, isAPI, nil, publicTS, values,
+			identity.CredentialsTypeLDAP, false, http.StatusOK,
+			testhelpers.ExpectURL(isAPI, publicTS.URL+ldap.RouteLogin, redirTS.URL))
+		if isAPI {
+			assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+			return gjson.Get(body, "session.identity")
+		}
+		return gjson.Get(body, "identity")
+	}
+
+	t.Run("case=should show the login form", func(t *testing.T) {
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, testhelpers.NewClientWithCookies(t), publicTS, false).Payload
+		c := testhelpers.GetLoginFlowMethodConfig(t, f, identity.CredentialsTypeLDAP.String())
+		assert.Contains(t, *c.Action, publicTS.URL+ldap.RouteLogin)
+
+		var names []string
+		for _, field := range c.Fields {
+			names = append(names, *field.Name)
+		}
+		assert.ElementsMatch(t, []string{"identifier", "password", "csrf_token"}, names)
+	})
+
+	for _, isAPI := range []bool{false, true} {
+		t.Run("api="+map[bool]string{true: "true", false: "false"}[isAPI], func(t *testing.T) {
+			uid := x.NewUUID().String()
+			mail := uid + "@example.org"
+
+			t.Run("case=should fail because the user does not exist", func(t *testing.T) {
+				expectInvalidCredentials(t, isAPI, credentials(uid, "secret"))
+			})
+
+			subject := addUser(uid, mail, "Jane Doe", "secret")
+
+			t.Run("case=should fail because the password is wrong", func(t *testing.T) {
+				expectInvalidCredentials(t, isAPI, credentials(uid, "not-secret"))
+			})
+
+			var id string
+			t.Run("case=should create the identity on first login", func(t *testing.T) {
+				i := expectSuccess(t, isAPI, credentials(uid, "secret"))
+				id = i.Get("id").String()
+				assert.Equal(t, mail, i.Get("traits.email").String(), "%s", i.Raw)
+				assert.Equal(t, "Jane Doe", i.Get("traits.name").String(), "%s", i.Raw)
+
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+				require.NoError(t, err)
+				creds, ok := actual.GetCredentials(identity.CredentialsTypeLDAP)
+				require.True(t, ok)
+				assert.Equal(t, []string{subject}, creds.Identifiers)
+				assert.Equal(t, "uid="+uid+","+testBaseDN, gjson.GetBytes(creds.Config, "dn").String())
+				require.Len(t, actual.VerifiableAddresses, 1)
+				assert.Equal(t, mail, actual.VerifiableAddresses[0].Value)
+			})
+
+			t.Run("case=should sign in with any identifier matching the filter", func(t *testing.T) {
+				i := expectSuccess(t, isAPI, credentials(mail, "secret"))
+				assert.Equal(t, id, i.Get("id").String())
+			})
+
+			t.Run("case=should update the identity from the directory", func(t *testing.T) {
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+				require.NoError(t, err)
+				actual.Traits = identity.Traits(`{"email":"` + mail + `","name":"Jane Doe","department":"Engineering"}`)
+				require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, actual))
+
+				newMail := "new-" + mail
+				dir.put("uid="+uid+","+testBaseDN, "secret", map[string][]string{
+					"objectClass": {"person"},
+					"uid":         {uid},
+					"mail":        {newMail},
+					"cn":          {"Jane Roe"},
+					"entryUUID":   {subject},
+				})
+
+				i := expectSuccess(t, isAPI, credentials(uid, "secret"))
+				assert.Equal(t, id, i.Get("id").String())
+				assert.Equal(t, newMail, i.Get("traits.email").String(), "%s", i.Raw)
+				assert.Equal(t, "Jane Roe", i.Get("traits.name").String(), "%s", i.Raw)
+				assert.Equal(t, "Engineering", i.Get("traits.department").String(), "traits which are not mapped must be kept: %s", i.Raw)
+
+				actual, err = reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+				require.NoError(t, err)
+				require.Len(t, actual.VerifiableAddresses, 1)
+				assert.Equal(t, newMail, actual.VerifiableAddresses[0].Value)
+			})
+
+			t.Run("case=should fail because the filter is ambiguous", func(t *testing.T) {
+				other := x.NewUUID().String()
+				addUser(other, "shared-"+uid+"@example.org", "John Doe", "secret")
+				addUser(other+"-2", "shared-"+uid+"@example.org", "John Doe", "secret")
+				expectInvalidCredentials(t, isAPI, credentials("shared-"+uid+"@example.org", "secret"))
+			})
+
+			t.Run("case=should fail because the identity is inactive", func(t *testing.T) {
+				actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+				require.NoError(t, err)
+				actual.State = identity.StateInactive
+				require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, actual))
+
+				body := testhelpers.SubmitLoginForm(t, isAPI, nil, publicTS, credentials(uid, "secret"),
+					identity.CredentialsTypeLDAP, false,
+					testhelpers.ExpectStatusCode(isAPI, http.StatusBadRequest, http.StatusOK),
+					testhelpers.ExpectURL(isAPI, publicTS.URL+ldap.RouteLogin, conf.SelfServiceFlowLoginUI().String()))
+				assert.Equal(t, text.NewErrorValidationIdentityInactive().Text, gjson.Get(body, "methods.ldap.config.messages.0.text").String(), "%s", body)
+			})
+		})
+	}
+
+	t.Run("case=should forward an error if the directory is unreachable", func(t *testing.T) {
+		conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeLDAP)+".config.url", "ldap://127.0.0.1:1")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeLDAP)+".config.url", dir.url())
+		})
+
+		body := testhelpers.SubmitLoginForm(t, false, nil, publicTS, credentials("jane", "secret"),
+			identity.CredentialsTypeLDAP, false, http.StatusOK, errTS.URL)
+		assert.Contains(t, body, "Unable to connect to the LDAP server.")
+	})
+}
@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/jsonx"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/ratelimit"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+var _ login.Strategy = new(Strategy)
+var _ identity.ActiveCredentialsCounter = new(Strategy)
+
+type dependencies interface {
+	errorx.ManagementProvider
+
+	config.Provider
+
+	x.LoggingProvider
+	x.WriterProvider
+	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
+
+	identity.PrivilegedPoolProvider
+	identity.ManagementProvider
+
+	session.ManagementProvider
+
+	login.HookExecutorProvider
+	login.FlowPersistenceProvider
+	login.ErrorHandlerProvider
+
+	ratelimit.ManagementProvider
+
+	audit.RecorderProvider
+}
+
+// Strategy implements login.Strategy. It authenticates identities by binding against an LDAP server and creates
+// or updates the identity from the directory entry on every login.
+type Strategy struct {
+	d  dependencies
+	f  *fetcher.Fetcher
+	hd *decoderx.HTTP
+}
+
+func NewStrategy(d dependencies) *Strategy {
+	return &Strategy{
+		d:  d,
+		f:  fetcher.NewFetcher(),
+		hd: decoderx.NewHTTP(),
+	}
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeLDAP
+}
+
+func (s *Strategy) CountActiveCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	for _, c := range cc {
+		if c.Type == s.ID() && gjson.ValidBytes(c.Config) {
+			var conf CredentialsConfig
+			if err = json.Unmarshal(c.Config, &conf); err != nil {
+				return 0, errors.WithStack(err)
+			}
+
+			for _, ider := range c.Identifiers {
+				if len(ider) > 0 && ider == conf.Subject {
+					count++
+				}
+			}
+		}
+	}
+	return
+}
+
+func (s *Strategy) Config(ctx context.Context) (*Configuration, error) {
+	var c Configuration
+
+	conf := s.d.Config(ctx).SelfServiceStrategy(string(s.ID())).Config
+	if err := jsonx.
+		NewStrictDecoder(bytes.NewBuffer(conf)).
+		Decode(&c); err != nil {
+		s.d.Logger().WithError(err).WithField("config", conf)
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode LDAP configuration: %s", err))
+	}
+
+	if c.URL == "" || c.BaseDN == "" || c.Mapper == "" {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The LDAP method requires the url, base_dn, and mapper_url configuration keys to be set."))
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,205 @@
+package ldap_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver"
+	"github.com/ory/kratos/driver/config"
+)
+
+const (
+	testBaseDN       = "ou=people,dc=example,dc=org"
+	testBindDN       = "cn=kratos,ou=services,dc=example,dc=org"
+	testBindPassword = "service-secret"
+)
+
+type directoryEntry struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+}
+
+// directory is a minimal in-process LDAP server which supports simple binds and searches with equality, presence,
+// and, or, and not filters.
+type directory struct {
+	l net.Listener
+
+	sync.Mutex
+	entries map[string]*directoryEntry
+}
+
+func newDirectory(t *testing.T) *directory {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	d := &directory{l: l, entries: map[string]*directoryEntry{}}
+	d.put(testBindDN, testBindPassword, map[string][]string{"cn": {"kratos"}})
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go d.serve(conn)
+		}
+	}()
+
+	return d
+}
+
+func (d *directory) url() string {
+	return "ldap://" + d.l.Addr().String()
+}
+
+func (d *directory) put(dn, password string, attributes map[string][]string) {
+	d.Lock()
+	defer d.Unlock()
+	d.entries[strings.ToLower(dn)] = &directoryEntry{dn: dn, password: password, attributes: attributes}
+}
+
+func (d *directory) serve(conn net.Conn) {
+	defer conn.Close()
+
+	var bound string
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			name, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+			code := uint16(ldap.LDAPResultInvalidCredentials)
+			d.Lock()
+			if e, ok := d.entries[strings.ToLower(name)]; ok && password != "" && e.password == password {
+				code, bound = ldap.LDAPResultSuccess, e.dn
+			}
+			d.Unlock()
+			_, _ = conn.Write(result(id, ldap.ApplicationBindResponse, code).Bytes())
+		case ldap.ApplicationSearchRequest:
+			if bound != testBindDN {
+				_, _ = conn.Write(result(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights).Bytes())
+				continue
+			}
+
+			base, limit := strings.ToLower(op.Children[0].Data.String()), op.Children[3].Value.(int64)
+			code := uint16(ldap.LDAPResultSuccess)
+			var found int64
+			d.Lock()
+			for dn, e := range d.entries {
+				if !strings.HasSuffix(dn, base) || !matches(op.Children[6], e.attributes) {
+					continue
+				}
+				if found++; limit > 0 && found > limit {
+					code = ldap.LDAPResultSizeLimitExceeded
+					break
+				}
+				_, _ = conn.Write(entry(id, e).Bytes())
+			}
+			d.Unlock()
+			_, _ = conn.Write(result(id, ldap.ApplicationSearchResultDone, code).Bytes())
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func matches(filter *ber.Packet, attributes map[string][]string) bool {
+	values := func(name string) []string {
+		for k, v := range attributes {
+			if strings.EqualFold(k, name) {
+				return v
+			}
+		}
+		return nil
+	}
+
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(child, attributes) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matches(child, attributes) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matches(filter.Children[0], attributes)
+	case ldap.FilterEqualityMatch:
+		for _, v := range values(filter.Children[0].Data.String()) {
+			if strings.EqualFold(v, filter.Children[1].Data.String()) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(values(filter.Data.String())) > 0
+	}
+	return false
+}
+
+func envelope(id int64, op *ber.Packet) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	p.AppendChild(op)
+	return p
+}
+
+func result(id int64, tag ber.Tag, code uint16) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return envelope(id, op)
+}
+
+func entry(id int64, e *directoryEntry) *ber.Packet {
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range e.attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attribute.AppendChild(set)
+		attributes.AppendChild(attribute)
+	}
+
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+	op.AppendChild(attributes)
+	return envelope(id, op)
+}
+
+func newReturnTs(t *testing.T, reg driver.Registry) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sess, err := reg.SessionManager().FetchFromRequest(r.Context(), r)
+		require.NoError(t, err)
+		reg.Writer().Write(w, r, sess)
+	}))
+	reg.Config(context.Background()).MustSet(config.ViperKeySelfServiceBrowserDefaultReturnTo, ts.URL)
+	t.Cleanup(ts.Close)
+	return ts
+}
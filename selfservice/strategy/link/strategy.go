@@ -1,15 +1,18 @@
 package link
 
 import (
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/courier"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/flow/verification"
 	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/decoderx"
@@ -54,6 +57,7 @@ type (
 		recovery.ErrorHandlerProvider
 		recovery.FlowPersistenceProvider
 		recovery.StrategyProvider
+		recovery.HookExecutorProvider
 
 		verification.ErrorHandlerProvider
 		verification.FlowPersistenceProvider
@@ -64,6 +68,10 @@ type (
 		SenderProvider
 
 		schema.IdentityTraitsProvider
+
+		captcha.ManagementProvider
+		ratelimit.ManagementProvider
+		audit.RecorderProvider
 	}
 
 	Strategy struct {
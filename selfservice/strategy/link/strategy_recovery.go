@@ -17,11 +17,14 @@ import (
 	"github.com/ory/x/sqlcon"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/recovery"
 	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
@@ -40,7 +43,7 @@ func (s *Strategy) RecoveryStrategyID() string {
 func (s *Strategy) RegisterPublicRecoveryRoutes(public *x.RouterPublic) {
 	redirect := session.RedirectOnAuthenticated(s.d)
 
-	wrappedHandleRecovery := strategy.IsRecoveryDisabled(s.d, s.RecoveryStrategyID(), s.handleRecovery)
+	wrappedHandleRecovery := s.d.RateLimiter().Handle(ratelimit.FlowRecovery, s.RecoveryStrategyID(), strategy.IsRecoveryDisabled(s.d, s.RecoveryStrategyID(), s.handleRecovery))
 	public.GET(RouteRecovery, s.d.SessionHandler().IsNotAuthenticated(wrappedHandleRecovery, redirect))
 	public.POST(RouteRecovery, s.d.SessionHandler().IsNotAuthenticated(wrappedHandleRecovery, redirect))
 }
@@ -55,6 +58,9 @@ func (s *Strategy) PopulateRecoveryMethod(r *http.Request, req *recovery.Flow) e
 
 	f.SetCSRF(s.d.GenerateCSRFToken(r))
 	f.SetField(form.Field{Name: "email", Type: "email", Required: true})
+	if err := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowRecovery, f); err != nil {
+		return err
+	}
 
 	req.Methods[s.RecoveryStrategyID()] = &recovery.FlowMethod{
 		Method: s.RecoveryStrategyID(),
@@ -231,6 +237,9 @@ type completeSelfServiceRecoveryFlowWithLinkMethod struct {
 
 	// Sending the anti-csrf token is only required for browser login flows.
 	CSRFToken string `form:"csrf_token" json:"csrf_token"`
+
+	// The CAPTCHA response token is only required if the recovery flow asks for a CAPTCHA challenge.
+	CaptchaToken string `form:"captcha_token" json:"captcha_token,omitempty"`
 }
 
 // swagger:route POST /self-service/recovery/methods/link public completeSelfServiceRecoveryFlowWithLinkMethod
@@ -313,6 +322,13 @@ func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if !recovered.IsActive() {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeRecovery, s.RecoveryStrategyID(), recoveredID,
+			errors.New("the identity is not active")))
+		s.retryRecoveryFlowWithMessage(w, r, flow.TypeBrowser, text.NewErrorValidationIdentityInactive())
+		return
+	}
+
 	f.Messages.Clear()
 	f.State = recovery.StatePassedChallenge
 	f.RecoveredIdentityID = uuid.NullUUID{
@@ -325,10 +341,17 @@ func (s *Strategy) recoveryIssueSession(w http.ResponseWriter, r *http.Request,
 	}
 
 	sess := session.NewActiveSession(recovered, s.d.Config(r.Context()), time.Now().UTC())
+	session.WithDevice(r)(sess)
 	if err := s.d.SessionManager().CreateAndIssueCookie(r.Context(), w, r, sess); err != nil {
 		s.handleRecoveryError(w, r, f, nil, err)
 		return
 	}
+	s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeRecovery, s.RecoveryStrategyID(), recoveredID, nil))
+
+	if err := s.d.RecoveryExecutor().PostRecoveryHook(w, r, f, sess); err != nil {
+		s.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
+		return
+	}
 
 	sf, err := s.d.SettingsHandler().NewFlow(w, r, sess.Identity, flow.TypeBrowser)
 	if err != nil {
@@ -378,6 +401,7 @@ func (s *Strategy) recoveryUseToken(w http.ResponseWriter, r *http.Request, body
 	}
 
 	if err := token.Valid(); err != nil {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeRecovery, s.RecoveryStrategyID(), token.RecoveryAddress.IdentityID, err))
 		s.handleRecoveryError(w, r, f, body, err)
 		return
 	}
@@ -427,11 +451,21 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if err := s.d.CaptchaManager().Verify(r, captcha.FlowRecovery, body.Body.CaptchaToken); err != nil {
+		s.handleRecoveryError(w, r, req, body, err)
+		return
+	}
+
 	if err := s.d.LinkSender().SendRecoveryLink(r.Context(), r, req, identity.VerifiableAddressTypeEmail, body.Body.Email); err != nil {
 		if !errors.Is(err, ErrUnknownAddress) {
 			s.handleRecoveryError(w, r, req, body, err)
 			return
 		}
+
+		if err := s.d.CaptchaManager().RegisterFailure(r, captcha.FlowRecovery); err != nil {
+			s.handleRecoveryError(w, r, req, body, err)
+			return
+		}
 		// Continue execution
 	}
 
@@ -444,6 +478,10 @@ func (s *Strategy) recoveryHandleFormSubmission(w http.ResponseWriter, r *http.R
 	config.Reset()
 	config.SetCSRF(s.d.GenerateCSRFToken(r))
 	config.SetField(form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email})
+	if err := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowRecovery, config); err != nil {
+		s.handleRecoveryError(w, r, req, body, err)
+		return
+	}
 
 	req.Active = sqlxx.NullString(s.RecoveryStrategyID())
 	req.State = recovery.StateEmailSent
@@ -478,6 +516,10 @@ func (s *Strategy) handleRecoveryError(w http.ResponseWriter, r *http.Request, r
 		config.Reset()
 		config.SetCSRF(s.d.GenerateCSRFToken(r))
 		config.SetField(form.Field{Name: "email", Type: "email", Required: true, Value: body.Body.Email})
+		if err := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowRecovery, config); err != nil {
+			s.d.RecoveryFlowErrorHandler().WriteFlowError(w, r, s.RecoveryStrategyID(), req, err)
+			return
+		}
 	}
 
 	s.d.RecoveryFlowErrorHandler().WriteFlowError(w, r, s.RecoveryStrategyID(), req, err)
@@ -131,6 +131,31 @@ func TestAdminStrategy(t *testing.T) {
 		assert.Equal(t, "You successfully recovered your account. Please change your password or set up an alternative login method (e.g. social sign in) within the next 60.00 minutes.", sr.Payload.Messages[0].Text)
 	})
 
+	t.Run("description=should create a valid recovery link but not recover an inactive account", func(t *testing.T) {
+		id := identity.Identity{Traits: identity.Traits(`{"email":"recoverme-inactive@ory.sh"}`), State: identity.StateInactive}
+
+		require.NoError(t, reg.IdentityManager().Create(context.Background(),
+			&id, identity.ManagerAllowWriteProtectedTraits))
+
+		uuid := models.UUID(id.ID.String())
+		rl, err := adminSDK.Admin.CreateRecoveryLink(admin.NewCreateRecoveryLinkParams().
+			WithBody(&models.CreateRecoveryLink{IdentityID: &uuid}))
+		require.NoError(t, err)
+
+		c := testhelpers.NewClientWithCookies(t)
+		res, err := c.Get(*rl.Payload.RecoveryLink)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Request.URL.String(), conf.SelfServiceFlowRecoveryUI().String()+"?flow=")
+
+		sr, err := adminSDK.Public.GetSelfServiceRecoveryFlow(
+			sdkp.NewGetSelfServiceRecoveryFlowParams().WithHTTPClient(c).
+				WithID(res.Request.URL.Query().Get("flow")))
+		require.NoError(t, err)
+
+		require.Len(t, sr.Payload.Messages, 1)
+		assert.Equal(t, text.NewErrorValidationIdentityInactive().Text, sr.Payload.Messages[0].Text)
+	})
 }
 
 func TestRecovery(t *testing.T) {
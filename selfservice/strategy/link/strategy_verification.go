@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy"
 
 	"github.com/gofrs/uuid"
@@ -16,6 +17,7 @@ import (
 	"github.com/ory/x/sqlxx"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow"
@@ -34,7 +36,7 @@ func (s *Strategy) VerificationStrategyID() string {
 }
 
 func (s *Strategy) RegisterPublicVerificationRoutes(public *x.RouterPublic) {
-	wrappedHandleVerification := strategy.IsVerificationDisabled(s.d, s.RecoveryStrategyID(), s.handleVerification)
+	wrappedHandleVerification := s.d.RateLimiter().Handle(ratelimit.FlowVerification, s.VerificationStrategyID(), strategy.IsVerificationDisabled(s.d, s.RecoveryStrategyID(), s.handleVerification))
 	public.POST(RouteVerification, wrappedHandleVerification)
 	public.GET(RouteVerification, wrappedHandleVerification)
 }
@@ -303,6 +305,7 @@ func (s *Strategy) verificationUseToken(w http.ResponseWriter, r *http.Request,
 	}
 
 	if err := token.Valid(); err != nil {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeVerification, s.VerificationStrategyID(), token.VerifiableAddress.IdentityID, err))
 		s.handleVerificationError(w, r, f, body, err)
 		return
 	}
@@ -322,6 +325,7 @@ func (s *Strategy) verificationUseToken(w http.ResponseWriter, r *http.Request,
 		s.handleVerificationError(w, r, f, body, err)
 		return
 	}
+	s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeVerification, s.VerificationStrategyID(), address.IdentityID, nil))
 
 	http.Redirect(w, r, s.d.Config(r.Context()).SelfServiceFlowVerificationReturnTo(f.
 		AppendTo(s.d.Config(r.Context()).SelfServiceFlowVerificationUI())).String(), http.StatusFound)
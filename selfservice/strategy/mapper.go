@@ -0,0 +1,127 @@
+package strategy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/google/go-jsonnet"
+	"github.com/imdario/mergo"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/identity"
+)
+
+// EvaluateMapper fetches the Jsonnet mapper and evaluates it with the claims passed as external variable `claims`.
+func EvaluateMapper(f *fetcher.Fetcher, mapper string, claims interface{}) (string, error) {
+	jn, err := f.Fetch(mapper)
+	if err != nil {
+		return "", err
+	}
+
+	var jsonClaims bytes.Buffer
+	if err := json.NewEncoder(&jsonClaims).Encode(claims); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.ExtCode("claims", jsonClaims.String())
+	evaluated, err := vm.EvaluateSnippet(mapper, jn.String())
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return evaluated, nil
+}
+
+// MergeTraits deeply merges the mapped traits into the identity's traits. Nested objects are merged key by key
+// while all other values (including arrays) replace the respective values. The returned boolean is false if the
+// merge did not change the traits.
+func MergeTraits(traits identity.Traits, mapped json.RawMessage) (identity.Traits, bool, error) {
+	var original, merged map[string]interface{}
+	if err := json.Unmarshal(traits, &original); err != nil {
+		return nil, false, errors.WithStack(herodot.ErrInternalServerError.WithReason("The identity traits could not be decoded properly").WithDebug(err.Error()))
+	}
+	if err := json.Unmarshal(traits, &merged); err != nil {
+		return nil, false, errors.WithStack(herodot.ErrInternalServerError.WithReason("The identity traits could not be decoded properly").WithDebug(err.Error()))
+	}
+
+	var update map[string]interface{}
+	if err := json.Unmarshal(mapped, &update); err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+
+	mergeObjects(merged, update)
+	if reflect.DeepEqual(original, merged) {
+		return traits, false, nil
+	}
+
+	result, err := json.Marshal(merged)
+	if err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+	return result, true, nil
+}
+
+func mergeObjects(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if sv, ok := v.(map[string]interface{}); ok {
+			if dv, ok := dst[k].(map[string]interface{}); ok {
+				mergeObjects(dv, sv)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+type decodedForm struct {
+	Traits map[string]interface{} `json:"traits"`
+}
+
+// MergeFormTraits merges the userFormValues (extracted from the initial POST request) prefixed with `traits`
+// (encoded) with the traits mapped from the upstream identity provider. Values from the form take precedence.
+func MergeFormTraits(userFormValues string, mapped json.RawMessage, option decoderx.HTTPDecoderOption) (identity.Traits, error) {
+	if userFormValues == "" {
+		return identity.Traits(mapped), nil
+	}
+
+	var df decodedForm
+
+	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(userFormValues))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	if err := decoderx.NewHTTP().Decode(
+		req, &df,
+		decoderx.HTTPFormDecoder(),
+		option,
+		decoderx.HTTPDecoderSetValidatePayloads(false),
+	); err != nil {
+		return nil, err
+	}
+
+	var decodedTraits map[string]interface{}
+	if err := json.NewDecoder(bytes.NewBuffer(mapped)).Decode(&decodedTraits); err != nil {
+		return nil, err
+	}
+
+	// decodedForm (coming from POST request) overrides decodedTraits (coming from the identity provider)
+	if err := mergo.Merge(&decodedTraits, df.Traits, mergo.WithOverride); err != nil {
+		return nil, err
+	}
+
+	var result bytes.Buffer
+	if err := json.NewEncoder(&result).Encode(decodedTraits); err != nil {
+		return nil, err
+	}
+
+	return result.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package strategy_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/fetcher"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy"
+)
+
+func TestEvaluateMapper(t *testing.T) {
+	mapper := "base64://bG9jYWwgY2xhaW1zID0gc3RkLmV4dFZhcignY2xhaW1zJyk7CnsgaWRlbnRpdHk6IHsgdHJhaXRzOiB7IGVtYWlsOiBjbGFpbXMuZW1haWwgfSB9IH0K"
+
+	evaluated, err := strategy.EvaluateMapper(fetcher.NewFetcher(), mapper, map[string]string{"email": "foo@ory.sh"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"identity":{"traits":{"email":"foo@ory.sh"}}}`, evaluated)
+
+	_, err = strategy.EvaluateMapper(fetcher.NewFetcher(), "base64://e30K", make(chan int))
+	require.Error(t, err)
+}
+
+func TestMergeTraits(t *testing.T) {
+	for k, tc := range []struct {
+		traits, mapped, expected string
+		changed                  bool
+	}{
+		{
+			traits:   `{"email":"foo@ory.sh","name":{"first":"Foo","last":"Bar"},"tags":["a","b"]}`,
+			mapped:   `{"name":{"first":"Baz"},"tags":["c"]}`,
+			expected: `{"email":"foo@ory.sh","name":{"first":"Baz","last":"Bar"},"tags":["c"]}`,
+			changed:  true,
+		},
+		{
+			traits:   `{"email":"foo@ory.sh","name":{"first":"Foo"}}`,
+			mapped:   `{"name":{"first":"Foo"}}`,
+			expected: `{"email":"foo@ory.sh","name":{"first":"Foo"}}`,
+		},
+		{
+			traits:   `{"email":"foo@ory.sh","name":"Foo"}`,
+			mapped:   `{"name":{"first":"Foo"}}`,
+			expected: `{"email":"foo@ory.sh","name":{"first":"Foo"}}`,
+			changed:  true,
+		},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			actual, changed, err := strategy.MergeTraits(identity.Traits(tc.traits), json.RawMessage(tc.mapped))
+			require.NoError(t, err)
+			assert.Equal(t, tc.changed, changed)
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+
+	_, _, err := strategy.MergeTraits(identity.Traits(`[]`), json.RawMessage(`{}`))
+	require.Error(t, err)
+}
@@ -1,5 +1,6 @@
 package oidc
 
 const (
-	sessionName = "ory_kratos_oidc_auth_code_session"
+	sessionName     = "ory_kratos_oidc_auth_code_session"
+	linkSessionName = "ory_kratos_oidc_link_session"
 )
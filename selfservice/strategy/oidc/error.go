@@ -13,4 +13,7 @@ var (
 
 	ErrAPIFlowNotSupported = herodot.ErrBadRequest.WithError("API-based flows are not supported for this method").
 				WithReasonf("Social Sign In and OpenID Connect are only supported for flows initiated using the Browser endpoint.")
+
+	ErrIDTokenNotSupported = herodot.ErrBadRequest.WithError("the provider does not support signing in with an ID token").
+				WithReasonf("Signing in with an ID token is not supported by this provider. Please use the Browser flow instead.")
 )
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/selfservice/strategy"
 )
 
 func TestMerge(t *testing.T) {
@@ -32,7 +34,7 @@ func TestMerge(t *testing.T) {
 			option, err := decoderRegistration(fmt.Sprintf("file://stub/merge/%d.schema.json", k))
 			require.NoError(t, err)
 
-			got, err := merge(tc.form, tc.op, option)
+			got, err := strategy.MergeFormTraits(tc.form, tc.op, option)
 			require.NoError(t, err)
 			assert.JSONEq(t, string(tc.expect), string(got))
 		})
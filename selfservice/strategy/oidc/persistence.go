@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/x"
+)
+
+type (
+	ProviderPersister interface {
+		CreateOIDCProvider(ctx context.Context, c *StoredConfiguration) error
+		UpdateOIDCProvider(ctx context.Context, c *StoredConfiguration) error
+		GetOIDCProvider(ctx context.Context, providerID string) (*StoredConfiguration, error)
+		ListOIDCProviders(ctx context.Context) ([]StoredConfiguration, error)
+		DeleteOIDCProvider(ctx context.Context, providerID string) error
+	}
+
+	ProviderPersistenceProvider interface {
+		OIDCProviderPersister() ProviderPersister
+	}
+)
+
+func TestPersister(ctx context.Context, p ProviderPersister) func(t *testing.T) {
+	var newConfiguration = func(id string) *StoredConfiguration {
+		return &StoredConfiguration{
+			ID:         x.NewUUID(),
+			ProviderID: id,
+			Config:     []byte(`{"id":"` + id + `","provider":"generic"}`),
+		}
+	}
+
+	return func(t *testing.T) {
+		t.Run("case=create and get", func(t *testing.T) {
+			expected := newConfiguration(x.NewUUID().String())
+			require.NoError(t, p.CreateOIDCProvider(ctx, expected))
+
+			actual, err := p.GetOIDCProvider(ctx, expected.ProviderID)
+			require.NoError(t, err)
+			assert.Equal(t, expected.ID, actual.ID)
+			assert.Equal(t, expected.ProviderID, actual.ProviderID)
+			assert.JSONEq(t, string(expected.Config), string(actual.Config))
+
+			_, err = p.GetOIDCProvider(ctx, x.NewUUID().String())
+			require.True(t, errors.Is(err, sqlcon.ErrNoRows), "%+v", err)
+		})
+
+		t.Run("case=provider ids are unique", func(t *testing.T) {
+			id := x.NewUUID().String()
+			require.NoError(t, p.CreateOIDCProvider(ctx, newConfiguration(id)))
+			err := p.CreateOIDCProvider(ctx, newConfiguration(id))
+			require.True(t, errors.Is(err, sqlcon.ErrUniqueViolation), "%+v", err)
+		})
+
+		t.Run("case=update", func(t *testing.T) {
+			expected := newConfiguration(x.NewUUID().String())
+			require.NoError(t, p.CreateOIDCProvider(ctx, expected))
+
+			expected.Config = []byte(`{"id":"` + expected.ProviderID + `","provider":"google"}`)
+			require.NoError(t, p.UpdateOIDCProvider(ctx, expected))
+
+			actual, err := p.GetOIDCProvider(ctx, expected.ProviderID)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected.Config), string(actual.Config))
+		})
+
+		t.Run("case=list and delete", func(t *testing.T) {
+			expected := newConfiguration(x.NewUUID().String())
+			require.NoError(t, p.CreateOIDCProvider(ctx, expected))
+
+			var has = func() bool {
+				actual, err := p.ListOIDCProviders(ctx)
+				require.NoError(t, err)
+				for _, c := range actual {
+					if c.ID == expected.ID {
+						return true
+					}
+				}
+				return false
+			}
+
+			assert.True(t, has())
+			require.NoError(t, p.DeleteOIDCProvider(ctx, expected.ProviderID))
+			assert.False(t, has())
+
+			err := p.DeleteOIDCProvider(ctx, expected.ProviderID)
+			require.True(t, errors.Is(err, sqlcon.ErrNoRows), "%+v", err)
+		})
+	}
+}
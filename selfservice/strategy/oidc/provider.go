@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"net/url"
 
 	"golang.org/x/oauth2"
 )
@@ -13,6 +14,34 @@ type Provider interface {
 	AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption
 }
 
+// IDTokenVerifier is implemented by providers which support signing in with an ID token which was obtained
+// natively by the client, for example using the Google Sign-In SDK on Android or iOS.
+type IDTokenVerifier interface {
+	// VerifyIDToken verifies the ID token's signature, issuer, audience, and nonce and returns its claims.
+	VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error)
+}
+
+// EndSessionURLer is implemented by providers which support OpenID Connect RP-Initiated Logout.
+type EndSessionURLer interface {
+	// EndSessionURL returns the URL of the provider's end_session_endpoint which ends the provider's session and
+	// redirects the browser to postLogoutRedirectURI.
+	EndSessionURL(ctx context.Context, idTokenHint, postLogoutRedirectURI string) (*url.URL, error)
+}
+
+// LogoutTokenVerifier is implemented by providers which support OpenID Connect Back-Channel Logout.
+type LogoutTokenVerifier interface {
+	// VerifyLogoutToken verifies the logout token's signature, issuer, audience, and event and returns the subject
+	// and session ID (`sid`) of the provider's session which ended. At least one of them is set.
+	VerifyLogoutToken(ctx context.Context, rawLogoutToken string) (subject, sessionID string, err error)
+}
+
+// CallbackClaimsDecoder is implemented by providers which return some claims only as parameters of the callback
+// request, for example "Sign in with Apple" which sends the user's name once, on the first sign in.
+type CallbackClaimsDecoder interface {
+	// DecodeCallbackClaims adds the claims from the callback request's parameters to claims.
+	DecodeCallbackClaims(values url.Values, claims *Claims)
+}
+
 type Claims struct {
 	Issuer              string `json:"iss,omitempty"`
 	Subject             string `json:"sub,omitempty"`
@@ -36,4 +65,5 @@ type Claims struct {
 	PhoneNumberVerified bool   `json:"phone_number_verified,omitempty"`
 	UpdatedAt           int64  `json:"updated_at,omitempty"`
 	HD                  string `json:"hd,omitempty"`
+	SessionID           string `json:"sid,omitempty"`
 }
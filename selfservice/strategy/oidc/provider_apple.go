@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+var _ CallbackClaimsDecoder = new(ProviderApple)
+
+type ProviderApple struct {
+	*ProviderGenericOIDC
+}
+
+func NewProviderApple(
+	config *Configuration,
+	public *url.URL,
+) *ProviderApple {
+	config.IssuerURL = "https://appleid.apple.com"
+	return &ProviderApple{
+		ProviderGenericOIDC: &ProviderGenericOIDC{
+			config: config,
+			public: public,
+		},
+	}
+}
+
+// newClientSecret returns the client secret JWT which Apple expects instead of a static secret. It is signed with
+// the "Sign in with Apple" private key and valid for a few minutes only.
+func (a *ProviderApple) newClientSecret() (string, error) {
+	key, err := parseApplePrivateKey(a.config.ApplePrivateKey)
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the Apple private key: %s", err))
+	}
+
+	now := time.Now().UTC()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, &jwt.StandardClaims{
+		Audience:  a.config.IssuerURL,
+		ExpiresAt: now.Add(time.Minute * 5).Unix(),
+		IssuedAt:  now.Unix(),
+		Issuer:    a.config.AppleTeamID,
+		Subject:   a.config.ClientID,
+	})
+	token.Header["kid"] = a.config.ApplePrivateKeyID
+
+	secret, err := token.SignedString(key)
+	if err != nil {
+		return "", errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to sign the Apple client secret: %s", err))
+	}
+	return secret, nil
+}
+
+// parseApplePrivateKey parses the PKCS #8 encoded key which Apple issues as well as SEC 1 encoded EC keys.
+func parseApplePrivateKey(encoded string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil {
+		return nil, errors.New("the key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if ec, ok := key.(*ecdsa.PrivateKey); ok {
+			return ec, nil
+		}
+		return nil, errors.Errorf("expected an EC private key but got %T", key)
+	}
+
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+func (a *ProviderApple) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	p, err := a.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := a.newClientSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := p.Endpoint()
+	endpoint.AuthStyle = oauth2.AuthStyleInParams
+
+	c := a.oauth2ConfigFromEndpoint(endpoint)
+	c.ClientSecret = secret
+	return c, nil
+}
+
+func (a *ProviderApple) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	// Apple only returns the user's name and email address to the callback if the response is posted.
+	return append(a.ProviderGenericOIDC.AuthCodeURLOptions(r),
+		oauth2.SetAuthURLParam("response_mode", "form_post"))
+}
+
+func (a *ProviderApple) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
+	raw, ok := exchange.Extra("id_token").(string)
+	if !ok || len(raw) == 0 {
+		return nil, errors.WithStack(ErrIDTokenMissing)
+	}
+
+	p, err := a.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.verifyWithProvider(ctx, p, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAppleClaims(token.Claims)
+}
+
+func (a *ProviderApple) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	p, err := a.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.verifyIDTokenAndNonceWithProvider(ctx, p, raw, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAppleClaims(token.Claims)
+}
+
+// DecodeCallbackClaims adds the user's name, which Apple only includes in the `user` parameter of the very first
+// callback and never in the ID token.
+func (a *ProviderApple) DecodeCallbackClaims(values url.Values, claims *Claims) {
+	var user struct {
+		Name struct {
+			FirstName string `json:"firstName"`
+			LastName  string `json:"lastName"`
+		} `json:"name"`
+	}
+
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil {
+		return
+	}
+
+	claims.GivenName = user.Name.FirstName
+	claims.FamilyName = user.Name.LastName
+	claims.LastName = user.Name.LastName
+	claims.Name = strings.TrimSpace(user.Name.FirstName + " " + user.Name.LastName)
+}
+
+// appleBool is a boolean claim which Apple encodes either as a JSON boolean or as a string.
+type appleBool bool
+
+func (b *appleBool) UnmarshalJSON(raw []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+
+	switch t := v.(type) {
+	case bool:
+		*b = appleBool(t)
+	case string:
+		parsed, err := strconv.ParseBool(t)
+		if err != nil {
+			return errors.Errorf("unable to decode %s as a boolean", raw)
+		}
+		*b = appleBool(parsed)
+	case nil:
+		*b = false
+	default:
+		return errors.Errorf("unable to decode %s as a boolean", raw)
+	}
+	return nil
+}
+
+func decodeAppleClaims(decode func(v interface{}) error) (*Claims, error) {
+	var claims struct {
+		Claims
+		EmailVerified appleBool `json:"email_verified"`
+	}
+	if err := decode(&claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	claims.Claims.EmailVerified = bool(claims.EmailVerified)
+	return &claims.Claims, nil
+}
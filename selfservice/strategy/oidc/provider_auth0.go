@@ -0,0 +1,84 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+type ProviderAuth0 struct {
+	*ProviderGenericOIDC
+}
+
+func NewProviderAuth0(
+	config *Configuration,
+	public *url.URL,
+) *ProviderAuth0 {
+	// Auth0 includes a trailing slash in the issuer which must match exactly.
+	if len(config.IssuerURL) > 0 && !strings.HasSuffix(config.IssuerURL, "/") {
+		config.IssuerURL += "/"
+	}
+
+	return &ProviderAuth0{
+		ProviderGenericOIDC: &ProviderGenericOIDC{
+			config: config,
+			public: public,
+		},
+	}
+}
+
+func (a *ProviderAuth0) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
+	raw, ok := exchange.Extra("id_token").(string)
+	if !ok || len(raw) == 0 {
+		return nil, errors.WithStack(ErrIDTokenMissing)
+	}
+
+	p, err := a.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.verifyWithProvider(ctx, p, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAuth0Claims(token.Claims)
+}
+
+func (a *ProviderAuth0) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	p, err := a.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.verifyIDTokenAndNonceWithProvider(ctx, p, raw, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAuth0Claims(token.Claims)
+}
+
+// decodeAuth0Claims decodes the claims of an Auth0 ID token, which encodes `updated_at` as an ISO 8601 timestamp
+// instead of seconds since the epoch.
+func decodeAuth0Claims(decode func(v interface{}) error) (*Claims, error) {
+	var claims struct {
+		Claims
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	if err := decode(&claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	if claims.UpdatedAt != nil {
+		claims.Claims.UpdatedAt = claims.UpdatedAt.Unix()
+	}
+	return &claims.Claims, nil
+}
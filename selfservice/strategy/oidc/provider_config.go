@@ -12,6 +12,9 @@ import (
 	"github.com/ory/x/urlx"
 )
 
+// Configuration is the configuration of an OpenID Connect Provider.
+//
+// swagger:model oidcProvider
 type Configuration struct {
 	// ID is the provider's ID
 	ID string `json:"id"`
@@ -25,16 +28,26 @@ type Configuration struct {
 	// - discord
 	// - slack
 	// - facebook
+	// - apple
+	// - auth0
+	// - keycloak
+	// - linkedin
+	// - spotify
+	// - twitch
 	Provider string `json:"provider"`
 
 	// ClientID is the application's Client ID.
 	ClientID string `json:"client_id"`
 
-	// ClientSecret is the application's secret.
-	ClientSecret string `json:"client_secret"`
+	// ClientSecret is the application's secret. It is not used when `provider` is set to `apple` because Apple
+	// expects a client secret which is signed with ApplePrivateKey instead.
+	ClientSecret string `json:"client_secret,omitempty"`
 
-	// IssuerURL is the OpenID Connect Server URL. You can leave this empty if `provider` is not set to `generic`.
-	// If set, neither `auth_url` nor `token_url` are required.
+	// IssuerURL is the OpenID Connect Server URL. You can leave this empty if `provider` is not set to `generic`,
+	// `auth0`, or `keycloak`. If set, neither `auth_url` nor `token_url` are required.
+	//
+	// For `auth0` this is the tenant's domain, for example `https://example.eu.auth0.com/`, and for `keycloak` the
+	// realm's URL, for example `https://keycloak.example.org/auth/realms/example`.
 	IssuerURL string `json:"issuer_url"`
 
 	// AuthURL is the authorize url, typically something like: https://example.org/oauth2/auth
@@ -52,6 +65,17 @@ type Configuration struct {
 	// `8eaef023-2b34-4da1-9baa-8bc8c9d6a490` or `contoso.onmicrosoft.com`.
 	Tenant string `json:"tenant"`
 
+	// AppleTeamID is the Apple Developer Team ID and must be set when `provider` is set to `apple`.
+	AppleTeamID string `json:"apple_team_id"`
+
+	// ApplePrivateKeyID is the ID of the "Sign in with Apple" private key and must be set when `provider` is set
+	// to `apple`.
+	ApplePrivateKeyID string `json:"apple_private_key_id"`
+
+	// ApplePrivateKey is the PEM encoded "Sign in with Apple" private key which is used to sign the client secret
+	// and must be set when `provider` is set to `apple`.
+	ApplePrivateKey string `json:"apple_private_key,omitempty"`
+
 	// Scope specifies optional requested permissions.
 	Scope []string `json:"scope"`
 
@@ -66,8 +90,49 @@ type Configuration struct {
 	//
 	// More information: https://openid.net/specs/openid-connect-core-1_0.html#ClaimsParameter
 	RequestedClaims json.RawMessage `json:"requested_claims"`
+
+	// PKCE enables Proof Key for Code Exchange (RFC 7636) with the S256 code challenge method. The code verifier is
+	// kept in the continuity container and sent with the token request. Some providers require PKCE even for
+	// confidential clients.
+	PKCE bool `json:"pkce"`
+
+	// Nonce sends a random nonce with the authorization request and requires the ID token to include the same
+	// nonce. Only has an effect for OpenID Connect providers.
+	Nonce bool `json:"nonce"`
+
+	// EndSessionOnLogout redirects the browser to the provider's end_session_endpoint when someone who signed in
+	// with this provider signs out of ORY Kratos, ending the provider's session as well.
+	EndSessionOnLogout bool `json:"end_session_on_logout"`
+
+	// BackChannelLogout accepts OpenID Connect Back-Channel Logout tokens from this provider and revokes the ORY
+	// Kratos sessions which were created from the provider's session which ended.
+	BackChannelLogout bool `json:"backchannel_logout"`
+
+	// IDTokenAudiences are additional audiences which are accepted when an ID token is submitted in an API flow,
+	// for example the client IDs of the iOS and Android apps. The ClientID is always accepted.
+	IDTokenAudiences []string `json:"additional_id_token_audiences"`
+
+	// LinkPolicy controls whether this provider is linked to an existing identity when the provider asserts a
+	// verified email address which matches a verified address of that identity. Can be either `none` (default)
+	// or `verified_email`.
+	LinkPolicy string `json:"link_policy"`
+
+	// UpdateTraitsOnLogin runs the Jsonnet mapper on every sign in with this provider and merges the resulting
+	// traits into the identity's traits, keeping the identity in sync with the provider's profile.
+	UpdateTraitsOnLogin bool `json:"update_traits_on_login"`
+
+	// LoginMapper optionally specifies a separate Jsonnet code snippet which is used instead of Mapper when
+	// UpdateTraitsOnLogin is enabled.
+	//
+	// It can be either a URL (file://, http(s)://, base64://) or an inline JSONNet code snippet.
+	LoginMapper string `json:"login_mapper_url"`
 }
 
+const (
+	LinkPolicyNone          = "none"
+	LinkPolicyVerifiedEmail = "verified_email"
+)
+
 func (p Configuration) Redir(public *url.URL) string {
 	return urlx.AppendPaths(public,
 		strings.Replace(RouteCallback, ":provider", p.ID, 1),
@@ -78,6 +143,15 @@ type ConfigurationCollection struct {
 	Providers []Configuration `json:"providers"`
 }
 
+func (c ConfigurationCollection) has(id string) bool {
+	for _, p := range c.Providers {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (c ConfigurationCollection) Provider(id string, public *url.URL) (Provider, error) {
 	for k := range c.Providers {
 		p := c.Providers[k]
@@ -105,6 +179,18 @@ func (c ConfigurationCollection) Provider(id string, public *url.URL) (Provider,
 				return NewProviderSlack(&p, public), nil
 			case addProviderName("facebook"):
 				return NewProviderFacebook(&p, public), nil
+			case addProviderName("apple"):
+				return NewProviderApple(&p, public), nil
+			case addProviderName("auth0"):
+				return NewProviderAuth0(&p, public), nil
+			case addProviderName("keycloak"):
+				return NewProviderKeycloak(&p, public), nil
+			case addProviderName("linkedin"):
+				return NewProviderLinkedIn(&p, public), nil
+			case addProviderName("spotify"):
+				return NewProviderSpotify(&p, public), nil
+			case addProviderName("twitch"):
+				return NewProviderTwitch(&p, public), nil
 			}
 			return nil, errors.Errorf("provider type %s is not supported, supported are: %v", p.Provider, providerNames)
 		}
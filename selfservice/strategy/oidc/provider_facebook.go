@@ -47,6 +47,10 @@ func (g *ProviderFacebook) OAuth2(ctx context.Context) (*oauth2.Config, error) {
 	return g.oauth2ConfigFromEndpoint(endpoint), nil
 }
 
+func (g *ProviderFacebook) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	return nil, errors.WithStack(ErrIDTokenNotSupported)
+}
+
 func (g *ProviderFacebook) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
 	o, err := g.OAuth2(ctx)
 	if err != nil {
@@ -2,7 +2,10 @@ package oidc
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
@@ -14,6 +17,26 @@ import (
 )
 
 var _ Provider = new(ProviderGenericOIDC)
+var _ EndSessionURLer = new(ProviderGenericOIDC)
+var _ LogoutTokenVerifier = new(ProviderGenericOIDC)
+
+const backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// logoutTokenMaxAge is how long logout tokens without an expiry are accepted after they were issued.
+const logoutTokenMaxAge = time.Minute * 5
+
+type nonceContextKey struct{}
+
+// withNonce returns a context which carries the nonce of the authorization request. The ID token returned by the
+// token endpoint must include this nonce if nonces are enabled for the provider.
+func withNonce(ctx context.Context, nonce string) context.Context {
+	return context.WithValue(ctx, nonceContextKey{}, nonce)
+}
+
+func nonceFromContext(ctx context.Context) string {
+	nonce, _ := ctx.Value(nonceContextKey{}).(string)
+	return nonce
+}
 
 type ProviderGenericOIDC struct {
 	p      *gooidc.Provider
@@ -85,7 +108,7 @@ func (g *ProviderGenericOIDC) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption
 	return options
 }
 
-func (g *ProviderGenericOIDC) verifyAndDecodeClaimsWithProvider(ctx context.Context, provider *gooidc.Provider, raw string) (*Claims, error) {
+func (g *ProviderGenericOIDC) verifyWithProvider(ctx context.Context, provider *gooidc.Provider, raw string) (*gooidc.IDToken, error) {
 	token, err := provider.
 		Verifier(&gooidc.Config{
 			ClientID: g.config.ClientID,
@@ -95,6 +118,74 @@ func (g *ProviderGenericOIDC) verifyAndDecodeClaimsWithProvider(ctx context.Cont
 		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
 	}
 
+	if g.config.Nonce {
+		if err := verifyNonce(nonceFromContext(ctx), token.Nonce); err != nil {
+			return nil, err
+		}
+	}
+
+	return token, nil
+}
+
+func (g *ProviderGenericOIDC) verifyAndDecodeClaimsWithProvider(ctx context.Context, provider *gooidc.Provider, raw string) (*Claims, error) {
+	token, err := g.verifyWithProvider(ctx, provider, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeIDTokenClaims(token)
+}
+
+func (g *ProviderGenericOIDC) verifyIDTokenAndNonceWithProvider(ctx context.Context, provider *gooidc.Provider, raw, nonce string) (*gooidc.IDToken, error) {
+	token, err := provider.
+		Verifier(&gooidc.Config{
+			// The audience is checked below because the ID token might have been issued to one of the
+			// additional audiences (e.g. the client ID of the iOS or Android app).
+			SkipClientIDCheck: true,
+		}).
+		Verify(ctx, raw)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	audiences := append([]string{g.config.ClientID}, g.config.IDTokenAudiences...)
+	var audienceMatches bool
+	for _, aud := range token.Audience {
+		if stringslice.Has(audiences, aud) {
+			audienceMatches = true
+			break
+		}
+	}
+	if !audienceMatches {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The ID token was issued for audience %v but expected one of: %v", token.Audience, audiences))
+	}
+
+	if err := verifyNonce(nonce, token.Nonce); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func verifyNonce(expected, actual string) error {
+	if len(expected) == 0 || len(actual) == 0 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The ID token and the request must both include a nonce."))
+	} else if subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) != 1 {
+		return errors.WithStack(herodot.ErrBadRequest.WithReason("The ID token nonce does not match the nonce from the request."))
+	}
+	return nil
+}
+
+func (g *ProviderGenericOIDC) verifyIDTokenWithProvider(ctx context.Context, provider *gooidc.Provider, raw, nonce string) (*Claims, error) {
+	token, err := g.verifyIDTokenAndNonceWithProvider(ctx, provider, raw, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeIDTokenClaims(token)
+}
+
+func decodeIDTokenClaims(token *gooidc.IDToken) (*Claims, error) {
 	var claims Claims
 	if err := token.Claims(&claims); err != nil {
 		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
@@ -103,6 +194,15 @@ func (g *ProviderGenericOIDC) verifyAndDecodeClaimsWithProvider(ctx context.Cont
 	return &claims, nil
 }
 
+func (g *ProviderGenericOIDC) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.verifyIDTokenWithProvider(ctx, p, raw, nonce)
+}
+
 func (g *ProviderGenericOIDC) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
 	raw, ok := exchange.Extra("id_token").(string)
 	if !ok || len(raw) == 0 {
@@ -116,3 +216,75 @@ func (g *ProviderGenericOIDC) Claims(ctx context.Context, exchange *oauth2.Token
 
 	return g.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
 }
+
+func (g *ProviderGenericOIDC) EndSessionURL(ctx context.Context, idTokenHint, postLogoutRedirectURI string) (*url.URL, error) {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := p.Claims(&metadata); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the OpenID Connect Provider's metadata: %s", err))
+	} else if len(metadata.EndSessionEndpoint) == 0 {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The OpenID Connect Provider does not advertise an end_session_endpoint."))
+	}
+
+	u, err := url.Parse(metadata.EndSessionEndpoint)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to parse the end_session_endpoint: %s", err))
+	}
+
+	q := u.Query()
+	q.Set("client_id", g.config.ClientID)
+	q.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	if len(idTokenHint) > 0 {
+		q.Set("id_token_hint", idTokenHint)
+	}
+	u.RawQuery = q.Encode()
+
+	return u, nil
+}
+
+func (g *ProviderGenericOIDC) VerifyLogoutToken(ctx context.Context, raw string) (string, string, error) {
+	p, err := g.provider(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	token, err := p.
+		Verifier(&gooidc.Config{
+			ClientID: g.config.ClientID,
+			// Logout tokens are not required to expire, the expiry is checked below.
+			SkipExpiryCheck: true,
+		}).
+		Verify(ctx, raw)
+	if err != nil {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	var claims struct {
+		SessionID string                     `json:"sid"`
+		Events    map[string]json.RawMessage `json:"events"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err))
+	}
+
+	now := time.Now()
+	if _, ok := claims.Events[backChannelLogoutEvent]; !ok {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReasonf("The logout token does not contain the %s event.", backChannelLogoutEvent))
+	} else if len(token.Nonce) > 0 {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token must not contain a nonce."))
+	} else if len(token.Subject) == 0 && len(claims.SessionID) == 0 {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token must contain a subject or a session ID."))
+	} else if !token.Expiry.IsZero() && token.Expiry.Before(now) {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token is expired."))
+	} else if token.Expiry.IsZero() && token.IssuedAt.Add(logoutTokenMaxAge).Before(now) {
+		return "", "", errors.WithStack(herodot.ErrBadRequest.WithReason("The logout token is too old."))
+	}
+
+	return token.Subject, claims.SessionID, nil
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
 
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/x"
@@ -67,3 +69,188 @@ func TestProviderGenericOIDC_AddAuthCodeURLOptions(t *testing.T) {
 		assert.Contains(t, makeAuthCodeURL(t, r), "claims="+url.QueryEscape(string(makeOIDCClaims())))
 	})
 }
+
+func TestAuthCodeContainer(t *testing.T) {
+	newProvider := func(pkce, nonce bool) Provider {
+		return NewProviderGenericOIDC(&Configuration{ID: "valid", Provider: "generic", PKCE: pkce, Nonce: nonce}, nil)
+	}
+
+	t.Run("case=should not send a code challenge or nonce by default", func(t *testing.T) {
+		c := newAuthCodeContainer(newProvider(false, false), x.NewUUID(), url.Values{})
+		assert.NotEmpty(t, c.State)
+		assert.Empty(t, c.CodeVerifier)
+		assert.Empty(t, c.Nonce)
+		assert.Empty(t, c.authCodeURLOptions())
+		assert.Empty(t, c.exchangeOptions())
+	})
+
+	t.Run("case=should send a S256 code challenge and nonce", func(t *testing.T) {
+		c := newAuthCodeContainer(newProvider(true, true), x.NewUUID(), url.Values{})
+		assert.Len(t, c.CodeVerifier, 64)
+		assert.NotEmpty(t, c.Nonce)
+		assert.NotEqual(t, c.CodeVerifier, newAuthCodeContainer(newProvider(true, true), x.NewUUID(), url.Values{}).CodeVerifier)
+
+		// The example from RFC 7636, Appendix B.
+		c.CodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+		config := &oauth2.Config{Endpoint: oauth2.Endpoint{AuthURL: "https://ory.sh/oauth2/auth"}}
+		u, err := url.Parse(config.AuthCodeURL(c.State, c.authCodeURLOptions()...))
+		require.NoError(t, err)
+		assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", u.Query().Get("code_challenge"))
+		assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+		assert.Equal(t, c.Nonce, u.Query().Get("nonce"))
+	})
+
+	t.Run("case=should send the code verifier with the token request", func(t *testing.T) {
+		stub := newProviderStub(t, "https://ory.sh")
+		c := newAuthCodeContainer(newProvider(true, false), x.NewUUID(), url.Values{})
+
+		config := &oauth2.Config{ClientID: "client", ClientSecret: "secret", Endpoint: oauth2.Endpoint{TokenURL: "https://ory.sh/oauth2/token"}}
+		_, err := config.Exchange(stub.stubContext(), "code", c.exchangeOptions()...)
+		require.NoError(t, err)
+		assert.Equal(t, c.CodeVerifier, stub.lastTokenRequest().PostForm.Get("code_verifier"))
+	})
+}
+
+func TestProviderGenericOIDC_Nonce(t *testing.T) {
+	stub := newProviderStub(t, "https://ory.sh")
+	public, err := url.Parse("https://ory.sh")
+	require.NoError(t, err)
+
+	newProvider := func(nonce bool) *ProviderGenericOIDC {
+		return NewProviderGenericOIDC(&Configuration{
+			ID:           "valid",
+			Provider:     "generic",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			IssuerURL:    "https://ory.sh",
+			Nonce:        nonce,
+		}, public)
+	}
+
+	claims := func(t *testing.T, p Provider, nonce string) (*Claims, error) {
+		ctx := stub.stubContext()
+		c, err := p.OAuth2(ctx)
+		require.NoError(t, err)
+		token, err := c.Exchange(ctx, "code")
+		require.NoError(t, err)
+		return p.Claims(withNonce(ctx, nonce), token)
+	}
+
+	stub.setIDToken(map[string]interface{}{"sub": "foo", "aud": "client", "nonce": "nonce"})
+
+	t.Run("case=should accept the ID token with the matching nonce", func(t *testing.T) {
+		c, err := claims(t, newProvider(true), "nonce")
+		require.NoError(t, err)
+		assert.Equal(t, "foo", c.Subject)
+	})
+
+	t.Run("case=should reject the ID token with a different nonce", func(t *testing.T) {
+		_, err := claims(t, newProvider(true), "other-nonce")
+		require.Error(t, err)
+	})
+
+	t.Run("case=should reject the ID token without a nonce", func(t *testing.T) {
+		stub.setIDToken(map[string]interface{}{"sub": "foo", "aud": "client"})
+		t.Cleanup(func() { stub.setIDToken(map[string]interface{}{"sub": "foo", "aud": "client", "nonce": "nonce"}) })
+
+		_, err := claims(t, newProvider(true), "nonce")
+		require.Error(t, err)
+	})
+
+	t.Run("case=should not validate the nonce if disabled", func(t *testing.T) {
+		_, err := claims(t, newProvider(false), "")
+		require.NoError(t, err)
+	})
+}
+
+func TestProviderGenericOIDC_EndSessionURL(t *testing.T) {
+	stub := newProviderStub(t, "https://ory.sh")
+	public, err := url.Parse("https://ory.sh")
+	require.NoError(t, err)
+
+	p := NewProviderGenericOIDC(&Configuration{
+		ID:           "valid",
+		Provider:     "generic",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		IssuerURL:    "https://ory.sh",
+	}, public)
+
+	t.Run("case=should include the id token hint", func(t *testing.T) {
+		u, err := p.EndSessionURL(stub.stubContext(), "id-token", "https://www.ory.sh/return")
+		require.NoError(t, err)
+		assert.Equal(t, "https://ory.sh/logout", u.Scheme+"://"+u.Host+u.Path)
+		assert.Equal(t, "bar", u.Query().Get("foo"))
+		assert.Equal(t, "client", u.Query().Get("client_id"))
+		assert.Equal(t, "id-token", u.Query().Get("id_token_hint"))
+		assert.Equal(t, "https://www.ory.sh/return", u.Query().Get("post_logout_redirect_uri"))
+	})
+
+	t.Run("case=should omit an empty id token hint", func(t *testing.T) {
+		u, err := p.EndSessionURL(stub.stubContext(), "", "https://www.ory.sh/return")
+		require.NoError(t, err)
+		_, ok := u.Query()["id_token_hint"]
+		assert.False(t, ok)
+	})
+}
+
+func TestProviderGenericOIDC_VerifyLogoutToken(t *testing.T) {
+	stub := newProviderStub(t, "https://ory.sh")
+	public, err := url.Parse("https://ory.sh")
+	require.NoError(t, err)
+
+	p := NewProviderGenericOIDC(&Configuration{
+		ID:           "valid",
+		Provider:     "generic",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		IssuerURL:    "https://ory.sh",
+	}, public)
+
+	events := map[string]interface{}{backChannelLogoutEvent: map[string]interface{}{}}
+	logoutToken := func(claims map[string]interface{}) string {
+		c := map[string]interface{}{"aud": "client", "sub": "foo", "sid": "bar", "events": events, "exp": nil}
+		for k, v := range claims {
+			c[k] = v
+		}
+		return stub.signIDToken(c)
+	}
+
+	t.Run("case=should return the subject and session id", func(t *testing.T) {
+		subject, sid, err := p.VerifyLogoutToken(stub.stubContext(), logoutToken(nil))
+		require.NoError(t, err)
+		assert.Equal(t, "foo", subject)
+		assert.Equal(t, "bar", sid)
+	})
+
+	t.Run("case=should accept a token with only a session id", func(t *testing.T) {
+		subject, sid, err := p.VerifyLogoutToken(stub.stubContext(), logoutToken(map[string]interface{}{"sub": nil}))
+		require.NoError(t, err)
+		assert.Empty(t, subject)
+		assert.Equal(t, "bar", sid)
+	})
+
+	for _, tc := range []struct {
+		d      string
+		claims map[string]interface{}
+	}{
+		{d: "without the logout event", claims: map[string]interface{}{"events": nil}},
+		{d: "with a nonce", claims: map[string]interface{}{"nonce": "nonce"}},
+		{d: "without subject and session id", claims: map[string]interface{}{"sub": nil, "sid": nil}},
+		{d: "for a different audience", claims: map[string]interface{}{"aud": "other-client"}},
+		{d: "which is expired", claims: map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()}},
+		{d: "which is too old", claims: map[string]interface{}{"iat": time.Now().Add(-logoutTokenMaxAge * 2).Unix()}},
+	} {
+		t.Run("case=should reject a token "+tc.d, func(t *testing.T) {
+			_, _, err := p.VerifyLogoutToken(stub.stubContext(), logoutToken(tc.claims))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("case=should reject a token signed by someone else", func(t *testing.T) {
+		other := newProviderStub(t, "https://ory.sh")
+		_, _, err := p.VerifyLogoutToken(stub.stubContext(), other.signIDToken(map[string]interface{}{"aud": "client", "sub": "foo", "events": events}))
+		require.Error(t, err)
+	})
+}
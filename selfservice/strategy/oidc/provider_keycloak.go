@@ -0,0 +1,56 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+type ProviderKeycloak struct {
+	*ProviderGenericOIDC
+}
+
+func NewProviderKeycloak(
+	config *Configuration,
+	public *url.URL,
+) *ProviderKeycloak {
+	return &ProviderKeycloak{
+		ProviderGenericOIDC: &ProviderGenericOIDC{
+			config: config,
+			public: public,
+		},
+	}
+}
+
+// Claims returns the ID token's claims merged with the claims from the UserInfo endpoint, because Keycloak's
+// client scope mappers often add claims such as the email address to the UserInfo response only.
+func (k *ProviderKeycloak) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
+	claims, err := k.ProviderGenericOIDC.Claims(ctx, exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := k.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo, err := p.UserInfo(ctx, oauth2.StaticTokenSource(exchange))
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	if userInfo.Subject != claims.Subject {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The UserInfo subject %s does not match the ID token subject %s.", userInfo.Subject, claims.Subject))
+	}
+
+	if err := userInfo.Claims(claims); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+const (
+	linkedInAuthURL    = "https://www.linkedin.com/oauth/v2/authorization"
+	linkedInTokenURL   = "https://www.linkedin.com/oauth/v2/accessToken"
+	linkedInProfileURL = "https://api.linkedin.com/v2/me?projection=(id,localizedFirstName,localizedLastName,profilePicture(displayImage~:playableStreams))"
+	linkedInEmailURL   = "https://api.linkedin.com/v2/emailAddress?q=members&projection=(elements*(handle~))"
+)
+
+type ProviderLinkedIn struct {
+	config *Configuration
+	public *url.URL
+}
+
+func NewProviderLinkedIn(
+	config *Configuration,
+	public *url.URL,
+) *ProviderLinkedIn {
+	return &ProviderLinkedIn{
+		config: config,
+		public: public,
+	}
+}
+
+func (l *ProviderLinkedIn) Config() *Configuration {
+	return l.config
+}
+
+func (l *ProviderLinkedIn) oauth2() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     l.config.ClientID,
+		ClientSecret: l.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   linkedInAuthURL,
+			TokenURL:  linkedInTokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: l.config.Redir(l.public),
+		Scopes:      l.config.Scope,
+	}
+}
+
+func (l *ProviderLinkedIn) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return l.oauth2(), nil
+}
+
+func (l *ProviderLinkedIn) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{}
+}
+
+func (l *ProviderLinkedIn) fetch(client *http.Client, u string, v interface{}) error {
+	res, err := client.Get(u)
+	if err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("LinkedIn responded with status code %d to %s", res.StatusCode, u))
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	return nil
+}
+
+func (l *ProviderLinkedIn) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
+	client := l.oauth2().Client(ctx, exchange)
+
+	var profile struct {
+		ID             string `json:"id"`
+		FirstName      string `json:"localizedFirstName"`
+		LastName       string `json:"localizedLastName"`
+		ProfilePicture struct {
+			DisplayImage struct {
+				Elements []struct {
+					Identifiers []struct {
+						Identifier string `json:"identifier"`
+					} `json:"identifiers"`
+				} `json:"elements"`
+			} `json:"displayImage~"`
+		} `json:"profilePicture"`
+	}
+	if err := l.fetch(client, linkedInProfileURL, &profile); err != nil {
+		return nil, err
+	}
+
+	var emails struct {
+		Elements []struct {
+			Handle struct {
+				EmailAddress string `json:"emailAddress"`
+			} `json:"handle~"`
+		} `json:"elements"`
+	}
+	if err := l.fetch(client, linkedInEmailURL, &emails); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{
+		Issuer:     "https://www.linkedin.com/oauth/v2",
+		Subject:    profile.ID,
+		Name:       strings.TrimSpace(profile.FirstName + " " + profile.LastName),
+		GivenName:  profile.FirstName,
+		FamilyName: profile.LastName,
+		LastName:   profile.LastName,
+	}
+
+	// The display images are ordered by size, the last one being the largest.
+	if images := profile.ProfilePicture.DisplayImage.Elements; len(images) > 0 {
+		if identifiers := images[len(images)-1].Identifiers; len(identifiers) > 0 {
+			claims.Picture = identifiers[0].Identifier
+		}
+	}
+
+	// LinkedIn only returns the member's primary email address, which members have to confirm.
+	if len(emails.Elements) > 0 {
+		claims.Email = emails.Elements[0].Handle.EmailAddress
+		claims.EmailVerified = len(claims.Email) > 0
+	}
+
+	return claims, nil
+}
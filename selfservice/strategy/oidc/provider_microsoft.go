@@ -51,6 +51,25 @@ func (m *ProviderMicrosoft) Claims(ctx context.Context, exchange *oauth2.Token)
 		return nil, errors.WithStack(ErrIDTokenMissing)
 	}
 
+	p, err := m.tenantProvider(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
+}
+
+func (m *ProviderMicrosoft) VerifyIDToken(ctx context.Context, raw, nonce string) (*Claims, error) {
+	p, err := m.tenantProvider(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.verifyIDTokenWithProvider(ctx, p, raw, nonce)
+}
+
+// tenantProvider returns the OpenID Connect Provider of the tenant which issued the ID token.
+func (m *ProviderMicrosoft) tenantProvider(ctx context.Context, raw string) (*gooidc.Provider, error) {
 	parser := new(jwt.Parser)
 	unverifiedClaims := microsoftUnverifiedClaims{}
 	if _, _, err := parser.ParseUnverified(raw, &unverifiedClaims); err != nil {
@@ -67,7 +86,7 @@ func (m *ProviderMicrosoft) Claims(ctx context.Context, exchange *oauth2.Token)
 		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to initialize OpenID Connect Provider: %s", err))
 	}
 
-	return m.verifyAndDecodeClaimsWithProvider(ctx, p, raw)
+	return p, nil
 }
 
 type microsoftUnverifiedClaims struct {
@@ -0,0 +1,119 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/stringslice"
+	"github.com/ory/x/stringsx"
+)
+
+const (
+	spotifyAuthURL    = "https://accounts.spotify.com/authorize"
+	spotifyTokenURL   = "https://accounts.spotify.com/api/token"
+	spotifyProfileURL = "https://api.spotify.com/v1/me"
+)
+
+type ProviderSpotify struct {
+	config *Configuration
+	public *url.URL
+}
+
+func NewProviderSpotify(
+	config *Configuration,
+	public *url.URL,
+) *ProviderSpotify {
+	return &ProviderSpotify{
+		config: config,
+		public: public,
+	}
+}
+
+func (s *ProviderSpotify) Config() *Configuration {
+	return s.config
+}
+
+func (s *ProviderSpotify) oauth2() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  spotifyAuthURL,
+			TokenURL: spotifyTokenURL,
+		},
+		RedirectURL: s.config.Redir(s.public),
+		Scopes:      s.config.Scope,
+	}
+}
+
+func (s *ProviderSpotify) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return s.oauth2(), nil
+}
+
+func (s *ProviderSpotify) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	if isForced(r) {
+		return []oauth2.AuthCodeOption{
+			oauth2.SetAuthURLParam("show_dialog", "true"),
+		}
+	}
+	return []oauth2.AuthCodeOption{}
+}
+
+func (s *ProviderSpotify) Claims(ctx context.Context, exchange *oauth2.Token) (*Claims, error) {
+	grantedScopes := stringsx.Splitx(fmt.Sprintf("%s", exchange.Extra("scope")), " ")
+	for _, check := range s.Config().Scope {
+		if !stringslice.Has(grantedScopes, check) {
+			return nil, errors.WithStack(ErrScopeMissing)
+		}
+	}
+
+	res, err := s.oauth2().Client(ctx, exchange).Get(spotifyProfileURL)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Spotify responded with status code %d", res.StatusCode))
+	}
+
+	var user struct {
+		ID           string `json:"id"`
+		DisplayName  string `json:"display_name"`
+		Email        string `json:"email"`
+		ExternalURLs struct {
+			Spotify string `json:"spotify"`
+		} `json:"external_urls"`
+		Images []struct {
+			URL string `json:"url"`
+		} `json:"images"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	claims := &Claims{
+		Issuer:            "https://accounts.spotify.com",
+		Subject:           user.ID,
+		Name:              user.DisplayName,
+		Nickname:          user.DisplayName,
+		PreferredUsername: user.ID,
+		Profile:           user.ExternalURLs.Spotify,
+		// Spotify does not verify email addresses.
+		Email:         user.Email,
+		EmailVerified: false,
+	}
+
+	if len(user.Images) > 0 {
+		claims.Picture = user.Images[0].URL
+	}
+
+	return claims, nil
+}
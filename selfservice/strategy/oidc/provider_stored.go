@@ -0,0 +1,133 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/x"
+)
+
+// StoredConfiguration is the configuration of an OpenID Connect Provider which is managed using the admin API and
+// stored in the database instead of the configuration file.
+type StoredConfiguration struct {
+	// ID is the row's unique ID.
+	ID uuid.UUID `json:"-" db:"id" faker:"-"`
+
+	// ProviderID is the provider's ID, which is used for example in the callback URL.
+	ProviderID string `json:"id" db:"provider_id"`
+
+	// Config is the JSON encoded Configuration. The client secret and the Apple private key are encrypted using
+	// the cipher secrets.
+	Config sqlxx.JSONRawMessage `json:"config" db:"config"`
+
+	// CreatedAt is a helper struct field for gobuffalo.pop.
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"updated_at" faker:"-" db:"updated_at"`
+}
+
+func (StoredConfiguration) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_oidc_providers")
+}
+
+// storeConfiguration encrypts the configuration's secrets and encodes it for storing it in the database.
+func (s *Strategy) storeConfiguration(ctx context.Context, c Configuration, into *StoredConfiguration) error {
+	var err error
+	if c.ClientSecret, err = s.d.Cipher().Encrypt(ctx, []byte(c.ClientSecret)); err != nil {
+		return err
+	}
+
+	if c.ApplePrivateKey, err = s.d.Cipher().Encrypt(ctx, []byte(c.ApplePrivateKey)); err != nil {
+		return err
+	}
+
+	config, err := json.Marshal(c)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if x.IsZeroUUID(into.ID) {
+		into.ID = x.NewUUID()
+	}
+	into.ProviderID = c.ID
+	into.Config = config
+	return nil
+}
+
+// loadConfiguration decodes a stored configuration and decrypts its secrets.
+func (s *Strategy) loadConfiguration(ctx context.Context, stored *StoredConfiguration) (*Configuration, error) {
+	var c Configuration
+	if err := json.Unmarshal(stored.Config, &c); err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode the stored OpenID Connect Provider configuration: %s", err))
+	}
+
+	secret, err := s.d.Cipher().Decrypt(ctx, c.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.d.Cipher().Decrypt(ctx, c.ApplePrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ID = stored.ProviderID
+	c.ClientSecret = string(secret)
+	c.ApplePrivateKey = string(key)
+	return &c, nil
+}
+
+// storedConfigurationsTTL is how long the stored providers are cached. Changes made using the admin API of this
+// instance take effect immediately, other instances pick them up once their cache expired.
+const storedConfigurationsTTL = 10 * time.Second
+
+// storedConfigurations returns the configurations of all providers which are stored in the database. They are
+// cached for storedConfigurationsTTL so that the providers are not loaded and decrypted on every request.
+//
+// Providers which can not be decoded or decrypted - for example because the cipher secrets were rotated - are
+// skipped and logged, so that they do not break all other providers.
+func (s *Strategy) storedConfigurations(ctx context.Context) ([]Configuration, error) {
+	s.storedLock.Lock()
+	defer s.storedLock.Unlock()
+
+	if s.stored != nil && time.Now().Before(s.storedExpiresAt) {
+		return s.stored, nil
+	}
+
+	stored, err := s.d.OIDCProviderPersister().ListOIDCProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]Configuration, 0, len(stored))
+	for k := range stored {
+		c, err := s.loadConfiguration(ctx, &stored[k])
+		if err != nil {
+			s.d.Logger().
+				WithError(err).
+				WithField("provider_id", stored[k].ProviderID).
+				Error("Unable to load the stored OpenID Connect Provider configuration, ignoring the provider.")
+			continue
+		}
+		configs = append(configs, *c)
+	}
+
+	s.stored, s.storedExpiresAt = configs, time.Now().Add(storedConfigurationsTTL)
+	return configs, nil
+}
+
+// forgetStoredConfigurations clears the cache of storedConfigurations after a stored provider was changed.
+func (s *Strategy) forgetStoredConfigurations() {
+	s.storedLock.Lock()
+	defer s.storedLock.Unlock()
+	s.stored = nil
+}
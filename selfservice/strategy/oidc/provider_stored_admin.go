@@ -0,0 +1,382 @@
+package oidc
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/jsonschema/v3"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteAdminProviders = "/oidc/providers"
+	RouteAdminProvider  = RouteAdminProviders + "/:id"
+
+	providerSchemaURL = "https://github.com/ory/kratos/driver/config/.schema/config.schema.json"
+)
+
+var (
+	providerSchema     *jsonschema.Schema
+	providerSchemaErr  error
+	providerSchemaOnce sync.Once
+)
+
+func (s *Strategy) registerAdminProviderRoutes(admin *x.RouterAdmin) {
+	admin.GET(RouteAdminProviders, strategy.IsDisabled(s.d, s.ID().String(), s.listProviders))
+	admin.POST(RouteAdminProviders, strategy.IsDisabled(s.d, s.ID().String(), s.createProvider))
+	admin.GET(RouteAdminProvider, strategy.IsDisabled(s.d, s.ID().String(), s.getProvider))
+	admin.PUT(RouteAdminProvider, strategy.IsDisabled(s.d, s.ID().String(), s.updateProvider))
+	admin.DELETE(RouteAdminProvider, strategy.IsDisabled(s.d, s.ID().String(), s.deleteProvider))
+}
+
+// validateProviderConfiguration validates the provider configuration against the same JSON Schema which is used
+// for providers in the configuration file.
+func validateProviderConfiguration(raw []byte) error {
+	providerSchemaOnce.Do(func() {
+		c := jsonschema.NewCompiler()
+		if err := c.AddResource(providerSchemaURL, bytes.NewReader(config.ValidationSchema)); err != nil {
+			providerSchemaErr = errors.WithStack(err)
+			return
+		}
+		providerSchema, providerSchemaErr = c.Compile(providerSchemaURL + "#/definitions/selfServiceOIDCProvider")
+	})
+	if providerSchemaErr != nil {
+		return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to compile the OpenID Connect Provider JSON Schema: %s", providerSchemaErr))
+	}
+
+	if err := providerSchema.Validate(bytes.NewReader(raw)); err != nil {
+		return errors.WithStack(herodot.ErrBadRequest.WithReasonf("The OpenID Connect Provider configuration is invalid: %s", err))
+	}
+	return nil
+}
+
+// redact removes the secrets which are never returned by the admin API.
+func (p Configuration) redact() Configuration {
+	p.ClientSecret = ""
+	p.ApplePrivateKey = ""
+	return p
+}
+
+// swagger:parameters getOIDCProvider deleteOIDCProvider
+// nolint:deadcode,unused
+type getOIDCProviderParameters struct {
+	// ID is the provider's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:parameters createOIDCProvider
+// nolint:deadcode,unused
+type createOIDCProviderParameters struct {
+	// in: body
+	Body Configuration
+}
+
+// swagger:parameters updateOIDCProvider
+// nolint:deadcode,unused
+type updateOIDCProviderParameters struct {
+	// ID is the provider's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+
+	// in: body
+	Body Configuration
+}
+
+// A single OpenID Connect Provider.
+//
+// swagger:response oidcProvider
+// nolint:deadcode,unused
+type oidcProviderResponse struct {
+	// in: body
+	// required: true
+	Body Configuration
+}
+
+// A list of OpenID Connect Providers.
+//
+// swagger:response oidcProviders
+// nolint:deadcode,unused
+type oidcProvidersResponse struct {
+	// in: body
+	// required: true
+	Body []Configuration
+}
+
+// swagger:route GET /oidc/providers admin listOIDCProviders
+//
+// List the OpenID Connect Providers Stored in the Database
+//
+// This endpoint lists the OpenID Connect Providers which are managed using the admin API. Providers from the
+// configuration file are not included. Secrets are never returned.
+//
+// Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: oidcProviders
+//       500: genericError
+func (s *Strategy) listProviders(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	stored, err := s.storedConfigurations(r.Context())
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	providers := make([]Configuration, len(stored))
+	for k, c := range stored {
+		providers[k] = c.redact()
+	}
+
+	s.d.Writer().Write(w, r, providers)
+}
+
+// swagger:route GET /oidc/providers/{id} admin getOIDCProvider
+//
+// Get an OpenID Connect Provider Stored in the Database
+//
+// Secrets are never returned.
+//
+// Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: oidcProvider
+//       404: genericError
+//       500: genericError
+func (s *Strategy) getProvider(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	stored, err := s.d.OIDCProviderPersister().GetOIDCProvider(r.Context(), ps.ByName("id"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	c, err := s.loadConfiguration(r.Context(), stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	s.d.Writer().Write(w, r, c.redact())
+}
+
+// swagger:route POST /oidc/providers admin createOIDCProvider
+//
+// Create an OpenID Connect Provider
+//
+// This endpoint stores an OpenID Connect Provider in the database. The provider can be used for signing in
+// immediately, without restarting ORY Kratos. The client secret and the Apple private key are stored encrypted.
+//
+// The provider's ID must not be used by a provider in the configuration file.
+//
+// Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       201: oidcProvider
+//       400: genericError
+//       409: genericError
+//       500: genericError
+func (s *Strategy) createProvider(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to read the request body: %s", err)))
+		return
+	}
+
+	c, err := decodeProviderConfiguration(raw)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if static, err := s.fileConfig(r.Context()); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	} else if static.has(c.ID) {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrConflict.WithReasonf(`OpenID Connect Provider "%s" is defined in the configuration file.`, c.ID)))
+		return
+	}
+
+	var stored StoredConfiguration
+	if err := s.storeConfiguration(r.Context(), *c, &stored); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.d.OIDCProviderPersister().CreateOIDCProvider(r.Context(), &stored); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.forgetStoredConfigurations()
+
+	s.d.Audit().
+		WithField("provider", c.ID).
+		Info("An OpenID Connect Provider has been created.")
+
+	s.d.Writer().WriteCreated(w, r,
+		urlx.AppendPaths(
+			s.d.Config(r.Context()).SelfAdminURL(),
+			RouteAdminProviders,
+			c.ID,
+		).String(),
+		c.redact(),
+	)
+}
+
+// swagger:route PUT /oidc/providers/{id} admin updateOIDCProvider
+//
+// Update an OpenID Connect Provider Stored in the Database
+//
+// This endpoint replaces the configuration of an OpenID Connect Provider which is stored in the database. Changes
+// take effect immediately. If the client secret or the Apple private key are omitted, the stored ones are kept.
+//
+// Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: oidcProvider
+//       400: genericError
+//       404: genericError
+//       500: genericError
+func (s *Strategy) updateProvider(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	stored, err := s.d.OIDCProviderPersister().GetOIDCProvider(r.Context(), ps.ByName("id"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	existing, err := s.loadConfiguration(r.Context(), stored)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to read the request body: %s", err)))
+		return
+	}
+
+	// The secrets are never returned by the admin API which is why they are kept if they are omitted.
+	for path, value := range map[string]string{
+		"id":                stored.ProviderID,
+		"client_secret":     existing.ClientSecret,
+		"apple_private_key": existing.ApplePrivateKey,
+	} {
+		if len(value) > 0 && !gjson.GetBytes(raw, path).Exists() {
+			if raw, err = sjson.SetBytes(raw, path, value); err != nil {
+				s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("%s", err)))
+				return
+			}
+		}
+	}
+
+	c, err := decodeProviderConfiguration(raw)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	} else if c.ID != stored.ProviderID {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The OpenID Connect Provider's ID can not be changed.")))
+		return
+	}
+
+	if err := s.storeConfiguration(r.Context(), *c, stored); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.d.OIDCProviderPersister().UpdateOIDCProvider(r.Context(), stored); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.forgetStoredConfigurations()
+
+	s.d.Audit().
+		WithField("provider", c.ID).
+		Info("An OpenID Connect Provider has been updated.")
+
+	s.d.Writer().Write(w, r, c.redact())
+}
+
+// swagger:route DELETE /oidc/providers/{id} admin deleteOIDCProvider
+//
+// Delete an OpenID Connect Provider Stored in the Database
+//
+// Identities which signed up with the provider keep their OpenID Connect credentials but can no longer sign in
+// with the provider.
+//
+// Learn how to configure OpenID Connect Providers in [ORY Kratos Social Sign In Documentation](https://www.ory.sh/docs/next/kratos/concepts/credentials/openid-connect-oidc-oauth2).
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       204: emptyResponse
+//       404: genericError
+//       500: genericError
+func (s *Strategy) deleteProvider(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if err := s.d.OIDCProviderPersister().DeleteOIDCProvider(r.Context(), ps.ByName("id")); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.forgetStoredConfigurations()
+
+	s.d.Audit().
+		WithField("provider", ps.ByName("id")).
+		Info("An OpenID Connect Provider has been deleted.")
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeProviderConfiguration(raw []byte) (*Configuration, error) {
+	if err := validateProviderConfiguration(raw); err != nil {
+		return nil, err
+	}
+
+	var c Configuration
+	if err := jsonx.NewStrictDecoder(bytes.NewReader(raw)).Decode(&c); err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode the OpenID Connect Provider configuration: %s", err))
+	} else if len(c.ID) == 0 {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The OpenID Connect Provider's ID must not be empty."))
+	}
+	return &c, nil
+}
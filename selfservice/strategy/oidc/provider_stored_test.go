@@ -0,0 +1,251 @@
+package oidc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestStoredProviders(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	issuer, _, authorize := newIDTokenIssuer(t)
+
+	viperSetProviderConfig(
+		t,
+		conf,
+		oidc.Configuration{
+			Provider:     "generic",
+			ID:           "static",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			IssuerURL:    issuer.URL,
+			Mapper:       "file://./stub/oidc.hydra.jsonnet",
+		},
+	)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/registration.schema.json")
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeOIDC.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	_ = testhelpers.NewUIFlowEchoServer(t, reg)
+	returnTS := newReturnTs(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	var do = func(t *testing.T, method, path string, body interface{}, expectedStatusCode int) gjson.Result {
+		var b bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&b).Encode(body))
+		}
+
+		req, err := http.NewRequest(method, adminTS.URL+path, &b)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		raw, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectedStatusCode, res.StatusCode, "%s", raw)
+		return gjson.ParseBytes(raw)
+	}
+
+	var providerPath = func(id string) string {
+		return strings.Replace(oidc.RouteAdminProvider, ":id", id, 1)
+	}
+
+	var signIn = func(t *testing.T, provider, subject string) *http.Response {
+		authorize(jwt.MapClaims{"sub": subject})
+		client := newClient(t, nil)
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false).Payload
+
+		res, err := client.PostForm(publicTS.URL+oidc.RouteBase+"/auth/"+string(*f.ID), url.Values{"provider": {provider}})
+		require.NoError(t, err)
+		return res
+	}
+
+	stored := map[string]interface{}{
+		"id":            "stored",
+		"provider":      "generic",
+		"client_id":     "client",
+		"client_secret": "stored-secret",
+		"issuer_url":    issuer.URL,
+		"mapper_url":    "file://./stub/oidc.hydra.jsonnet",
+	}
+
+	t.Run("case=should not sign in with a provider which is not stored yet", func(t *testing.T) {
+		res := signIn(t, "stored", x.NewUUID().String()+"@ory.sh")
+		defer res.Body.Close()
+		assert.NotContains(t, res.Request.URL.String(), returnTS.URL)
+	})
+
+	t.Run("case=should create the provider", func(t *testing.T) {
+		actual := do(t, "POST", oidc.RouteAdminProviders, stored, http.StatusCreated)
+		assert.Equal(t, "stored", actual.Get("id").String())
+		assert.Equal(t, issuer.URL, actual.Get("issuer_url").String())
+		assert.False(t, actual.Get("client_secret").Exists(), "%s", actual.Raw)
+	})
+
+	t.Run("case=should store the secrets encrypted", func(t *testing.T) {
+		actual, err := reg.OIDCProviderPersister().GetOIDCProvider(ctx, "stored")
+		require.NoError(t, err)
+		assert.NotContains(t, string(actual.Config), "stored-secret")
+		assert.NotEmpty(t, gjson.GetBytes(actual.Config, "client_secret").String())
+	})
+
+	t.Run("case=should sign in with the stored provider without restarting", func(t *testing.T) {
+		res := signIn(t, "stored", x.NewUUID().String()+"@ory.sh")
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+	})
+
+	t.Run("case=should sign in with the static provider", func(t *testing.T) {
+		res := signIn(t, "static", x.NewUUID().String()+"@ory.sh")
+		defer res.Body.Close()
+		assert.Contains(t, res.Request.URL.String(), returnTS.URL)
+	})
+
+	t.Run("case=should get and list the provider", func(t *testing.T) {
+		actual := do(t, "GET", providerPath("stored"), nil, http.StatusOK)
+		assert.Equal(t, "stored", actual.Get("id").String())
+		assert.False(t, actual.Get("client_secret").Exists(), "%s", actual.Raw)
+
+		list := do(t, "GET", oidc.RouteAdminProviders, nil, http.StatusOK)
+		require.Len(t, list.Array(), 1, "%s", list.Raw)
+		assert.Equal(t, "stored", list.Get("0.id").String())
+
+		do(t, "GET", providerPath("static"), nil, http.StatusNotFound)
+	})
+
+	t.Run("case=should cache the stored providers", func(t *testing.T) {
+		cached := map[string]interface{}{}
+		for k, v := range stored {
+			cached[k] = v
+		}
+		cached["id"] = "cached"
+		do(t, "POST", oidc.RouteAdminProviders, cached, http.StatusCreated)
+
+		res := signIn(t, "cached", x.NewUUID().String()+"@ory.sh")
+		defer res.Body.Close()
+		assert.Contains(t, res.Request.URL.String(), returnTS.URL)
+
+		// Changes which are not made using the admin API take effect once the cache expired.
+		require.NoError(t, reg.OIDCProviderPersister().DeleteOIDCProvider(ctx, "cached"))
+
+		res = signIn(t, "cached", x.NewUUID().String()+"@ory.sh")
+		defer res.Body.Close()
+		assert.Contains(t, res.Request.URL.String(), returnTS.URL)
+	})
+
+	t.Run("case=should ignore stored providers which can not be loaded", func(t *testing.T) {
+		for id, raw := range map[string]string{
+			"broken-json":   `"not an object"`,
+			"broken-secret": `{"provider":"generic","client_id":"client","client_secret":"not-encrypted","issuer_url":"` + issuer.URL + `"}`,
+		} {
+			id := id
+			require.NoError(t, reg.OIDCProviderPersister().CreateOIDCProvider(ctx, &oidc.StoredConfiguration{ProviderID: id, Config: sqlxx.JSONRawMessage(raw)}))
+			t.Cleanup(func() {
+				require.NoError(t, reg.OIDCProviderPersister().DeleteOIDCProvider(ctx, id))
+			})
+		}
+
+		// Updating a provider using the admin API clears the cache, which loads the broken providers.
+		do(t, "PUT", providerPath("stored"), stored, http.StatusOK)
+
+		list := do(t, "GET", oidc.RouteAdminProviders, nil, http.StatusOK)
+		require.Len(t, list.Array(), 1, "%s", list.Raw)
+		assert.Equal(t, "stored", list.Get("0.id").String())
+
+		for _, provider := range []string{"stored", "static"} {
+			res := signIn(t, provider, x.NewUUID().String()+"@ory.sh")
+			defer res.Body.Close()
+			assert.Contains(t, res.Request.URL.String(), returnTS.URL, "provider %s", provider)
+		}
+	})
+
+	t.Run("case=should reject invalid providers", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			body     map[string]interface{}
+			expected int
+		}{
+			"duplicate":       {body: stored, expected: http.StatusConflict},
+			"static":          {body: map[string]interface{}{"id": "static", "provider": "generic", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet"}, expected: http.StatusConflict},
+			"unknown type":    {body: map[string]interface{}{"id": "other", "provider": "unknown", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet"}, expected: http.StatusBadRequest},
+			"missing secret":  {body: map[string]interface{}{"id": "other", "provider": "generic", "client_id": "client", "mapper_url": "file://./stub/oidc.hydra.jsonnet"}, expected: http.StatusBadRequest},
+			"unknown field":   {body: map[string]interface{}{"id": "other", "provider": "generic", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet", "foo": "bar"}, expected: http.StatusBadRequest},
+			"missing mapper":  {body: map[string]interface{}{"id": "other", "provider": "generic", "client_id": "client", "client_secret": "secret"}, expected: http.StatusBadRequest},
+			"empty id":        {body: map[string]interface{}{"id": "", "provider": "generic", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet"}, expected: http.StatusBadRequest},
+			"missing tenant":  {body: map[string]interface{}{"id": "other", "provider": "microsoft", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet"}, expected: http.StatusBadRequest},
+			"invalid scope":   {body: map[string]interface{}{"id": "other", "provider": "generic", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet", "scope": "openid"}, expected: http.StatusBadRequest},
+			"missing issuer":  {body: map[string]interface{}{"id": "other", "provider": "keycloak", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet"}, expected: http.StatusBadRequest},
+			"invalid link":    {body: map[string]interface{}{"id": "other", "provider": "generic", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet", "link_policy": "always"}, expected: http.StatusBadRequest},
+			"invalid boolean": {body: map[string]interface{}{"id": "other", "provider": "generic", "client_id": "client", "client_secret": "secret", "mapper_url": "file://./stub/oidc.hydra.jsonnet", "pkce": "yes"}, expected: http.StatusBadRequest},
+		} {
+			t.Run("case="+name, func(t *testing.T) {
+				do(t, "POST", oidc.RouteAdminProviders, tc.body, tc.expected)
+			})
+		}
+	})
+
+	t.Run("case=should update the provider and keep the secret", func(t *testing.T) {
+		actual := do(t, "PUT", providerPath("stored"), map[string]interface{}{
+			"provider":   "generic",
+			"client_id":  "client",
+			"issuer_url": issuer.URL,
+			"mapper_url": "file://./stub/oidc.hydra.jsonnet",
+			"scope":      []string{"openid", "email"},
+		}, http.StatusOK)
+		assert.Equal(t, "stored", actual.Get("id").String())
+		assert.Equal(t, `["openid","email"]`, actual.Get("scope").Raw)
+
+		c, err := reg.OIDCProviderPersister().GetOIDCProvider(ctx, "stored")
+		require.NoError(t, err)
+		decrypted, err := reg.Cipher().Decrypt(ctx, gjson.GetBytes(c.Config, "client_secret").String())
+		require.NoError(t, err)
+		assert.Equal(t, "stored-secret", string(decrypted))
+	})
+
+	t.Run("case=should not change the provider's id", func(t *testing.T) {
+		body := map[string]interface{}{}
+		for k, v := range stored {
+			body[k] = v
+		}
+		body["id"] = "renamed"
+		do(t, "PUT", providerPath("stored"), body, http.StatusBadRequest)
+		do(t, "PUT", providerPath("unknown"), stored, http.StatusNotFound)
+	})
+
+	t.Run("case=should delete the provider", func(t *testing.T) {
+		do(t, "DELETE", providerPath("stored"), nil, http.StatusNoContent)
+		do(t, "DELETE", providerPath("stored"), nil, http.StatusNotFound)
+		do(t, "GET", providerPath("stored"), nil, http.StatusNotFound)
+
+		res := signIn(t, "stored", x.NewUUID().String()+"@ory.sh")
+		defer res.Body.Close()
+		assert.NotContains(t, res.Request.URL.String(), returnTS.URL)
+	})
+}
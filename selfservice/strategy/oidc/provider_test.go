@@ -0,0 +1,488 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/x"
+)
+
+// providerStub is an OpenID Connect Provider and API which answers all requests of a provider. Requests to the
+// provider's real hosts are sent to the stub using the HTTP client from stubContext.
+type providerStub struct {
+	*httptest.Server
+	t      *testing.T
+	issuer string
+	key    *rsa.PrivateKey
+	api    map[string]interface{}
+
+	sync.Mutex
+	idToken  map[string]interface{}
+	userInfo map[string]interface{}
+	scope    string
+	// tokenRequest is the last request to the token endpoint.
+	tokenRequest *http.Request
+}
+
+func newProviderStub(t *testing.T, issuer string) *providerStub {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := &providerStub{t: t, issuer: issuer, key: key, api: map[string]interface{}{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *providerStub) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(s.t, json.NewEncoder(w).Encode(v))
+}
+
+func (s *providerStub) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/.well-known/openid-configuration"):
+		s.writeJSON(w, map[string]interface{}{
+			"issuer":                                s.issuer,
+			"authorization_endpoint":                s.issuer + "/authorize",
+			"token_endpoint":                        s.issuer + "/token",
+			"userinfo_endpoint":                     s.issuer + "/userinfo",
+			"jwks_uri":                              s.issuer + "/jwks",
+			"end_session_endpoint":                  s.issuer + "/logout?foo=bar",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	case strings.HasSuffix(r.URL.Path, "/jwks"):
+		s.writeJSON(w, map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "stub",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}}})
+	case strings.HasSuffix(r.URL.Path, "/token"), strings.HasSuffix(r.URL.Path, "/accessToken"):
+		require.NoError(s.t, r.ParseForm())
+		s.tokenRequest = r
+		response := map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "bearer",
+			"expires_in":   3600,
+			"scope":        s.scope,
+		}
+		if s.idToken != nil {
+			response["id_token"] = s.signIDToken(s.idToken)
+		}
+		s.writeJSON(w, response)
+	case strings.HasSuffix(r.URL.Path, "/userinfo"):
+		s.writeJSON(w, s.userInfo)
+	default:
+		if v, ok := s.api[r.URL.Path]; ok && r.Header.Get("Authorization") == "Bearer access-token" {
+			s.writeJSON(w, v)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *providerStub) signIDToken(claims map[string]interface{}) string {
+	c := jwt.MapClaims{
+		"iss": s.issuer,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(c, k)
+			continue
+		}
+		c[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	token.Header["kid"] = "stub"
+	raw, err := token.SignedString(s.key)
+	require.NoError(s.t, err)
+	return raw
+}
+
+func (s *providerStub) setIDToken(claims map[string]interface{}) {
+	s.Lock()
+	defer s.Unlock()
+	s.idToken = claims
+}
+
+func (s *providerStub) lastTokenRequest() *http.Request {
+	s.Lock()
+	defer s.Unlock()
+	return s.tokenRequest
+}
+
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = ""
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// stubContext returns a context whose HTTP client sends all requests to the stub.
+func (s *providerStub) stubContext() context.Context {
+	target, err := url.Parse(s.URL)
+	require.NoError(s.t, err)
+	return context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: &rewriteTransport{target: target}})
+}
+
+func exchange(t *testing.T, ctx context.Context, p Provider) (*oauth2.Token, *Claims) {
+	c, err := p.OAuth2(ctx)
+	require.NoError(t, err)
+
+	token, err := c.Exchange(ctx, "code")
+	require.NoError(t, err)
+
+	claims, err := p.Claims(ctx, token)
+	require.NoError(t, err)
+	return token, claims
+}
+
+func newPublicURL(t *testing.T) *url.URL {
+	public, err := url.Parse("https://kratos.test")
+	require.NoError(t, err)
+	return public
+}
+
+func TestProviderApple(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	stub := newProviderStub(t, "https://appleid.apple.com")
+	ctx := stub.stubContext()
+
+	var newProvider = func(privateKey string) *ProviderApple {
+		return NewProviderApple(&Configuration{
+			ID:                "apple",
+			Provider:          "apple",
+			ClientID:          "com.example.app",
+			AppleTeamID:       "TEAMID",
+			ApplePrivateKeyID: "KEYID",
+			ApplePrivateKey:   privateKey,
+		}, newPublicURL(t))
+	}
+	p := newProvider(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+
+	t.Run("case=should send a signed client secret", func(t *testing.T) {
+		stub.setIDToken(map[string]interface{}{"sub": "apple-user", "aud": "com.example.app"})
+		exchange(t, ctx, p)
+
+		form := stub.lastTokenRequest().PostForm
+		assert.Equal(t, "com.example.app", form.Get("client_id"))
+
+		var claims jwt.StandardClaims
+		token, err := jwt.ParseWithClaims(form.Get("client_secret"), &claims, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+				return nil, errors.Errorf("unexpected signing method %s", token.Method.Alg())
+			}
+			return &key.PublicKey, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "KEYID", token.Header["kid"])
+		assert.Equal(t, "TEAMID", claims.Issuer)
+		assert.Equal(t, "com.example.app", claims.Subject)
+		assert.Equal(t, "https://appleid.apple.com", claims.Audience)
+		assert.True(t, claims.ExpiresAt > time.Now().Unix())
+	})
+
+	t.Run("case=should fail with an invalid private key", func(t *testing.T) {
+		_, err := newProvider("not a key").OAuth2(ctx)
+		require.Error(t, err)
+		assert.Contains(t, errors.Cause(err).(*herodot.DefaultError).Reason(), "Unable to parse the Apple private key")
+	})
+
+	t.Run("case=should request a posted response", func(t *testing.T) {
+		c, err := p.OAuth2(ctx)
+		require.NoError(t, err)
+		u, err := url.Parse(c.AuthCodeURL("state", p.AuthCodeURLOptions(&login.Flow{ID: x.NewUUID()})...))
+		require.NoError(t, err)
+		assert.Equal(t, "form_post", u.Query().Get("response_mode"))
+	})
+
+	t.Run("case=should decode email_verified encoded as a string", func(t *testing.T) {
+		stub.setIDToken(map[string]interface{}{
+			"sub":              "apple-user",
+			"aud":              "com.example.app",
+			"email":            "foo@privaterelay.appleid.com",
+			"email_verified":   "true",
+			"is_private_email": "true",
+		})
+		_, claims := exchange(t, ctx, p)
+		assert.Equal(t, "apple-user", claims.Subject)
+		assert.Equal(t, "foo@privaterelay.appleid.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+	})
+
+	t.Run("case=should verify a native ID token", func(t *testing.T) {
+		claims, err := p.VerifyIDToken(ctx, stub.signIDToken(map[string]interface{}{
+			"sub":            "apple-user",
+			"aud":            "com.example.app",
+			"nonce":          "nonce",
+			"email":          "foo@example.org",
+			"email_verified": false,
+		}), "nonce")
+		require.NoError(t, err)
+		assert.Equal(t, "foo@example.org", claims.Email)
+		assert.False(t, claims.EmailVerified)
+	})
+
+	t.Run("case=should decode the name from the callback", func(t *testing.T) {
+		claims := &Claims{Subject: "apple-user"}
+		p.DecodeCallbackClaims(url.Values{"user": {`{"name":{"firstName":"Jane","lastName":"Doe"},"email":"foo@example.org"}`}}, claims)
+		assert.Equal(t, "Jane", claims.GivenName)
+		assert.Equal(t, "Doe", claims.FamilyName)
+		assert.Equal(t, "Jane Doe", claims.Name)
+		assert.Empty(t, claims.Email)
+
+		claims = &Claims{Subject: "apple-user"}
+		p.DecodeCallbackClaims(url.Values{}, claims)
+		assert.Empty(t, claims.Name)
+	})
+}
+
+func TestProviderAuth0(t *testing.T) {
+	stub := newProviderStub(t, "https://example.eu.auth0.com/")
+	ctx := stub.stubContext()
+
+	p := NewProviderAuth0(&Configuration{
+		ID:           "auth0",
+		Provider:     "auth0",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		IssuerURL:    "https://example.eu.auth0.com",
+	}, newPublicURL(t))
+	assert.Equal(t, "https://example.eu.auth0.com/", p.Config().IssuerURL)
+
+	updatedAt := time.Date(2021, 4, 1, 10, 0, 0, 0, time.UTC)
+	t.Run("case=should decode updated_at encoded as a timestamp", func(t *testing.T) {
+		stub.setIDToken(map[string]interface{}{
+			"sub":            "auth0|1234",
+			"aud":            "client",
+			"email":          "foo@example.org",
+			"email_verified": true,
+			"updated_at":     updatedAt.Format(time.RFC3339),
+		})
+		_, claims := exchange(t, ctx, p)
+		assert.Equal(t, "auth0|1234", claims.Subject)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, updatedAt.Unix(), claims.UpdatedAt)
+	})
+
+	t.Run("case=should verify a native ID token", func(t *testing.T) {
+		claims, err := p.VerifyIDToken(ctx, stub.signIDToken(map[string]interface{}{
+			"sub":        "auth0|1234",
+			"aud":        "client",
+			"nonce":      "nonce",
+			"updated_at": updatedAt.Format(time.RFC3339),
+		}), "nonce")
+		require.NoError(t, err)
+		assert.Equal(t, updatedAt.Unix(), claims.UpdatedAt)
+	})
+}
+
+func TestProviderKeycloak(t *testing.T) {
+	stub := newProviderStub(t, "https://keycloak.example.org/auth/realms/example")
+	ctx := stub.stubContext()
+
+	p := NewProviderKeycloak(&Configuration{
+		ID:           "keycloak",
+		Provider:     "keycloak",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		IssuerURL:    "https://keycloak.example.org/auth/realms/example",
+	}, newPublicURL(t))
+	stub.setIDToken(map[string]interface{}{"sub": "keycloak-user", "aud": "client", "preferred_username": "foo"})
+
+	t.Run("case=should merge the UserInfo claims", func(t *testing.T) {
+		stub.Lock()
+		stub.userInfo = map[string]interface{}{"sub": "keycloak-user", "email": "foo@example.org", "email_verified": true}
+		stub.Unlock()
+
+		_, claims := exchange(t, ctx, p)
+		assert.Equal(t, "keycloak-user", claims.Subject)
+		assert.Equal(t, "foo", claims.PreferredUsername)
+		assert.Equal(t, "foo@example.org", claims.Email)
+		assert.True(t, claims.EmailVerified)
+	})
+
+	t.Run("case=should fail if the UserInfo subject does not match", func(t *testing.T) {
+		stub.Lock()
+		stub.userInfo = map[string]interface{}{"sub": "someone-else", "email": "bar@example.org"}
+		stub.Unlock()
+
+		c, err := p.OAuth2(ctx)
+		require.NoError(t, err)
+		token, err := c.Exchange(ctx, "code")
+		require.NoError(t, err)
+
+		_, err = p.Claims(ctx, token)
+		require.Error(t, err)
+	})
+}
+
+func TestProviderTwitch(t *testing.T) {
+	stub := newProviderStub(t, "https://id.twitch.tv/oauth2")
+	ctx := stub.stubContext()
+
+	p := NewProviderTwitch(&Configuration{
+		ID:           "twitch",
+		Provider:     "twitch",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scope:        []string{"user:read:email"},
+	}, newPublicURL(t))
+
+	t.Run("case=should request the email and profile claims", func(t *testing.T) {
+		c, err := p.OAuth2(ctx)
+		require.NoError(t, err)
+		u, err := url.Parse(c.AuthCodeURL("state", p.AuthCodeURLOptions(&login.Flow{ID: x.NewUUID()})...))
+		require.NoError(t, err)
+		assert.Equal(t, string(twitchRequestedClaims), u.Query().Get("claims"))
+	})
+
+	t.Run("case=should send the client credentials in the request body", func(t *testing.T) {
+		stub.setIDToken(map[string]interface{}{
+			"sub":                "1234",
+			"aud":                "client",
+			"email":              "foo@example.org",
+			"email_verified":     true,
+			"preferred_username": "foo",
+			"picture":            "https://static-cdn.jtvnw.net/foo.png",
+		})
+		_, claims := exchange(t, ctx, p)
+		assert.Equal(t, "1234", claims.Subject)
+		assert.Equal(t, "foo", claims.PreferredUsername)
+		assert.Equal(t, "https://static-cdn.jtvnw.net/foo.png", claims.Picture)
+		assert.True(t, claims.EmailVerified)
+
+		req := stub.lastTokenRequest()
+		assert.Equal(t, "secret", req.PostForm.Get("client_secret"))
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+}
+
+func TestProviderLinkedIn(t *testing.T) {
+	stub := newProviderStub(t, "https://www.linkedin.com/oauth/v2")
+	ctx := stub.stubContext()
+	stub.api["/v2/me"] = map[string]interface{}{
+		"id":                 "linkedin-user",
+		"localizedFirstName": "Jane",
+		"localizedLastName":  "Doe",
+		"profilePicture": map[string]interface{}{
+			"displayImage~": map[string]interface{}{
+				"elements": []interface{}{
+					map[string]interface{}{"identifiers": []interface{}{map[string]string{"identifier": "https://media.licdn.com/100.jpg"}}},
+					map[string]interface{}{"identifiers": []interface{}{map[string]string{"identifier": "https://media.licdn.com/800.jpg"}}},
+				},
+			},
+		},
+	}
+	stub.api["/v2/emailAddress"] = map[string]interface{}{
+		"elements": []interface{}{map[string]interface{}{
+			"handle":  "urn:li:emailAddress:1234",
+			"handle~": map[string]string{"emailAddress": "jane@example.org"},
+		}},
+	}
+
+	p := NewProviderLinkedIn(&Configuration{
+		ID:           "linkedin",
+		Provider:     "linkedin",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scope:        []string{"r_liteprofile", "r_emailaddress"},
+	}, newPublicURL(t))
+
+	_, claims := exchange(t, ctx, p)
+	assert.Equal(t, "linkedin-user", claims.Subject)
+	assert.Equal(t, "Jane Doe", claims.Name)
+	assert.Equal(t, "Jane", claims.GivenName)
+	assert.Equal(t, "Doe", claims.FamilyName)
+	assert.Equal(t, "https://media.licdn.com/800.jpg", claims.Picture)
+	assert.Equal(t, "jane@example.org", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "secret", stub.lastTokenRequest().PostForm.Get("client_secret"))
+}
+
+func TestProviderSpotify(t *testing.T) {
+	stub := newProviderStub(t, "https://accounts.spotify.com")
+	ctx := stub.stubContext()
+	stub.api["/v1/me"] = map[string]interface{}{
+		"id":            "spotify-user",
+		"display_name":  "Jane",
+		"email":         "jane@example.org",
+		"external_urls": map[string]string{"spotify": "https://open.spotify.com/user/spotify-user"},
+		"images":        []interface{}{map[string]string{"url": "https://i.scdn.co/image/jane"}},
+	}
+
+	p := NewProviderSpotify(&Configuration{
+		ID:           "spotify",
+		Provider:     "spotify",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scope:        []string{"user-read-email"},
+	}, newPublicURL(t))
+
+	t.Run("case=should fail if a scope was not granted", func(t *testing.T) {
+		c, err := p.OAuth2(ctx)
+		require.NoError(t, err)
+		token, err := c.Exchange(ctx, "code")
+		require.NoError(t, err)
+
+		_, err = p.Claims(ctx, token)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrScopeMissing))
+	})
+
+	t.Run("case=should return the profile", func(t *testing.T) {
+		stub.Lock()
+		stub.scope = "user-read-email"
+		stub.Unlock()
+
+		_, claims := exchange(t, ctx, p)
+		assert.Equal(t, "spotify-user", claims.Subject)
+		assert.Equal(t, "Jane", claims.Name)
+		assert.Equal(t, "https://open.spotify.com/user/spotify-user", claims.Profile)
+		assert.Equal(t, "https://i.scdn.co/image/jane", claims.Picture)
+		assert.Equal(t, "jane@example.org", claims.Email)
+		assert.False(t, claims.EmailVerified)
+	})
+}
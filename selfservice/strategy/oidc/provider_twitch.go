@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// twitchRequestedClaims are requested if no claims are configured because Twitch only includes the email address
+// and profile in the ID token if they are requested explicitly.
+var twitchRequestedClaims = json.RawMessage(`{"id_token":{"email":null,"email_verified":null,"picture":null,"preferred_username":null}}`)
+
+type ProviderTwitch struct {
+	*ProviderGenericOIDC
+}
+
+func NewProviderTwitch(
+	config *Configuration,
+	public *url.URL,
+) *ProviderTwitch {
+	config.IssuerURL = "https://id.twitch.tv/oauth2"
+	if len(config.RequestedClaims) == 0 {
+		config.RequestedClaims = twitchRequestedClaims
+	}
+
+	return &ProviderTwitch{
+		ProviderGenericOIDC: &ProviderGenericOIDC{
+			config: config,
+			public: public,
+		},
+	}
+}
+
+func (t *ProviderTwitch) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	p, err := t.provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Twitch expects the client credentials in the request body.
+	endpoint := p.Endpoint()
+	endpoint.AuthStyle = oauth2.AuthStyleInParams
+
+	return t.oauth2ConfigFromEndpoint(endpoint), nil
+}
@@ -3,25 +3,32 @@ package oidc
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
 
 	"github.com/ory/x/jsonx"
+	"github.com/ory/x/randx"
 
 	"github.com/ory/x/fetcher"
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/cipher"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
@@ -40,8 +47,9 @@ import (
 const (
 	RouteBase = "/self-service/methods/oidc"
 
-	RouteAuth     = RouteBase + "/auth/:flow"
-	RouteCallback = RouteBase + "/callback/:provider"
+	RouteAuth              = RouteBase + "/auth/:flow"
+	RouteCallback          = RouteBase + "/callback/:provider"
+	RouteBackChannelLogout = RouteBase + "/backchannel-logout/:provider"
 )
 
 var _ identity.ActiveCredentialsCounter = new(Strategy)
@@ -54,14 +62,17 @@ type dependencies interface {
 	x.LoggingProvider
 	x.CookieProvider
 	x.CSRFTokenGeneratorProvider
+	x.CSRFProvider
 	x.WriterProvider
 
 	identity.ValidationProvider
 	identity.PrivilegedPoolProvider
+	identity.ManagementProvider
 	identity.ActiveCredentialsCounterStrategyProvider
 
 	session.ManagementProvider
 	session.HandlerProvider
+	session.PersistenceProvider
 
 	login.HookExecutorProvider
 	login.FlowPersistenceProvider
@@ -82,6 +93,13 @@ type dependencies interface {
 	settings.HookExecutorProvider
 
 	continuity.ManagementProvider
+
+	cipher.Provider
+
+	audit.RecorderProvider
+
+	ProviderPersistenceProvider
+	LinkNotifierProvider
 }
 
 func isForced(req interface{}) bool {
@@ -97,12 +115,60 @@ type Strategy struct {
 	d         dependencies
 	f         *fetcher.Fetcher
 	validator *schema.Validator
+
+	storedLock      sync.Mutex
+	stored          []Configuration
+	storedExpiresAt time.Time
 }
 
 type authCodeContainer struct {
 	FlowID string     `json:"flow_id"`
 	State  string     `json:"state"`
 	Form   url.Values `json:"form"`
+
+	// CodeVerifier is the PKCE code verifier and only set if PKCE is enabled for the provider.
+	CodeVerifier string `json:"code_verifier,omitempty"`
+
+	// Nonce is the OpenID Connect nonce and only set if nonces are enabled for the provider.
+	Nonce string `json:"nonce,omitempty"`
+}
+
+func newAuthCodeContainer(provider Provider, flowID uuid.UUID, form url.Values) *authCodeContainer {
+	c := &authCodeContainer{
+		State:  x.NewUUID().String(),
+		FlowID: flowID.String(),
+		Form:   form,
+	}
+	if provider.Config().PKCE {
+		c.CodeVerifier = randx.MustString(64, randx.AlphaNum)
+	}
+	if provider.Config().Nonce {
+		c.Nonce = randx.MustString(32, randx.AlphaNum)
+	}
+	return c
+}
+
+// authCodeURLOptions returns the PKCE code challenge and nonce parameters of the authorization request.
+func (c *authCodeContainer) authCodeURLOptions() []oauth2.AuthCodeOption {
+	var options []oauth2.AuthCodeOption
+	if len(c.CodeVerifier) > 0 {
+		challenge := sha256.Sum256([]byte(c.CodeVerifier))
+		options = append(options,
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+			oauth2.SetAuthURLParam("code_challenge_method", "S256"))
+	}
+	if len(c.Nonce) > 0 {
+		options = append(options, oauth2.SetAuthURLParam("nonce", c.Nonce))
+	}
+	return options
+}
+
+// exchangeOptions returns the PKCE code verifier parameter of the token request.
+func (c *authCodeContainer) exchangeOptions() []oauth2.AuthCodeOption {
+	if len(c.CodeVerifier) == 0 {
+		return nil
+	}
+	return []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("code_verifier", c.CodeVerifier)}
 }
 
 func (s *Strategy) CountActiveCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
@@ -136,6 +202,14 @@ func (s *Strategy) setRoutes(r *x.RouterPublic) {
 		r.GET(RouteCallback, wrappedHandleCallback)
 	}
 
+	// Some providers, for example "Sign in with Apple", post the authorization response to the callback. The
+	// continuity cookie is not sent with cross-site POST requests which is why the response is redirected to
+	// the GET callback.
+	s.d.CSRFHandler().ExemptGlob(strings.Replace(RouteCallback, ":provider", "*", 1))
+	if handle, _, _ := r.Lookup("POST", RouteCallback); handle == nil {
+		r.POST(RouteCallback, strategy.IsDisabled(s.d, s.ID().String(), s.redirectToGET))
+	}
+
 	wrappedHandleAuth := strategy.IsDisabled(s.d, s.ID().String(), s.handleAuth)
 	if handle, _, _ := r.Lookup("POST", RouteAuth); handle == nil {
 		r.POST(RouteAuth, wrappedHandleAuth)
@@ -144,6 +218,12 @@ func (s *Strategy) setRoutes(r *x.RouterPublic) {
 	if handle, _, _ := r.Lookup("GET", RouteAuth); handle == nil {
 		r.GET(RouteAuth, wrappedHandleAuth)
 	}
+
+	// Logout tokens are posted by the OpenID Connect Provider and not by the browser.
+	s.d.CSRFHandler().ExemptGlob(strings.Replace(RouteBackChannelLogout, ":provider", "*", 1))
+	if handle, _, _ := r.Lookup("POST", RouteBackChannelLogout); handle == nil {
+		r.POST(RouteBackChannelLogout, strategy.IsDisabled(s.d, s.ID().String(), s.handleBackChannelLogout))
+	}
 }
 
 func NewStrategy(d dependencies) *Strategy {
@@ -160,6 +240,13 @@ func (s *Strategy) ID() identity.CredentialsType {
 
 func (s *Strategy) handleAuth(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	rid := x.ParseUUID(ps.ByName("flow"))
+	if s.isAPIFlow(r.Context(), rid) {
+		// API clients can not follow the redirect to the OpenID Connect Provider and instead submit an
+		// ID token which they obtained natively.
+		s.handleAuthWithIDToken(w, r, rid)
+		return
+	}
+
 	if err := r.ParseForm(); err != nil {
 		s.handleError(w, r, rid, "", nil, errors.WithStack(herodot.ErrBadRequest.WithDebug(err.Error()).WithReasonf("Unable to parse HTTP form request: %s", err.Error())))
 		return
@@ -193,19 +280,16 @@ func (s *Strategy) handleAuth(w http.ResponseWriter, r *http.Request, ps httprou
 		return
 	}
 
-	state := x.NewUUID().String()
+	container := newAuthCodeContainer(provider, rid, r.PostForm)
 	if err := s.d.ContinuityManager().Pause(r.Context(), w, r, sessionName,
-		continuity.WithPayload(&authCodeContainer{
-			State:  state,
-			FlowID: rid.String(),
-			Form:   r.PostForm,
-		}),
+		continuity.WithPayload(container),
 		continuity.WithLifespan(time.Minute*30)); err != nil {
 		s.handleError(w, r, rid, pid, nil, err)
 		return
 	}
 
-	http.Redirect(w, r, config.AuthCodeURL(state, provider.AuthCodeURLOptions(req)...), http.StatusFound)
+	http.Redirect(w, r, config.AuthCodeURL(container.State,
+		append(provider.AuthCodeURLOptions(req), container.authCodeURLOptions()...)...), http.StatusFound)
 }
 
 func (s *Strategy) validateFlow(ctx context.Context, r *http.Request, rid uuid.UUID) (ider, error) {
@@ -255,6 +339,17 @@ func (s *Strategy) validateFlow(ctx context.Context, r *http.Request, rid uuid.U
 	return ar, err // this must return the error
 }
 
+func (s *Strategy) redirectToGET(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if err := r.ParseForm(); err != nil {
+		s.handleError(w, r, x.EmptyUUID, "", nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse the OpenID Connect callback: %s", err)))
+		return
+	}
+
+	dest := urlx.AppendPaths(urlx.Copy(s.d.Config(r.Context()).SelfPublicURL(r)), r.URL.Path)
+	dest.RawQuery = r.PostForm.Encode()
+	http.Redirect(w, r, dest.String(), http.StatusSeeOther)
+}
+
 func (s *Strategy) validateCallback(w http.ResponseWriter, r *http.Request) (ider, *authCodeContainer, error) {
 	var (
 		code  = r.URL.Query().Get("code")
@@ -336,24 +431,28 @@ func (s *Strategy) handleCallback(w http.ResponseWriter, r *http.Request, ps htt
 		return
 	}
 
-	token, err := config.Exchange(r.Context(), code)
+	token, err := config.Exchange(r.Context(), code, container.exchangeOptions()...)
 	if err != nil {
 		s.handleError(w, r, req.GetID(), pid, nil, err)
 		return
 	}
 
-	claims, err := provider.Claims(r.Context(), token)
+	claims, err := provider.Claims(withNonce(r.Context(), container.Nonce), token)
 	if err != nil {
 		s.handleError(w, r, req.GetID(), pid, nil, err)
 		return
 	}
 
+	if decoder, ok := provider.(CallbackClaimsDecoder); ok {
+		decoder.DecodeCallbackClaims(r.URL.Query(), claims)
+	}
+
 	switch a := req.(type) {
 	case *login.Flow:
-		s.processLogin(w, r, a, claims, provider, container)
+		s.processLogin(w, r, a, claims, provider, container, token)
 		return
 	case *registration.Flow:
-		s.processRegistration(w, r, a, claims, provider, container)
+		s.processRegistration(w, r, a, claims, provider, container, token)
 		return
 	case *settings.Flow:
 		sess, err := s.d.SessionManager().FetchFromRequest(r.Context(), r)
@@ -361,7 +460,7 @@ func (s *Strategy) handleCallback(w http.ResponseWriter, r *http.Request, ps htt
 			s.handleError(w, r, req.GetID(), pid, nil, err)
 			return
 		}
-		s.linkProvider(w, r, &settings.UpdateContext{Session: sess, Flow: a}, claims, provider)
+		s.linkProvider(w, r, &settings.UpdateContext{Session: sess, Flow: a}, claims, provider, token)
 		return
 	default:
 		s.handleError(w, r, req.GetID(), pid, nil, errors.WithStack(x.PseudoPanic.
@@ -396,7 +495,29 @@ func (s *Strategy) populateMethod(r *http.Request, flowID uuid.UUID) (*FlowMetho
 	return NewFlowMethod(f).AddProviders(conf.Providers), nil
 }
 
+// Config returns the providers from the configuration file and the providers which are stored in the database.
 func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error) {
+	c, err := s.fileConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storedConfigurations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Providers from the configuration file take precedence over stored providers with the same ID.
+	for _, sc := range stored {
+		if !c.has(sc.ID) {
+			c.Providers = append(c.Providers, sc)
+		}
+	}
+
+	return c, nil
+}
+
+func (s *Strategy) fileConfig(ctx context.Context) (*ConfigurationCollection, error) {
 	var c ConfigurationCollection
 
 	conf := s.d.Config(ctx).SelfServiceStrategy(string(s.ID())).Config
@@ -427,6 +548,7 @@ func (s *Strategy) handleError(w http.ResponseWriter, r *http.Request, rid uuid.
 	}
 
 	if lr, rerr := s.d.LoginFlowPersister().GetLoginFlow(r.Context(), rid); rerr == nil {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeLogin, s.ID().String(), uuid.Nil, err))
 		s.d.LoginFlowErrorHandler().WriteFlowError(w, r, s.ID(), lr, err)
 		return
 	} else if sr, rerr := s.d.SettingsFlowPersister().GetSettingsFlow(r.Context(), rid); rerr == nil {
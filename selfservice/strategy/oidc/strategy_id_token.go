@@ -0,0 +1,147 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/decoderx"
+
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	idTokenPayloadSchema = `{
+  "$id": "https://schemas.ory.sh/kratos/selfservice/oidc/id_token/config.schema.json",
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["provider", "id_token", "id_token_nonce"],
+  "properties": {
+    "provider": {
+      "type": "string",
+      "minLength": 1
+    },
+    "id_token": {
+      "type": "string",
+      "minLength": 1
+    },
+    "id_token_nonce": {
+      "type": "string",
+      "minLength": 1
+    }
+  }
+}`
+)
+
+// completeSelfServiceFlowWithIDToken is used to decode the payload which API clients send to sign in or sign up with
+// an ID token they obtained natively, for example using the Google Sign-In SDK on Android or iOS.
+type completeSelfServiceFlowWithIDToken struct {
+	// Provider is the ID of the OpenID Connect Provider which issued the ID token.
+	Provider string `json:"provider"`
+
+	// IDToken is the ID token issued by the OpenID Connect Provider.
+	IDToken string `json:"id_token"`
+
+	// IDTokenNonce is the nonce which was used when requesting the ID token.
+	IDTokenNonce string `json:"id_token_nonce"`
+}
+
+// isAPIFlow returns true if the flow is a login or registration flow which was initiated by an API client.
+func (s *Strategy) isAPIFlow(ctx context.Context, rid uuid.UUID) bool {
+	if x.IsZeroUUID(rid) {
+		return false
+	}
+
+	if f, err := s.d.LoginFlowPersister().GetLoginFlow(ctx, rid); err == nil {
+		return f.Type == flow.TypeAPI
+	}
+
+	if f, err := s.d.RegistrationFlowPersister().GetRegistrationFlow(ctx, rid); err == nil {
+		return f.Type == flow.TypeAPI
+	}
+
+	return false
+}
+
+func (s *Strategy) validateAPIFlow(ctx context.Context, rid uuid.UUID) (ider, error) {
+	if ar, err := s.d.RegistrationFlowPersister().GetRegistrationFlow(ctx, rid); err == nil {
+		if err := ar.Valid(); err != nil {
+			return ar, err
+		}
+		return ar, nil
+	}
+
+	ar, err := s.d.LoginFlowPersister().GetLoginFlow(ctx, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ar.Valid(); err != nil {
+		return ar, err
+	}
+	return ar, nil
+}
+
+func (s *Strategy) handleAuthWithIDToken(w http.ResponseWriter, r *http.Request, rid uuid.UUID) {
+	var p completeSelfServiceFlowWithIDToken
+	if err := decoderx.NewHTTP().Decode(r, &p,
+		decoderx.MustHTTPRawJSONSchemaCompiler([]byte(idTokenPayloadSchema)),
+		decoderx.HTTPDecoderJSONFollowsFormFormat()); err != nil {
+		s.handleError(w, r, rid, p.Provider, nil, err)
+		return
+	}
+
+	req, err := s.validateAPIFlow(r.Context(), rid)
+	if err != nil {
+		s.handleError(w, r, rid, p.Provider, nil, err)
+		return
+	}
+
+	if _, err := s.d.SessionManager().FetchFromRequest(r.Context(), r); err == nil && !isForced(req) {
+		if _, ok := req.(*registration.Flow); ok {
+			s.d.Writer().WriteError(w, r, errors.WithStack(registration.ErrAlreadyLoggedIn))
+			return
+		}
+		s.d.Writer().WriteError(w, r, errors.WithStack(login.ErrAlreadyLoggedIn))
+		return
+	}
+
+	provider, err := s.provider(r.Context(), r, p.Provider)
+	if err != nil {
+		s.handleError(w, r, rid, p.Provider, nil, err)
+		return
+	}
+
+	verifier, ok := provider.(IDTokenVerifier)
+	if !ok {
+		s.handleError(w, r, rid, p.Provider, nil, errors.WithStack(ErrIDTokenNotSupported))
+		return
+	}
+
+	claims, err := verifier.VerifyIDToken(r.Context(), p.IDToken, p.IDTokenNonce)
+	if err != nil {
+		s.handleError(w, r, rid, p.Provider, nil, err)
+		return
+	}
+
+	container := &authCodeContainer{FlowID: rid.String(), Form: url.Values{}}
+	switch a := req.(type) {
+	case *login.Flow:
+		s.processLogin(w, r, a, claims, provider, container, nil)
+		return
+	case *registration.Flow:
+		s.processRegistration(w, r, a, claims, provider, container, nil)
+		return
+	default:
+		s.handleError(w, r, rid, p.Provider, nil, errors.WithStack(herodot.ErrInternalServerError.
+			WithReasonf("Unexpected type in OpenID Connect flow: %T", a)))
+		return
+	}
+}
@@ -0,0 +1,259 @@
+package oidc_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+// newIDTokenIssuer starts an OpenID Connect Provider stub which serves the discovery document and the JWKS. Its
+// authorization endpoint immediately redirects back with a code which the token endpoint exchanges for an ID token
+// containing the claims set by the returned setter. Every access token it issues is unique.
+func newIDTokenIssuer(t *testing.T) (*httptest.Server, func(claims jwt.MapClaims) string, func(claims jwt.MapClaims)) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var sign = func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "stub"
+		raw, err := token.SignedString(key)
+		require.NoError(t, err)
+		return raw
+	}
+
+	var codeClaims jwt.MapClaims
+	var issued int
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                                ts.URL,
+				"authorization_endpoint":                ts.URL + "/oauth2/auth",
+				"token_endpoint":                        ts.URL + "/oauth2/token",
+				"jwks_uri":                              ts.URL + "/jwks",
+				"id_token_signing_alg_values_supported": []string{"RS256"},
+			}))
+		case "/jwks":
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []map[string]interface{}{{
+					"kty": "RSA",
+					"kid": "stub",
+					"alg": "RS256",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+				}},
+			}))
+		case "/oauth2/auth":
+			redir, err := url.Parse(r.URL.Query().Get("redirect_uri"))
+			require.NoError(t, err)
+			redir.RawQuery = url.Values{"code": {"stub"}, "state": {r.URL.Query().Get("state")}}.Encode()
+			http.Redirect(w, r, redir.String(), http.StatusFound)
+		case "/oauth2/token":
+			require.NoError(t, r.ParseForm())
+			issued++
+
+			w.Header().Set("Content-Type", "application/json")
+			if r.PostForm.Get("grant_type") == "refresh_token" {
+				require.Equal(t, "refresh-token", r.PostForm.Get("refresh_token"))
+				require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+					"access_token": fmt.Sprintf("access-token-%d", issued),
+					"token_type":   "bearer",
+					"expires_in":   3600,
+				}))
+				return
+			}
+
+			claims := jwt.MapClaims{"iss": ts.URL, "aud": "client", "iat": time.Now().Unix(), "exp": time.Now().Add(time.Hour).Unix()}
+			for k, v := range codeClaims {
+				claims[k] = v
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  fmt.Sprintf("access-token-%d", issued),
+				"refresh_token": "refresh-token",
+				"token_type":    "bearer",
+				"expires_in":    3600,
+				"id_token":      sign(claims),
+			}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts, sign, func(claims jwt.MapClaims) {
+		codeClaims = claims
+	}
+}
+
+func TestStrategyIDToken(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	issuer, sign, _ := newIDTokenIssuer(t)
+
+	viperSetProviderConfig(
+		t,
+		conf,
+		oidc.Configuration{
+			Provider:         "generic",
+			ID:               "valid",
+			ClientID:         "client",
+			ClientSecret:     "secret",
+			IssuerURL:        issuer.URL,
+			Mapper:           "file://./stub/oidc.hydra.jsonnet",
+			IDTokenAudiences: []string{"ios-client"},
+		},
+		oidc.Configuration{
+			Provider:     "github",
+			ID:           "github",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Mapper:       "file://./stub/oidc.hydra.jsonnet",
+		},
+	)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/registration.schema.json")
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeOIDC.String()), []config.SelfServiceHook{{Name: "session"}})
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+
+	var claims = func(subject string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   issuer.URL,
+			"sub":   subject,
+			"aud":   "client",
+			"nonce": "nonce",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	var submit = func(t *testing.T, flowID, provider, idToken, nonce string) (int, string) {
+		var b bytes.Buffer
+		require.NoError(t, json.NewEncoder(&b).Encode(map[string]string{
+			"provider":       provider,
+			"id_token":       idToken,
+			"id_token_nonce": nonce,
+		}))
+
+		req, err := http.NewRequest("POST", publicTS.URL+oidc.RouteBase+"/auth/"+flowID, &b)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(body)
+	}
+
+	var newLoginFlow = func(t *testing.T) string {
+		return string(*testhelpers.InitializeLoginFlowViaAPI(t, new(http.Client), publicTS, false).Payload.ID)
+	}
+
+	var newRegistrationFlow = func(t *testing.T) string {
+		return string(*testhelpers.InitializeRegistrationFlowViaAPI(t, new(http.Client), publicTS).Payload.ID)
+	}
+
+	var expectError = func(t *testing.T, flowID, provider, idToken, nonce, reason string) {
+		code, body := submit(t, flowID, provider, idToken, nonce)
+		assert.Equal(t, http.StatusBadRequest, code, "%s", body)
+		assert.Contains(t, body, reason)
+	}
+
+	t.Run("case=should show the providers in API flows", func(t *testing.T) {
+		f := testhelpers.InitializeLoginFlowViaAPI(t, new(http.Client), publicTS, false).Payload
+		config := testhelpers.GetLoginFlowMethodConfig(t, f, identity.CredentialsTypeOIDC.String())
+		assert.Contains(t, *config.Action, oidc.RouteBase+"/auth/"+string(*f.ID))
+	})
+
+	t.Run("case=should register and then sign in", func(t *testing.T) {
+		subject := x.NewUUID().String() + "@ory.sh"
+
+		code, body := submit(t, newRegistrationFlow(t), "valid", sign(claims(subject)), "nonce")
+		require.Equal(t, http.StatusOK, code, "%s", body)
+		assert.Equal(t, subject, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+
+		code, body = submit(t, newLoginFlow(t), "valid", sign(claims(subject)), "nonce")
+		require.Equal(t, http.StatusOK, code, "%s", body)
+		assert.Equal(t, subject, gjson.Get(body, "session.identity.traits.subject").String(), "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+	})
+
+	t.Run("case=should register when signing in with an unknown subject", func(t *testing.T) {
+		subject := x.NewUUID().String() + "@ory.sh"
+
+		code, body := submit(t, newLoginFlow(t), "valid", sign(claims(subject)), "nonce")
+		require.Equal(t, http.StatusOK, code, "%s", body)
+		assert.Equal(t, subject, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+		assert.NotEmpty(t, gjson.Get(body, "session_token").String(), "%s", body)
+	})
+
+	t.Run("case=should accept additional audiences", func(t *testing.T) {
+		c := claims(x.NewUUID().String() + "@ory.sh")
+		c["aud"] = "ios-client"
+
+		code, body := submit(t, newLoginFlow(t), "valid", sign(c), "nonce")
+		require.Equal(t, http.StatusOK, code, "%s", body)
+	})
+
+	t.Run("case=should reject an unknown audience", func(t *testing.T) {
+		c := claims(x.NewUUID().String() + "@ory.sh")
+		c["aud"] = "other-client"
+		expectError(t, newLoginFlow(t), "valid", sign(c), "nonce", "was issued for audience")
+	})
+
+	t.Run("case=should reject a mismatching nonce", func(t *testing.T) {
+		expectError(t, newLoginFlow(t), "valid", sign(claims(x.NewUUID().String()+"@ory.sh")), "other-nonce", "nonce does not match")
+
+		c := claims(x.NewUUID().String() + "@ory.sh")
+		delete(c, "nonce")
+		expectError(t, newLoginFlow(t), "valid", sign(c), "nonce", "must both include a nonce")
+	})
+
+	t.Run("case=should reject an expired token", func(t *testing.T) {
+		c := claims(x.NewUUID().String() + "@ory.sh")
+		c["exp"] = time.Now().Add(-time.Minute).Unix()
+		expectError(t, newLoginFlow(t), "valid", sign(c), "nonce", "token is expired")
+	})
+
+	t.Run("case=should reject a token from another issuer", func(t *testing.T) {
+		c := claims(x.NewUUID().String() + "@ory.sh")
+		c["iss"] = "https://www.ory.sh/"
+		expectError(t, newLoginFlow(t), "valid", sign(c), "nonce", "id token issued by a different provider")
+	})
+
+	t.Run("case=should reject a token with an invalid signature", func(t *testing.T) {
+		_, signOther, _ := newIDTokenIssuer(t)
+		expectError(t, newLoginFlow(t), "valid", signOther(claims(x.NewUUID().String()+"@ory.sh")), "nonce", "failed to verify signature")
+	})
+
+	t.Run("case=should reject providers which do not support ID tokens", func(t *testing.T) {
+		expectError(t, newLoginFlow(t), "github", sign(claims(x.NewUUID().String()+"@ory.sh")), "nonce", "not supported by this provider")
+	})
+}
@@ -0,0 +1,163 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/sqlcon"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+var _ login.PostHookExecutor = new(Strategy)
+
+type (
+	// LinkNotifier notifies the identity that a provider was linked to it automatically.
+	LinkNotifier interface {
+		NotifyProviderLinked(r *http.Request, i *identity.Identity, provider string)
+	}
+
+	LinkNotifierProvider interface {
+		OIDCLinkNotifier() LinkNotifier
+	}
+)
+
+// linkContainer is stored in a continuity session while the user proves that they own the identity
+// which the provider should be linked to.
+type linkContainer struct {
+	FlowID      uuid.UUID                 `json:"flow_id"`
+	Credentials ProviderCredentialsConfig `json:"credentials"`
+}
+
+// linkableAddress returns the verified email address of an existing identity which matches the verified email
+// address asserted by the provider. It returns nil if the provider's link policy does not allow linking or if no
+// such address exists.
+func (s *Strategy) linkableAddress(ctx context.Context, claims *Claims, provider Provider) (*identity.VerifiableAddress, error) {
+	if provider.Config().LinkPolicy != LinkPolicyVerifiedEmail || !claims.EmailVerified || len(claims.Email) == 0 {
+		return nil, nil
+	}
+
+	address, err := s.d.PrivilegedIdentityPool().FindVerifiableAddressByValue(ctx, identity.VerifiableAddressTypeEmail, strings.ToLower(claims.Email))
+	if errors.Is(err, sqlcon.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !address.Verified {
+		return nil, nil
+	}
+
+	return address, nil
+}
+
+// initLinkFlow initializes a login flow in which the user has to sign in to the identity owning the matching email
+// address. Once the login flow completes, ExecuteLoginPostHook links the provider to that identity.
+func (s *Strategy) initLinkFlow(w http.ResponseWriter, r *http.Request, a *registration.Flow, address *identity.VerifiableAddress, claims *Claims, provider Provider, token *oauth2.Token) {
+	s.d.Logger().WithRequest(r).WithField("provider", provider.Config().ID).
+		WithField("subject", claims.Subject).
+		Debug("Received successful OpenID Connect callback for an email address which belongs to an existing identity. Initializing login flow to link the provider now.")
+
+	c, err := s.newProviderCredentials(r.Context(), provider.Config().ID, claims.Subject, token)
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+		return
+	}
+
+	lf, err := s.d.LoginHandler().NewLoginFlow(w, r, flow.TypeBrowser)
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+		return
+	}
+
+	lf.Messages.Add(text.NewInfoLoginLinkCredentials(provider.Config().ID, address.Value))
+	if err := s.d.LoginFlowPersister().UpdateLoginFlow(r.Context(), lf); err != nil {
+		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+		return
+	}
+
+	if err := s.d.ContinuityManager().Pause(r.Context(), w, r, linkSessionName,
+		continuity.WithIdentity(&identity.Identity{ID: address.IdentityID}),
+		continuity.WithPayload(&linkContainer{FlowID: lf.ID, Credentials: *c}),
+		continuity.WithLifespan(s.d.Config(r.Context()).SelfServiceFlowLoginRequestLifespan())); err != nil {
+		s.handleError(w, r, lf.GetID(), provider.Config().ID, nil, err)
+		return
+	}
+
+	http.Redirect(w, r, lf.AppendTo(s.d.Config(r.Context()).SelfServiceFlowLoginUI()).String(), http.StatusFound)
+}
+
+// ExecuteLoginPostHook links the provider to the identity if the login flow was initialized by initLinkFlow
+// and the user signed in to the identity owning the matching email address.
+func (s *Strategy) ExecuteLoginPostHook(w http.ResponseWriter, r *http.Request, a *login.Flow, sess *session.Session) error {
+	if a.Type != flow.TypeBrowser {
+		return nil
+	}
+
+	var p linkContainer
+	container, err := s.d.ContinuityManager().Continue(r.Context(), w, r, linkSessionName, continuity.WithPayload(&p))
+	if errors.Is(err, &continuity.ErrNotResumable) {
+		return nil
+	} else if errors.Is(err, &herodot.ErrBadRequest) {
+		// The link session has expired, we just sign in without linking the provider.
+		s.d.Logger().WithRequest(r).WithError(err).Debug("Unable to continue OpenID Connect link session.")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if p.FlowID != a.ID || x.DerefUUID(container.IdentityID) != sess.IdentityID {
+		s.d.Logger().WithRequest(r).
+			WithField("provider", p.Credentials.Provider).
+			WithField("identity_id", sess.IdentityID).
+			Debug("Signed in to a different identity or flow than the one which initiated linking the OpenID Connect provider, the provider will not be linked.")
+		return nil
+	}
+
+	i, err := s.linkVerifiedProvider(r.Context(), sess.IdentityID, p.Credentials)
+	s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeIdentityUpdated, s.ID().String(), sess.IdentityID, err))
+	if err != nil {
+		return err
+	}
+
+	s.d.Audit().
+		WithRequest(r).
+		WithField("identity_id", sess.IdentityID).
+		WithField("provider", p.Credentials.Provider).
+		Info("Linked OpenID Connect provider to an existing identity with a matching verified email address.")
+
+	s.d.OIDCLinkNotifier().NotifyProviderLinked(r, i, p.Credentials.Provider)
+	return nil
+}
+
+// linkVerifiedProvider adds the provider's credentials to the identity and returns the updated identity.
+func (s *Strategy) linkVerifiedProvider(ctx context.Context, id uuid.UUID, c ProviderCredentialsConfig) (*identity.Identity, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setProviderCredentials(i, c); err != nil {
+		return nil, err
+	}
+
+	if err := s.d.IdentityManager().Update(ctx, i, identity.ManagerAllowWriteProtectedTraits); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
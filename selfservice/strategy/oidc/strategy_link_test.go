@@ -0,0 +1,214 @@
+package oidc_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/courier/template"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/text"
+	"github.com/ory/kratos/x"
+)
+
+func TestStrategyLink(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	issuer, _, authorize := newIDTokenIssuer(t)
+
+	viperSetProviderConfig(
+		t,
+		conf,
+		oidc.Configuration{
+			Provider:     "generic",
+			ID:           "linkable",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			IssuerURL:    issuer.URL,
+			Mapper:       "file://./stub/oidc.link.jsonnet",
+			LinkPolicy:   oidc.LinkPolicyVerifiedEmail,
+		},
+		oidc.Configuration{
+			Provider:     "generic",
+			ID:           "unlinkable",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			IssuerURL:    issuer.URL,
+			Mapper:       "file://./stub/oidc.link.jsonnet",
+		},
+	)
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+identity.CredentialsTypePassword.String()+".enabled", true)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/link.schema.json")
+	conf.MustSet(config.ViperKeySelfServiceSecurityNotifications+"."+string(template.SecurityNotificationOIDCLinked)+".enabled", true)
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeOIDC.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	uiTS := testhelpers.NewUIFlowEchoServer(t, reg)
+	returnTS := newReturnTs(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+
+	const password = "MMpfXFZ4^g3NGDZz"
+
+	var createIdentity = func(t *testing.T, verified bool) *identity.Identity {
+		email := x.NewUUID().String() + "@ory.sh"
+		p, err := reg.Hasher().Generate(context.Background(), []byte(password))
+		require.NoError(t, err)
+
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, email))
+		i.SetCredentials(identity.CredentialsTypePassword, identity.Credentials{
+			Identifiers: []string{email},
+			Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
+		})
+		require.NoError(t, reg.IdentityManager().Create(context.Background(), i))
+		require.Len(t, i.VerifiableAddresses, 1)
+
+		if verified {
+			address := i.VerifiableAddresses[0]
+			address.Verified = true
+			address.Status = identity.VerifiableAddressStatusCompleted
+			require.NoError(t, reg.PrivilegedIdentityPool().UpdateVerifiableAddress(context.Background(), &address))
+		}
+
+		return i
+	}
+
+	var email = func(i *identity.Identity) string {
+		return i.VerifiableAddresses[0].Value
+	}
+
+	var claims = func(email string, verified bool) jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":            x.NewUUID().String(),
+			"email":          email,
+			"email_verified": verified,
+		}
+	}
+
+	var do = func(t *testing.T, client *http.Client, action string, values url.Values) (*http.Response, string) {
+		res, err := client.PostForm(action, values)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, string(body)
+	}
+
+	var signUpWithProvider = func(t *testing.T, client *http.Client, provider string, c jwt.MapClaims) (*http.Response, string) {
+		authorize(c)
+		f := testhelpers.InitializeRegistrationFlowViaBrowser(t, client, publicTS).Payload
+		return do(t, client, publicTS.URL+oidc.RouteBase+"/auth/"+string(*f.ID), url.Values{"provider": {provider}})
+	}
+
+	var signInWithPassword = func(t *testing.T, client *http.Client, body, identifier string) (*http.Response, string) {
+		values := url.Values{"identifier": {identifier}, "password": {password}}
+		for _, field := range gjson.Get(body, "methods.password.config.fields").Array() {
+			if field.Get("name").String() == "csrf_token" {
+				values.Set("csrf_token", field.Get("value").String())
+			}
+		}
+		return do(t, client, gjson.Get(body, "methods.password.config.action").String(), values)
+	}
+
+	var expectLinkFlow = func(t *testing.T, res *http.Response, body string) {
+		require.Contains(t, res.Request.URL.String(), uiTS.URL+"/login", "%s", body)
+		assert.EqualValues(t, text.InfoSelfServiceLoginLinkCredentials, gjson.Get(body, "messages.0.id").Int(), "%s", body)
+	}
+
+	var expectDuplicate = func(t *testing.T, res *http.Response, body string) {
+		require.Contains(t, res.Request.URL.String(), uiTS.URL+"/registration", "%s", body)
+		assert.Contains(t, body, "exists already")
+	}
+
+	var linkedSubjects = func(t *testing.T, i *identity.Identity) []string {
+		actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), i.ID)
+		require.NoError(t, err)
+		return actual.Credentials[identity.CredentialsTypeOIDC].Identifiers
+	}
+
+	t.Run("case=should link the provider after signing in with the existing credentials", func(t *testing.T) {
+		i := createIdentity(t, true)
+		c := claims(email(i), true)
+
+		client := newClient(t, nil)
+		res, body := signUpWithProvider(t, client, "linkable", c)
+		expectLinkFlow(t, res, body)
+
+		res, body = signInWithPassword(t, client, body, email(i))
+		require.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+		assert.Equal(t, i.ID.String(), gjson.Get(body, "identity.id").String(), "%s", body)
+		assert.Equal(t, []string{"linkable:" + c["sub"].(string)}, linkedSubjects(t, i))
+
+		t.Run("case=should have recorded the link in the audit log", func(t *testing.T) {
+			events, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{IdentityID: i.ID, Type: audit.EventTypeIdentityUpdated}, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActorIdentity, events[0].Actor)
+			assert.Equal(t, identity.CredentialsTypeOIDC.String(), events[0].Method)
+			assert.Equal(t, audit.OutcomeSuccess, events[0].Outcome)
+		})
+
+		t.Run("case=should have notified the identity", func(t *testing.T) {
+			messages, err := reg.CourierPersister().NextMessages(context.Background(), 10)
+			require.NoError(t, err)
+			require.Len(t, messages, 1)
+			assert.Equal(t, email(i), messages[0].Recipient)
+			assert.Contains(t, messages[0].Body, "linkable")
+		})
+
+		t.Run("case=should now sign in with the provider", func(t *testing.T) {
+			res, body := signUpWithProvider(t, newClient(t, nil), "linkable", c)
+			require.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+			assert.Equal(t, i.ID.String(), gjson.Get(body, "identity.id").String(), "%s", body)
+		})
+	})
+
+	t.Run("case=should not link if the provider does not allow linking", func(t *testing.T) {
+		i := createIdentity(t, true)
+		res, body := signUpWithProvider(t, newClient(t, nil), "unlinkable", claims(email(i), true))
+		expectDuplicate(t, res, body)
+	})
+
+	t.Run("case=should not link if the provider did not verify the email address", func(t *testing.T) {
+		i := createIdentity(t, true)
+		res, body := signUpWithProvider(t, newClient(t, nil), "linkable", claims(email(i), false))
+		expectDuplicate(t, res, body)
+	})
+
+	t.Run("case=should not link if the existing email address is not verified", func(t *testing.T) {
+		i := createIdentity(t, false)
+		res, body := signUpWithProvider(t, newClient(t, nil), "linkable", claims(email(i), true))
+		expectDuplicate(t, res, body)
+	})
+
+	t.Run("case=should not link if signing in to another identity", func(t *testing.T) {
+		i := createIdentity(t, true)
+		other := createIdentity(t, true)
+
+		client := newClient(t, nil)
+		res, body := signUpWithProvider(t, client, "linkable", claims(email(i), true))
+		expectLinkFlow(t, res, body)
+
+		res, body = signInWithPassword(t, client, body, email(other))
+		require.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+		assert.Equal(t, other.ID.String(), gjson.Get(body, "identity.id").String(), "%s", body)
+		assert.Empty(t, linkedSubjects(t, i))
+		assert.Empty(t, linkedSubjects(t, other))
+	})
+}
@@ -5,13 +5,18 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gofrs/uuid"
+	"github.com/mohae/deepcopy"
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
 
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/identity"
-	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/strategy"
 	"github.com/ory/kratos/x"
 )
 
@@ -22,10 +27,6 @@ func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
 }
 
 func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
-	if sr.Type != flow.TypeBrowser {
-		return nil
-	}
-
 	config, err := s.populateMethod(r, sr.ID)
 	if err != nil {
 		return err
@@ -35,7 +36,7 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
 	return nil
 }
 
-func (s *Strategy) processLogin(w http.ResponseWriter, r *http.Request, a *login.Flow, claims *Claims, provider Provider, container *authCodeContainer) {
+func (s *Strategy) processLogin(w http.ResponseWriter, r *http.Request, a *login.Flow, claims *Claims, provider Provider, container *authCodeContainer, token *oauth2.Token) {
 	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), identity.CredentialsTypeOIDC, uid(provider.Config().ID, claims.Subject))
 	if err != nil {
 		if errors.Is(err, herodot.ErrNotFound) {
@@ -52,14 +53,13 @@ func (s *Strategy) processLogin(w http.ResponseWriter, r *http.Request, a *login
 
 			s.d.Logger().WithField("provider", provider.Config().ID).WithField("subject", claims.Subject).Debug("Received successful OpenID Connect callback but user is not registered. Re-initializing registration flow now.")
 
-			// This flow only works for browsers anyways.
-			aa, err := s.d.RegistrationHandler().NewRegistrationFlow(w, r, flow.TypeBrowser)
+			aa, err := s.d.RegistrationHandler().NewRegistrationFlow(w, r, a.Type)
 			if err != nil {
 				s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
 				return
 			}
 
-			s.processRegistration(w, r, aa, claims, provider, container)
+			s.processRegistration(w, r, aa, claims, provider, container, token)
 			return
 		}
 
@@ -73,15 +73,98 @@ func (s *Strategy) processLogin(w http.ResponseWriter, r *http.Request, a *login
 		return
 	}
 
+	if !i.IsActive() {
+		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, errors.WithStack(schema.NewIdentityInactiveError()))
+		return
+	}
+
 	for _, c := range o.Providers {
 		if c.Subject == claims.Subject && c.Provider == provider.Config().ID {
-			if err = s.d.LoginHookExecutor().PostLoginHook(w, r, identity.CredentialsTypeOIDC, a, i); err != nil {
+			if err := s.updateToken(r.Context(), i.ID, c, token); err != nil {
 				s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
 				return
 			}
+
+			if provider.Config().UpdateTraitsOnLogin {
+				if i, err = s.updateTraits(r, i.ID, claims, provider); err != nil {
+					s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+					return
+				}
+			}
+
+			upstream, err := s.upstreamSession(r.Context(), provider, claims, token)
+			if err != nil {
+				s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+				return
+			}
+
+			if err = s.d.LoginHookExecutor().PostLoginHook(w, r, identity.CredentialsTypeOIDC, a, i, upstream); err != nil {
+				// The login hook executor already recorded the failure in the audit log.
+				s.d.LoginFlowErrorHandler().WriteFlowError(w, r, s.ID(), a, err)
+				return
+			}
 			return
 		}
 	}
 
 	s.handleError(w, r, a.GetID(), provider.Config().ID, nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to find matching OpenID Connect Credentials.").WithDebugf(`Unable to find credentials that match the given provider "%s" and subject "%s".`, provider.Config().ID, claims.Subject)))
 }
+
+// updateTraits runs the provider's login mapper and merges the mapped traits into the traits of the identity. The
+// update is validated like any other unprivileged update, so changes to protected traits (e.g. login identifiers
+// or verifiable addresses) are rejected. In that case, or if the merged traits are invalid, the identity is left
+// unchanged and the sign in continues.
+func (s *Strategy) updateTraits(r *http.Request, id uuid.UUID, claims *Claims, provider Provider) (*identity.Identity, error) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := provider.Config().LoginMapper
+	if mapper == "" {
+		mapper = provider.Config().Mapper
+	}
+
+	evaluated, err := strategy.EvaluateMapper(s.f, mapper, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := gjson.Get(evaluated, "identity.traits")
+	if !mapped.IsObject() {
+		s.d.Logger().
+			WithRequest(r).
+			WithField("oidc_provider", provider.Config().ID).
+			WithSensitiveField("oidc_claims", claims).
+			WithField("mapper_jsonnet_output", evaluated).
+			WithField("mapper_jsonnet_url", mapper).
+			Error("OpenID Connect Jsonnet mapper did not return an object for key identity.traits. Please check your Jsonnet code!")
+		return i, nil
+	}
+
+	traits, changed, err := strategy.MergeTraits(i.Traits, json.RawMessage(mapped.Raw))
+	if err != nil {
+		return nil, err
+	} else if !changed {
+		return i, nil
+	}
+
+	updated := deepcopy.Copy(i).(*identity.Identity)
+	updated.Traits = traits
+
+	if err := s.d.IdentityManager().Update(r.Context(), updated); err != nil {
+		if errors.Is(err, herodot.ErrBadRequest) || errors.Is(err, identity.ErrProtectedFieldModified) {
+			s.d.Logger().
+				WithRequest(r).
+				WithError(err).
+				WithField("oidc_provider", provider.Config().ID).
+				WithField("identity_id", i.ID).
+				WithField("mapper_jsonnet_url", mapper).
+				Warn("Unable to update the identity traits from the OpenID Connect claims. The identity was left unchanged.")
+			return i, nil
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,119 @@
+package oidc_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestStrategyUpdateTraitsOnLogin(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	issuer, _, authorize := newIDTokenIssuer(t)
+
+	var setProvider = func(t *testing.T, enabled bool) {
+		viperSetProviderConfig(
+			t,
+			conf,
+			oidc.Configuration{
+				Provider:            "generic",
+				ID:                  "valid",
+				ClientID:            "client",
+				ClientSecret:        "secret",
+				IssuerURL:           issuer.URL,
+				Mapper:              "file://./stub/oidc.hydra.jsonnet",
+				LoginMapper:         "file://./stub/oidc.login.jsonnet",
+				UpdateTraitsOnLogin: enabled,
+			},
+		)
+	}
+	setProvider(t, true)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/registration.schema.json")
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeOIDC.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	_ = testhelpers.NewUIFlowEchoServer(t, reg)
+	returnTS := newReturnTs(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+
+	var signIn = func(t *testing.T, claims jwt.MapClaims) gjson.Result {
+		authorize(claims)
+		client := newClient(t, nil)
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false).Payload
+
+		res, err := client.PostForm(publicTS.URL+oidc.RouteBase+"/auth/"+string(*f.ID), url.Values{"provider": {"valid"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+		return gjson.GetBytes(body, "identity")
+	}
+
+	var storedTraits = func(t *testing.T, id string) gjson.Result {
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+		require.NoError(t, err)
+		return gjson.ParseBytes(i.Traits)
+	}
+
+	subject := x.NewUUID().String() + "@ory.sh"
+	registered := signIn(t, jwt.MapClaims{"sub": subject, "name": "Alice"})
+	id := registered.Get("id").String()
+	require.NotEmpty(t, id)
+
+	t.Run("case=should only use the registration mapper when registering", func(t *testing.T) {
+		assert.False(t, registered.Get("traits.name").Exists(), "%s", registered.Raw)
+	})
+
+	t.Run("case=should update the traits when signing in", func(t *testing.T) {
+		i := signIn(t, jwt.MapClaims{"sub": subject, "name": "Alice"})
+		assert.Equal(t, id, i.Get("id").String())
+		assert.Equal(t, "Alice", i.Get("traits.name").String(), "%s", i.Raw)
+		assert.Equal(t, "Alice", storedTraits(t, id).Get("name").String())
+
+		i = signIn(t, jwt.MapClaims{"sub": subject, "name": "Bob"})
+		assert.Equal(t, "Bob", i.Get("traits.name").String(), "%s", i.Raw)
+		assert.Equal(t, subject, storedTraits(t, id).Get("subject").String())
+		assert.Equal(t, "Bob", storedTraits(t, id).Get("name").String())
+	})
+
+	t.Run("case=should keep the traits if the mapped traits are invalid", func(t *testing.T) {
+		i := signIn(t, jwt.MapClaims{"sub": subject, "name": "x"})
+		assert.Equal(t, id, i.Get("id").String())
+		assert.Equal(t, "Bob", i.Get("traits.name").String(), "%s", i.Raw)
+		assert.Equal(t, "Bob", storedTraits(t, id).Get("name").String())
+	})
+
+	t.Run("case=should keep the traits if a protected trait would be modified", func(t *testing.T) {
+		i := signIn(t, jwt.MapClaims{"sub": subject, "email": x.NewUUID().String() + "@ory.sh", "name": "Carol"})
+		assert.Equal(t, id, i.Get("id").String())
+		assert.Equal(t, subject, i.Get("traits.subject").String(), "%s", i.Raw)
+		assert.Equal(t, "Bob", i.Get("traits.name").String(), "%s", i.Raw)
+		assert.Equal(t, subject, storedTraits(t, id).Get("subject").String())
+		assert.Equal(t, "Bob", storedTraits(t, id).Get("name").String())
+	})
+
+	t.Run("case=should not update the traits if disabled", func(t *testing.T) {
+		setProvider(t, false)
+		t.Cleanup(func() { setProvider(t, true) })
+
+		i := signIn(t, jwt.MapClaims{"sub": subject, "name": "Dave"})
+		assert.Equal(t, "Bob", i.Get("traits.name").String(), "%s", i.Raw)
+		assert.Equal(t, "Bob", storedTraits(t, id).Get("name").String())
+	})
+}
@@ -0,0 +1,97 @@
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/selfservice/flow/logout"
+	"github.com/ory/kratos/session"
+)
+
+var _ logout.UpstreamLogoutStrategy = new(Strategy)
+
+// UpstreamLogoutURL returns the provider's end_session_endpoint if the session was created from a session of a
+// provider which has `end_session_on_logout` enabled.
+func (s *Strategy) UpstreamLogoutURL(r *http.Request, sess *session.Session, returnTo *url.URL) (*url.URL, error) {
+	if len(sess.UpstreamProvider) == 0 {
+		return nil, nil
+	}
+
+	provider, err := s.provider(r.Context(), r, sess.UpstreamProvider)
+	if err != nil {
+		return nil, err
+	} else if !provider.Config().EndSessionOnLogout {
+		return nil, nil
+	}
+
+	ender, ok := provider.(EndSessionURLer)
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The OpenID Connect Provider %s does not support ending sessions.", sess.UpstreamProvider))
+	}
+
+	var hint string
+	if len(sess.UpstreamIDToken) > 0 {
+		decrypted, err := s.d.Cipher().Decrypt(r.Context(), string(sess.UpstreamIDToken))
+		if err != nil {
+			return nil, err
+		}
+		hint = string(decrypted)
+	}
+
+	return ender.EndSessionURL(r.Context(), hint, returnTo.String())
+}
+
+// handleBackChannelLogout is called by the OpenID Connect Provider, not by the browser, when a session at the
+// provider ended and revokes all sessions which were created from it. The endpoint is only available for providers
+// which have `backchannel_logout` enabled.
+//
+// See https://openid.net/specs/openid-connect-backchannel-1_0.html
+func (s *Strategy) handleBackChannelLogout(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	// See https://openid.net/specs/openid-connect-backchannel-1_0.html#BCResponse
+	w.Header().Set("Cache-Control", "no-store")
+
+	pid := ps.ByName("provider")
+	provider, err := s.provider(r.Context(), r, pid)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	} else if !provider.Config().BackChannelLogout {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound.WithReasonf("Back-channel logout is not enabled for OpenID Connect Provider %s.", pid)))
+		return
+	}
+
+	verifier, ok := provider.(LogoutTokenVerifier)
+	if !ok {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The OpenID Connect Provider %s does not support back-channel logout.", pid)))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to parse the logout request: %s", err)))
+		return
+	}
+
+	raw := r.PostForm.Get("logout_token")
+	if len(raw) == 0 {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrBadRequest.WithReason("The logout_token parameter is missing.")))
+		return
+	}
+
+	subject, sessionID, err := verifier.VerifyLogoutToken(r.Context(), raw)
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if err := s.d.SessionPersister().RevokeSessionsByUpstream(r.Context(), pid, subject, sessionID); err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
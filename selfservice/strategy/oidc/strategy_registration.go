@@ -1,17 +1,17 @@
 package oidc
 
 import (
-	"bytes"
 	"encoding/json"
 	"net/http"
 
-	"github.com/google/go-jsonnet"
 	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/strategy"
 	"github.com/ory/kratos/x"
 )
 
@@ -33,10 +33,6 @@ func (s *Strategy) RegisterRegistrationRoutes(r *x.RouterPublic) {
 }
 
 func (s *Strategy) PopulateRegistrationMethod(r *http.Request, sr *registration.Flow) error {
-	if sr.Type != flow.TypeBrowser {
-		return nil
-	}
-
 	config, err := s.populateMethod(r, sr.ID)
 	if err != nil {
 		return err
@@ -48,7 +44,7 @@ func (s *Strategy) PopulateRegistrationMethod(r *http.Request, sr *registration.
 	return nil
 }
 
-func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a *registration.Flow, claims *Claims, provider Provider, container *authCodeContainer) {
+func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a *registration.Flow, claims *Claims, provider Provider, container *authCodeContainer, token *oauth2.Token) {
 	if _, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), identity.CredentialsTypeOIDC, uid(provider.Config().ID, claims.Subject)); err == nil {
 		// If the identity already exists, we should perform the login flow instead.
 
@@ -63,34 +59,32 @@ func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a
 			WithField("subject", claims.Subject).
 			Debug("Received successful OpenID Connect callback but user is already registered. Re-initializing login flow now.")
 
-		// This endpoint only handles browser flow at the moment.
-		ar, err := s.d.LoginHandler().NewLoginFlow(w, r, flow.TypeBrowser)
+		ar, err := s.d.LoginHandler().NewLoginFlow(w, r, a.Type)
 		if err != nil {
 			s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
 			return
 		}
 
-		s.processLogin(w, r, ar, claims, provider, container)
-		return
-	}
-
-	jn, err := s.f.Fetch(provider.Config().Mapper)
-	if err != nil {
-		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+		s.processLogin(w, r, ar, claims, provider, container, token)
 		return
 	}
 
-	var jsonClaims bytes.Buffer
-	if err := json.NewEncoder(&jsonClaims).Encode(claims); err != nil {
-		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
-		return
+	if a.Type == flow.TypeBrowser {
+		address, err := s.linkableAddress(r.Context(), claims, provider)
+		if err != nil {
+			s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
+			return
+		} else if address != nil {
+			// An identity with the same verified email address already exists. Instead of failing with a
+			// duplicate credentials error, the user has to sign in to that identity to link the provider.
+			s.initLinkFlow(w, r, a, address, claims, provider, token)
+			return
+		}
 	}
 
 	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
 
-	vm := jsonnet.MakeVM()
-	vm.ExtCode("claims", jsonClaims.String())
-	evaluated, err := vm.EvaluateSnippet(provider.Config().Mapper, jn.String())
+	evaluated, err := strategy.EvaluateMapper(s.f, provider.Config().Mapper, claims)
 	if err != nil {
 		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
 		return
@@ -107,6 +101,14 @@ func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a
 		i.Traits = []byte(traits.Raw)
 	}
 
+	if metadata := gjson.Get(evaluated, "identity.metadata_public"); metadata.Exists() {
+		i.MetadataPublic = []byte(metadata.Raw)
+	}
+
+	if metadata := gjson.Get(evaluated, "identity.metadata_admin"); metadata.Exists() {
+		i.MetadataAdmin = []byte(metadata.Raw)
+	}
+
 	s.d.Logger().
 		WithRequest(r).
 		WithField("oidc_provider", provider.Config().ID).
@@ -121,7 +123,7 @@ func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a
 		return
 	}
 
-	i.Traits, err = merge(container.Form.Encode(), json.RawMessage(i.Traits), option)
+	i.Traits, err = strategy.MergeFormTraits(container.Form.Encode(), json.RawMessage(i.Traits), option)
 	if err != nil {
 		s.handleError(w, r, a.GetID(), provider.Config().ID, nil, err)
 		return
@@ -133,14 +135,24 @@ func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a
 		return
 	}
 
-	creds, err := NewCredentials(provider.Config().ID, claims.Subject)
+	c, err := s.newProviderCredentials(r.Context(), provider.Config().ID, claims.Subject, token)
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.Config().ID, i.Traits, err)
+		return
+	}
+
+	if err := setProviderCredentials(i, *c); err != nil {
+		s.handleError(w, r, a.GetID(), provider.Config().ID, i.Traits, err)
+		return
+	}
+
+	upstream, err := s.upstreamSession(r.Context(), provider, claims, token)
 	if err != nil {
 		s.handleError(w, r, a.GetID(), provider.Config().ID, i.Traits, err)
 		return
 	}
 
-	i.SetCredentials(s.ID(), *creds)
-	if err := s.d.RegistrationExecutor().PostRegistrationHook(w, r, identity.CredentialsTypeOIDC, a, i); err != nil {
+	if err := s.d.RegistrationExecutor().PostRegistrationHook(w, r, identity.CredentialsTypeOIDC, a, i, upstream); err != nil {
 		s.handleError(w, r, a.GetID(), provider.Config().ID, i.Traits, err)
 		return
 	}
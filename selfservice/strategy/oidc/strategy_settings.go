@@ -11,6 +11,7 @@ import (
 	"github.com/gobuffalo/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 
 	"github.com/ory/herodot"
 	"github.com/ory/jsonschema/v3"
@@ -34,6 +35,20 @@ var UnknownConnectionValidationError = &jsonschema.ValidationError{
 var ConnectionExistValidationError = &jsonschema.ValidationError{
 	Message: "can not link unknown or already existing OpenID Connect connection", InstancePtr: "#/"}
 
+type linkedProviderContextKey struct{}
+
+// WithLinkedProvider returns a context which carries the ID of the provider which is being linked. It allows
+// settings hooks to tell which connection was added.
+func WithLinkedProvider(ctx context.Context, provider string) context.Context {
+	return context.WithValue(ctx, linkedProviderContextKey{}, provider)
+}
+
+// LinkedProviderFromContext returns the ID of the provider which is being linked or an empty string.
+func LinkedProviderFromContext(ctx context.Context) string {
+	provider, _ := ctx.Value(linkedProviderContextKey{}).(string)
+	return provider
+}
+
 func (s *Strategy) RegisterSettingsRoutes(router *x.RouterPublic) {
 	wrappedCompleteSettingsFlow := strategy.IsDisabled(s.d, s.SettingsStrategyID(), s.completeSettingsFlow)
 	router.POST(SettingsPath, wrappedCompleteSettingsFlow)
@@ -314,7 +329,7 @@ func (s *Strategy) initLinkProvider(w http.ResponseWriter, r *http.Request, ctxU
 }
 
 func (s *Strategy) linkProvider(w http.ResponseWriter, r *http.Request,
-	ctxUpdate *settings.UpdateContext, claims *Claims, provider Provider) {
+	ctxUpdate *settings.UpdateContext, claims *Claims, provider Provider, token *oauth2.Token) {
 	p := &completeSelfServiceBrowserSettingsOIDCFlowPayload{
 		Link: provider.Config().ID, FlowID: ctxUpdate.Flow.ID.String()}
 	if ctxUpdate.Session.AuthenticatedAt.Add(s.d.Config(r.Context()).SelfServiceFlowSettingsPrivilegedSessionMaxAge()).Before(time.Now()) {
@@ -328,30 +343,18 @@ func (s *Strategy) linkProvider(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	var conf CredentialsConfig
-	creds, err := i.ParseCredentials(s.ID(), &conf)
-	if errors.Is(err, herodot.ErrNotFound) {
-		var err error
-		if creds, err = NewCredentials(provider.Config().ID, claims.Subject); err != nil {
-			s.handleSettingsError(w, r, ctxUpdate, p, err)
-			return
-		}
-	} else if err != nil {
+	c, err := s.newProviderCredentials(r.Context(), provider.Config().ID, claims.Subject, token)
+	if err != nil {
 		s.handleSettingsError(w, r, ctxUpdate, p, err)
 		return
-	} else {
-		creds.Identifiers = append(creds.Identifiers, uid(provider.Config().ID, claims.Subject))
-		conf.Providers = append(conf.Providers, ProviderCredentialsConfig{
-			Subject: claims.Subject, Provider: provider.Config().ID})
-		creds.Config, err = json.Marshal(conf)
-		if err != nil {
-			s.handleSettingsError(w, r, ctxUpdate, p, err)
-			return
-		}
 	}
 
-	i.Credentials[s.ID()] = *creds
-	if err := s.d.SettingsHookExecutor().PostSettingsHook(w, r, s.SettingsStrategyID(), ctxUpdate, i, settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
+	if err := setProviderCredentials(i, *c); err != nil {
+		s.handleSettingsError(w, r, ctxUpdate, p, err)
+		return
+	}
+
+	if err := s.d.SettingsHookExecutor().PostSettingsHook(w, r.WithContext(WithLinkedProvider(r.Context(), provider.Config().ID)), s.SettingsStrategyID(), ctxUpdate, i, settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
 		return s.PopulateSettingsMethod(r, ctxUpdate.Session.Identity, ctxUpdate.Flow)
 	})); err != nil {
 		s.handleSettingsError(w, r, ctxUpdate, p, err)
@@ -55,7 +55,7 @@ func TestSettingsStrategy(t *testing.T) {
 	)
 
 	remoteAdmin, remotePublic, _ := newHydra(t, &subject, &scope)
-	uiTS := newUI(t, reg)
+	uiTS := testhelpers.NewUIFlowEchoServer(t, reg)
 	errTS := testhelpers.NewErrorTestServer(t, reg)
 	publicTS, adminTS := testhelpers.NewKratosServers(t)
 	public := testhelpers.NewSDKClient(publicTS)
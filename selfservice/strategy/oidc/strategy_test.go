@@ -49,7 +49,7 @@ func TestStrategy(t *testing.T) {
 
 	remoteAdmin, remotePublic, hydraIntegrationTSURL := newHydra(t, &subject, &scope)
 	returnTS := newReturnTs(t, reg)
-	uiTS := newUI(t, reg)
+	uiTS := testhelpers.NewUIFlowEchoServer(t, reg)
 	errTS := testhelpers.NewErrorTestServer(t, reg)
 	ts, tsA := testhelpers.NewKratosServers(t)
 
@@ -623,3 +623,57 @@ func TestDisabledEndpoint(t *testing.T) {
 		})
 	})
 }
+
+func TestFormPostCallback(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.StrategyEnable(t, conf, identity.CredentialsTypeOIDC.String(), true)
+
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+	callback := publicTS.URL + strings.Replace(oidc.RouteCallback, ":provider", "apple", 1)
+
+	c := testhelpers.NewClientWithCookies(t)
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	res, err := c.PostForm(callback, url.Values{"code": {"code"}, "state": {"state"}, "user": {`{"name":{"firstName":"Foo"}}`}})
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusSeeOther, res.StatusCode)
+
+	location, err := url.Parse(res.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, callback, location.Scheme+"://"+location.Host+location.Path)
+	assert.Equal(t, "code", location.Query().Get("code"))
+	assert.Equal(t, "state", location.Query().Get("state"))
+	assert.Equal(t, `{"name":{"firstName":"Foo"}}`, location.Query().Get("user"))
+}
+
+func TestBackChannelLogout(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	viperSetProviderConfig(t, conf,
+		oidc.Configuration{Provider: "generic", ID: "enabled", ClientID: "client", ClientSecret: "secret", IssuerURL: "https://ory.sh/", BackChannelLogout: true},
+		oidc.Configuration{Provider: "generic", ID: "disabled", ClientID: "client", ClientSecret: "secret", IssuerURL: "https://ory.sh/"},
+	)
+
+	publicTS, _ := testhelpers.NewKratosServerWithCSRF(t, reg)
+	logout := func(t *testing.T, provider string, values url.Values) *http.Response {
+		res, err := publicTS.Client().PostForm(publicTS.URL+strings.Replace(oidc.RouteBackChannelLogout, ":provider", provider, 1), values)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+		return res
+	}
+
+	t.Run("case=should fail if back-channel logout is disabled for the provider", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, logout(t, "disabled", url.Values{"logout_token": {"token"}}).StatusCode)
+	})
+
+	t.Run("case=should fail for an unknown provider", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, logout(t, "unknown", url.Values{"logout_token": {"token"}}).StatusCode)
+	})
+
+	t.Run("case=should fail without a logout token", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, logout(t, "enabled", url.Values{}).StatusCode)
+	})
+}
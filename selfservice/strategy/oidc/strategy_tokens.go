@@ -0,0 +1,316 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteAdminTokens = "/identities/:id/credentials/oidc/tokens"
+)
+
+var _ login.AdminHandler = new(Strategy)
+
+func (s *Strategy) RegisterAdminLoginRoutes(admin *x.RouterAdmin) {
+	wrappedGetIdentityOIDCTokens := strategy.IsDisabled(s.d, s.ID().String(), s.getIdentityOIDCTokens)
+	admin.GET(RouteAdminTokens, wrappedGetIdentityOIDCTokens)
+
+	s.registerAdminProviderRoutes(admin)
+}
+
+// setProviderCredentials adds the provider's subject to the identity's OpenID Connect credentials or, if the
+// subject is already linked, replaces its upstream OAuth2 token.
+func setProviderCredentials(i *identity.Identity, c ProviderCredentialsConfig) error {
+	var conf CredentialsConfig
+	creds, err := i.ParseCredentials(identity.CredentialsTypeOIDC, &conf)
+	if errors.Is(err, herodot.ErrNotFound) {
+		creds = &identity.Credentials{Type: identity.CredentialsTypeOIDC}
+	} else if err != nil {
+		return err
+	}
+
+	var found bool
+	for k, p := range conf.Providers {
+		if p.Provider == c.Provider && p.Subject == c.Subject {
+			conf.Providers[k] = c
+			found = true
+		}
+	}
+
+	if !found {
+		conf.Providers = append(conf.Providers, c)
+		creds.Identifiers = append(creds.Identifiers, uid(c.Provider, c.Subject))
+	}
+
+	if creds.Config, err = json.Marshal(conf); err != nil {
+		return errors.WithStack(err)
+	}
+
+	i.SetCredentials(identity.CredentialsTypeOIDC, *creds)
+	return nil
+}
+
+// newProviderCredentials returns the credentials of the provider's subject. The upstream OAuth2 token, if any,
+// is encrypted using the cipher secrets.
+func (s *Strategy) newProviderCredentials(ctx context.Context, provider, subject string, token *oauth2.Token) (*ProviderCredentialsConfig, error) {
+	c := &ProviderCredentialsConfig{Subject: subject, Provider: provider}
+	if token == nil {
+		return c, nil
+	}
+
+	if err := s.encryptToken(ctx, c, token); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *Strategy) encryptToken(ctx context.Context, c *ProviderCredentialsConfig, token *oauth2.Token) error {
+	accessToken, err := s.d.Cipher().Encrypt(ctx, []byte(token.AccessToken))
+	if err != nil {
+		return err
+	}
+	c.AccessToken = accessToken
+
+	// Providers do not always issue a new refresh token when the access token is refreshed, in which case
+	// we keep the existing one.
+	if len(token.RefreshToken) > 0 {
+		refreshToken, err := s.d.Cipher().Encrypt(ctx, []byte(token.RefreshToken))
+		if err != nil {
+			return err
+		}
+		c.RefreshToken = refreshToken
+	}
+
+	c.TokenType = token.TokenType
+	c.Expiry = nil
+	if !token.Expiry.IsZero() {
+		expiry := token.Expiry.UTC()
+		c.Expiry = &expiry
+	}
+
+	return nil
+}
+
+func (s *Strategy) decryptToken(ctx context.Context, c ProviderCredentialsConfig) (*oauth2.Token, error) {
+	accessToken, err := s.d.Cipher().Decrypt(ctx, c.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.d.Cipher().Decrypt(ctx, c.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  string(accessToken),
+		RefreshToken: string(refreshToken),
+		TokenType:    c.TokenType,
+	}
+	if c.Expiry != nil {
+		token.Expiry = *c.Expiry
+	}
+
+	return token, nil
+}
+
+// upstreamSession returns the option which remembers the provider's session the ORY Kratos session is created
+// from. The ID token, if any, is encrypted using the cipher secrets and used as a hint when ending the provider's
+// session on logout.
+func (s *Strategy) upstreamSession(ctx context.Context, provider Provider, claims *Claims, token *oauth2.Token) (session.Option, error) {
+	var idToken string
+	if token != nil {
+		if raw, ok := token.Extra("id_token").(string); ok && len(raw) > 0 {
+			encrypted, err := s.d.Cipher().Encrypt(ctx, []byte(raw))
+			if err != nil {
+				return nil, err
+			}
+			idToken = encrypted
+		}
+	}
+
+	return session.WithUpstream(provider.Config().ID, claims.Subject, claims.SessionID, idToken), nil
+}
+
+// updateToken stores the upstream OAuth2 token which was issued when the identity signed in again.
+func (s *Strategy) updateToken(ctx context.Context, id uuid.UUID, c ProviderCredentialsConfig, token *oauth2.Token) error {
+	if token == nil {
+		return nil
+	}
+
+	if err := s.encryptToken(ctx, &c, token); err != nil {
+		return err
+	}
+
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := setProviderCredentials(i, c); err != nil {
+		return err
+	}
+
+	return s.d.PrivilegedIdentityPool().UpdateIdentity(ctx, i)
+}
+
+// ProviderToken is the upstream OAuth2 token of an OpenID Connect Provider which is linked to an identity.
+//
+// swagger:model oidcProviderToken
+type ProviderToken struct {
+	// Provider is the ID of the OpenID Connect Provider.
+	//
+	// required: true
+	Provider string `json:"provider"`
+
+	// Subject is the identity's subject at the OpenID Connect Provider.
+	//
+	// required: true
+	Subject string `json:"subject"`
+
+	// AccessToken is the upstream OAuth2 access token. It is empty if the provider did not issue one,
+	// for example when signing in with an ID token.
+	AccessToken string `json:"access_token,omitempty"`
+
+	// TokenType is the type of the access token, typically "bearer".
+	TokenType string `json:"token_type,omitempty"`
+
+	// Expiry is the time at which the access token expires. It is empty if the access token does not expire.
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// swagger:parameters getIdentityOIDCTokens
+// nolint:deadcode,unused
+type getIdentityOIDCTokensParameters struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// A list of upstream OAuth2 tokens.
+//
+// swagger:response oidcProviderTokens
+// nolint:deadcode,unused
+type oidcProviderTokensResponse struct {
+	// in: body
+	// required: true
+	Body []ProviderToken
+}
+
+// swagger:route GET /identities/{id}/credentials/oidc/tokens admin getIdentityOIDCTokens
+//
+// Get the Upstream OAuth2 Tokens of an Identity
+//
+// This endpoint returns the upstream OAuth2 tokens of all OpenID Connect Providers which are linked to the identity,
+// for example to call the GitHub or Google APIs on behalf of the user. Expired access tokens are refreshed if the
+// provider issued a refresh token. Refresh tokens are never returned.
+//
+// Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       200: oidcProviderTokens
+//       404: genericError
+//       500: genericError
+func (s *Strategy) getIdentityOIDCTokens(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var conf CredentialsConfig
+	if _, err := i.ParseCredentials(s.ID(), &conf); errors.Is(err, herodot.ErrNotFound) {
+		s.d.Writer().Write(w, r, []ProviderToken{})
+		return
+	} else if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	var refreshed bool
+	tokens := make([]ProviderToken, len(conf.Providers))
+	for k, c := range conf.Providers {
+		token, err := s.decryptToken(r.Context(), c)
+		if err != nil {
+			s.d.Writer().WriteError(w, r, err)
+			return
+		}
+
+		if len(token.RefreshToken) > 0 && !token.Valid() {
+			if token, err = s.refreshToken(r, c.Provider, token); err != nil {
+				s.d.Writer().WriteError(w, r, err)
+				return
+			}
+
+			if err := s.encryptToken(r.Context(), &c, token); err != nil {
+				s.d.Writer().WriteError(w, r, err)
+				return
+			}
+
+			if err := setProviderCredentials(i, c); err != nil {
+				s.d.Writer().WriteError(w, r, err)
+				return
+			}
+			refreshed = true
+		}
+
+		tokens[k] = ProviderToken{
+			Provider:    c.Provider,
+			Subject:     c.Subject,
+			AccessToken: token.AccessToken,
+			TokenType:   token.TokenType,
+			Expiry:      c.Expiry,
+		}
+	}
+
+	if refreshed {
+		if err := s.d.PrivilegedIdentityPool().UpdateIdentity(r.Context(), i); err != nil {
+			s.d.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+
+	s.d.Writer().Write(w, r, tokens)
+}
+
+func (s *Strategy) refreshToken(r *http.Request, pid string, token *oauth2.Token) (*oauth2.Token, error) {
+	provider, err := s.provider(r.Context(), r, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := provider.OAuth2(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed, err := config.TokenSource(r.Context(), token).Token()
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to refresh the upstream OAuth2 token of OpenID Connect Provider \"%s\": %s", pid, err))
+	}
+
+	return refreshed, nil
+}
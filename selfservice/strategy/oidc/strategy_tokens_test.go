@@ -0,0 +1,158 @@
+package oidc_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/selfservice/strategy/oidc"
+	"github.com/ory/kratos/x"
+)
+
+func TestStrategyTokens(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	issuer, _, authorize := newIDTokenIssuer(t)
+
+	viperSetProviderConfig(
+		t,
+		conf,
+		oidc.Configuration{
+			Provider:     "generic",
+			ID:           "valid",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			IssuerURL:    issuer.URL,
+			Mapper:       "file://./stub/oidc.hydra.jsonnet",
+		},
+	)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/registration.schema.json")
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeOIDC.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	_ = testhelpers.NewUIFlowEchoServer(t, reg)
+	returnTS := newReturnTs(t, reg)
+	_ = testhelpers.NewErrorTestServer(t, reg)
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
+
+	var signIn = func(t *testing.T, subject string) string {
+		authorize(jwt.MapClaims{"sub": subject})
+		client := newClient(t, nil)
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false).Payload
+
+		res, err := client.PostForm(publicTS.URL+oidc.RouteBase+"/auth/"+string(*f.ID), url.Values{"provider": {"valid"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+		return gjson.GetBytes(body, "identity.id").String()
+	}
+
+	var getTokens = func(t *testing.T, id string, expectedStatusCode int) gjson.Result {
+		res, err := http.Get(adminTS.URL + strings.Replace(oidc.RouteAdminTokens, ":id", id, 1))
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectedStatusCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	var storedCredentials = func(t *testing.T, id string) (*identity.Identity, *oidc.CredentialsConfig) {
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+		require.NoError(t, err)
+
+		var c oidc.CredentialsConfig
+		_, err = i.ParseCredentials(identity.CredentialsTypeOIDC, &c)
+		require.NoError(t, err)
+		require.Len(t, c.Providers, 1)
+		return i, &c
+	}
+
+	subject := x.NewUUID().String() + "@ory.sh"
+	id := signIn(t, subject)
+
+	t.Run("case=should store the upstream tokens encrypted", func(t *testing.T) {
+		_, c := storedCredentials(t, id)
+		p := c.Providers[0]
+
+		assert.NotEmpty(t, p.AccessToken)
+		assert.NotContains(t, p.AccessToken, "access-token")
+		assert.NotEmpty(t, p.RefreshToken)
+		assert.NotContains(t, p.RefreshToken, "refresh-token")
+		assert.Equal(t, "bearer", p.TokenType)
+		require.NotNil(t, p.Expiry)
+		assert.True(t, p.Expiry.After(time.Now()))
+	})
+
+	t.Run("case=should return the access token", func(t *testing.T) {
+		tokens := getTokens(t, id, http.StatusOK)
+		require.Len(t, tokens.Array(), 1, "%s", tokens.Raw)
+		assert.Equal(t, "valid", tokens.Get("0.provider").String())
+		assert.Equal(t, subject, tokens.Get("0.subject").String())
+		assert.Regexp(t, `^access-token-\d+$`, tokens.Get("0.access_token").String())
+		assert.Equal(t, "bearer", tokens.Get("0.token_type").String())
+		assert.False(t, tokens.Get("0.refresh_token").Exists(), "%s", tokens.Raw)
+
+		assert.Equal(t, tokens.Get("0.access_token").String(), getTokens(t, id, http.StatusOK).Get("0.access_token").String(),
+			"valid access tokens must not be refreshed")
+	})
+
+	t.Run("case=should update the tokens when signing in again", func(t *testing.T) {
+		before := getTokens(t, id, http.StatusOK).Get("0.access_token").String()
+		assert.Equal(t, id, signIn(t, subject))
+		assert.NotEqual(t, before, getTokens(t, id, http.StatusOK).Get("0.access_token").String())
+	})
+
+	t.Run("case=should refresh expired access tokens", func(t *testing.T) {
+		expiredToken := getTokens(t, id, http.StatusOK).Get("0.access_token").String()
+
+		i, c := storedCredentials(t, id)
+		expired := time.Now().Add(-time.Minute).UTC()
+		c.Providers[0].Expiry = &expired
+
+		creds := i.Credentials[identity.CredentialsTypeOIDC]
+		var err error
+		creds.Config, err = json.Marshal(c)
+		require.NoError(t, err)
+		i.SetCredentials(identity.CredentialsTypeOIDC, creds)
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(ctx, i))
+
+		before := getTokens(t, id, http.StatusOK)
+		_, c = storedCredentials(t, id)
+		after := getTokens(t, id, http.StatusOK)
+
+		assert.NotEqual(t, expiredToken, before.Get("0.access_token").String())
+		assert.Equal(t, before.Get("0.access_token").String(), after.Get("0.access_token").String())
+		assert.True(t, c.Providers[0].Expiry.After(time.Now()))
+		assert.NotEmpty(t, c.Providers[0].RefreshToken, "the refresh token must be kept if no new one was issued")
+	})
+
+	t.Run("case=should return an empty list if no provider is linked", func(t *testing.T) {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"subject":"` + x.NewUUID().String() + `@ory.sh"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		assert.Equal(t, "[]", getTokens(t, i.ID.String(), http.StatusOK).Raw)
+	})
+
+	t.Run("case=should return 404 for unknown identities", func(t *testing.T) {
+		getTokens(t, x.NewUUID().String(), http.StatusNotFound)
+	})
+}
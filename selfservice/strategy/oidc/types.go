@@ -3,6 +3,7 @@ package oidc
 import (
 	"bytes"
 	"encoding/json"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
@@ -35,6 +36,12 @@ func NewCredentials(provider, subject string) (*identity.Credentials, error) {
 type ProviderCredentialsConfig struct {
 	Subject  string `json:"subject"`
 	Provider string `json:"provider"`
+
+	// AccessToken and RefreshToken are the upstream OAuth2 tokens, encrypted using the cipher secrets.
+	AccessToken  string     `json:"access_token,omitempty"`
+	RefreshToken string     `json:"refresh_token,omitempty"`
+	TokenType    string     `json:"token_type,omitempty"`
+	Expiry       *time.Time `json:"expiry,omitempty"`
 }
 
 type FlowMethod struct {
@@ -0,0 +1,204 @@
+package password
+
+import (
+	"bufio"
+	/* #nosec G505 sha1 is used for k-anonymity */
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+// A breached passwords dataset is a local copy of the Have I Been Pwned range API. It is a directory containing one
+// file per five character prefix of the SHA-1 hashes (e.g. `21BD1.txt`). Each file uses the format of the range API
+// and contains one `<remaining 35 characters of the hash>:<count>` line per breached password.
+const breachedDatasetPrefixLength = 5
+
+var breachedHashPattern = regexp.MustCompile("^[0-9A-F]{40}$")
+
+// BreachedPasswordHash returns the upper case hex encoded SHA-1 hash of the password which is used to look up
+// the password in a breached passwords dataset.
+func BreachedPasswordHash(password string) string {
+	/* #nosec G401 sha1 is used for k-anonymity */
+	h := sha1.Sum([]byte(password))
+	return b20(h[:])
+}
+
+func breachedDatasetRangeFile(dataset, prefix string) string {
+	return filepath.Join(dataset, prefix+".txt")
+}
+
+// lookupBreachedDataset returns how often the hex encoded SHA-1 hash was breached according to the dataset. Ranges
+// without any breached passwords may be left out of the dataset.
+func lookupBreachedDataset(dataset, hash string) (int64, error) {
+	if fi, err := os.Stat(dataset); err != nil {
+		return 0, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to open the breached passwords dataset: %s", err))
+	} else if !fi.IsDir() {
+		return 0, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("The breached passwords dataset %s is not a directory.", dataset))
+	}
+
+	f, err := os.Open(breachedDatasetRangeFile(dataset, hash[:breachedDatasetPrefixLength]))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to open the breached passwords dataset: %s", err))
+	}
+	defer f.Close()
+
+	suffix := hash[breachedDatasetPrefixLength:]
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		row := sc.Text()
+		if !strings.HasPrefix(row, suffix+":") {
+			continue
+		}
+
+		count, err := strconv.ParseInt(strings.TrimSpace(row[len(suffix)+1:]), 10, 64)
+		if err != nil {
+			return 0, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Expected password hash to contain a count formatted as int but got: %s", row))
+		}
+		return count, nil
+	}
+
+	if err := sc.Err(); err != nil {
+		return 0, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to read the breached passwords dataset: %s", err))
+	}
+
+	return 0, nil
+}
+
+// BreachedDatasetWriter writes a breached passwords dataset. Hashes should be added in the order of their prefixes,
+// as for example in the files of the Have I Been Pwned password downloads ordered by hash, because the range file is
+// reopened whenever the prefix changes.
+type BreachedDatasetWriter struct {
+	dir    string
+	prefix string
+	f      *os.File
+	w      *bufio.Writer
+}
+
+// NewBreachedDatasetWriter creates the dataset directory. It fails if the directory exists and is not empty
+// because adding the same hashes twice would break the dataset.
+func NewBreachedDatasetWriter(dir string) (*BreachedDatasetWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if len(files) > 0 {
+		return nil, errors.Errorf("the directory %s must be empty", dir)
+	}
+
+	return &BreachedDatasetWriter{dir: dir}, nil
+}
+
+// Add adds an upper or lower case hex encoded SHA-1 hash and how often it was breached.
+func (d *BreachedDatasetWriter) Add(hash string, count int64) error {
+	hash = strings.ToUpper(hash)
+	if !breachedHashPattern.MatchString(hash) {
+		return errors.Errorf("expected a hex encoded SHA-1 hash but got: %s", hash)
+	} else if count < 1 {
+		return errors.Errorf("expected the hash %s to have been breached at least once but got: %d", hash, count)
+	}
+
+	if prefix := hash[:breachedDatasetPrefixLength]; prefix != d.prefix {
+		if err := d.Close(); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(breachedDatasetRangeFile(d.dir, prefix), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		d.prefix, d.f, d.w = prefix, f, bufio.NewWriter(f)
+	}
+
+	_, err := fmt.Fprintf(d.w, "%s:%d\n", hash[breachedDatasetPrefixLength:], count)
+	return errors.WithStack(err)
+}
+
+// Close flushes and closes the current range file.
+func (d *BreachedDatasetWriter) Close() error {
+	if d.f == nil {
+		return nil
+	}
+
+	f := d.f
+	d.prefix, d.f = "", nil
+	if err := d.w.Flush(); err != nil {
+		_ = f.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(f.Close())
+}
+
+// ImportBreachedHashes adds all `<hash>:<count>` lines, as used by the Have I Been Pwned password downloads, to the
+// dataset. Lines without a count are counted as breached once. It returns the number of imported hashes.
+func ImportBreachedHashes(d *BreachedDatasetWriter, r io.Reader) (int, error) {
+	var n, line int
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+		row := strings.TrimSpace(sc.Text())
+		if len(row) == 0 {
+			continue
+		}
+
+		var count int64 = 1
+		parts := strings.SplitN(row, ":", 2)
+		if len(parts) == 2 {
+			var err error
+			if count, err = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64); err != nil {
+				return n, errors.Errorf("expected line %d to contain a count formatted as int but got: %s", line, parts[1])
+			}
+		}
+
+		if err := d.Add(parts[0], count); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, errors.WithStack(sc.Err())
+}
+
+// ImportBreachedPasswords adds the plaintext passwords, one per line, to the dataset. Passwords which are listed
+// multiple times are counted as breached multiple times. It returns the number of imported hashes.
+func ImportBreachedPasswords(d *BreachedDatasetWriter, r io.Reader) (int, error) {
+	counts := map[string]int64{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if password := strings.TrimRight(sc.Text(), "\r"); len(password) > 0 {
+			counts[BreachedPasswordHash(password)]++
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	hashes := make([]string, 0, len(counts))
+	for hash := range counts {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	for k, hash := range hashes {
+		if err := d.Add(hash, counts[hash]); err != nil {
+			return k, err
+		}
+	}
+
+	return len(hashes), nil
+}
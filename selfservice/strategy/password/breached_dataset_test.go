@@ -0,0 +1,117 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/text"
+)
+
+func TestBreachedDataset(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
+
+	s := password.NewDefaultPasswordValidatorStrategy(reg)
+	fakeClient := NewFakeHTTPClient()
+	fakeClient.RespondWithError("Network request failed")
+	s.Client = &fakeClient.Client
+
+	var expectBreached = func(t *testing.T, pw string) {
+		var violation *password.PolicyViolationError
+		err := s.Validate(context.Background(), "", pw)
+		require.True(t, errors.As(err, &violation), "%+v", err)
+		assert.Equal(t, text.ErrorValidationPasswordBreached, violation.Message.ID)
+	}
+
+	var newDataset = func(t *testing.T, importer func(*password.BreachedDatasetWriter) (int, error), expected int) string {
+		dir := filepath.Join(t.TempDir(), "dataset")
+		d, err := password.NewBreachedDatasetWriter(dir)
+		require.NoError(t, err)
+
+		n, err := importer(d)
+		require.NoError(t, err)
+		require.NoError(t, d.Close())
+		assert.Equal(t, expected, n)
+
+		conf.MustSet(config.ViperKeyPasswordHaveIBeenPwnedDataset, dir)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPasswordHaveIBeenPwnedDataset, nil)
+		})
+		return dir
+	}
+
+	t.Run("case=should check hashes imported from the downloads", func(t *testing.T) {
+		dir := newDataset(t, func(d *password.BreachedDatasetWriter) (int, error) {
+			return password.ImportBreachedHashes(d, strings.NewReader(strings.Join([]string{
+				password.BreachedPasswordHash("bupzizhoka") + ":3",
+				"",
+				strings.ToLower(password.BreachedPasswordHash("nufhekoted")),
+			}, "\n")))
+		}, 2)
+
+		expectBreached(t, "bupzizhoka")
+		expectBreached(t, "nufhekoted")
+		require.NoError(t, s.Validate(context.Background(), "", "ruvbakfone"))
+		assert.Empty(t, fakeClient.RequestedURLs())
+
+		prefix := password.BreachedPasswordHash("bupzizhoka")[:5]
+		actual, err := ioutil.ReadFile(filepath.Join(dir, prefix+".txt"))
+		require.NoError(t, err)
+		assert.Equal(t, password.BreachedPasswordHash("bupzizhoka")[5:]+":3\n", string(actual))
+	})
+
+	t.Run("case=should respect the allowed number of breaches", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyPasswordMaxBreaches, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPasswordMaxBreaches, 0)
+		})
+
+		newDataset(t, func(d *password.BreachedDatasetWriter) (int, error) {
+			return password.ImportBreachedPasswords(d, strings.NewReader("cufmebgiwa\r\nrabkotezpa\ncufmebgiwa\ncufmebgiwa\n"))
+		}, 2)
+
+		expectBreached(t, "cufmebgiwa")
+		require.NoError(t, s.Validate(context.Background(), "", "rabkotezpa"))
+	})
+
+	t.Run("case=should fail if the dataset does not exist", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyIgnoreNetworkErrors, true)
+		conf.MustSet(config.ViperKeyPasswordHaveIBeenPwnedDataset, filepath.Join(t.TempDir(), "does-not-exist"))
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
+			conf.MustSet(config.ViperKeyPasswordHaveIBeenPwnedDataset, nil)
+		})
+
+		err := s.Validate(context.Background(), "", "hobtaszeru")
+		require.Error(t, err)
+		var violation *password.PolicyViolationError
+		assert.False(t, errors.As(err, &violation))
+	})
+
+	t.Run("case=should reject invalid hashes and non-empty directories", func(t *testing.T) {
+		dir := t.TempDir()
+		d, err := password.NewBreachedDatasetWriter(dir)
+		require.NoError(t, err)
+
+		_, err = password.ImportBreachedHashes(d, strings.NewReader("not-a-hash:1"))
+		require.Error(t, err)
+		_, err = password.ImportBreachedHashes(d, strings.NewReader(password.BreachedPasswordHash("a")+":many"))
+		require.Error(t, err)
+
+		require.NoError(t, d.Add(password.BreachedPasswordHash("a"), 1))
+		require.NoError(t, d.Close())
+
+		_, err = password.NewBreachedDatasetWriter(dir)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,29 @@
+package password
+
+import (
+	"context"
+	"time"
+
+	"github.com/ory/kratos/selfservice/flow/settings"
+)
+
+// expired returns true if an administrator expired the password or if the password is older than maxAge. A
+// maxAge of zero disables expiring passwords because of their age.
+func (c *CredentialsConfig) expired(maxAge time.Duration) bool {
+	if c.ChangeRequired {
+		return true
+	}
+
+	return maxAge > 0 && c.ChangedAt != nil && c.ChangedAt.Add(maxAge).Before(time.Now())
+}
+
+// clearPasswordChangeRequired is called after the password was changed and allows all sessions of the identity
+// which were created using the expired password to be used again.
+func (s *Strategy) clearPasswordChangeRequired(ctx context.Context, ctxUpdate *settings.UpdateContext) error {
+	if err := s.d.SessionPersister().ClearPasswordChangeRequired(ctx, ctxUpdate.Session.IdentityID); err != nil {
+		return err
+	}
+
+	ctxUpdate.Session.PasswordChangeRequired = false
+	return nil
+}
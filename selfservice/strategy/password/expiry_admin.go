@@ -0,0 +1,88 @@
+package password
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteAdminExpirePassword = "/identities/:id/password/expire"
+)
+
+// swagger:parameters expirePassword
+// nolint:deadcode,unused
+type expirePasswordParameters struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route POST /identities/{id}/password/expire admin expirePassword
+//
+// Expire an Identity's Password
+//
+// Calling this endpoint expires the password of the identity given its ID. The next time the identity signs in
+// using the password, it must change the password using the settings flow before the session can be used.
+// Existing sessions are not affected.
+//
+// Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       204: emptyResponse
+//       404: genericError
+//       500: genericError
+func (s *Strategy) expirePassword(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	c, ok := i.GetCredentials(identity.CredentialsTypePassword)
+	if !ok {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrNotFound.WithReason("The identity does not have a password.")))
+		return
+	}
+
+	var o CredentialsConfig
+	if err := json.Unmarshal(c.Config, &o); err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrInternalServerError.WithReason("The password credentials could not be decoded properly").WithDebug(err.Error())))
+		return
+	}
+
+	o.ChangeRequired = true
+	if c.Config, err = json.Marshal(&o); err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode password options to JSON: %s", err)))
+		return
+	}
+
+	i.SetCredentials(identity.CredentialsTypePassword, *c)
+	if err := s.d.PrivilegedIdentityPool().UpdateIdentity(r.Context(), i); err != nil {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, i.ID, err))
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+	s.d.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, i.ID, nil))
+
+	s.d.Audit().
+		WithField("identity_id", i.ID).
+		Info("The identity's password has been expired.")
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,54 @@
+package password
+
+import (
+	"context"
+
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/text"
+)
+
+// validatePasswordHistory returns an error if the password matches the current password or one of the previous
+// passwords which are kept in the password history.
+func (s *Strategy) validatePasswordHistory(ctx context.Context, current *CredentialsConfig, password string) error {
+	size := s.d.Config(ctx).PasswordPolicyConfig().HistorySize
+	if size == 0 {
+		return nil
+	}
+
+	for _, hpw := range current.history(size) {
+		if err := s.d.Hasher().Compare(ctx, []byte(password), []byte(hpw)); err == nil {
+			return schema.NewPasswordPolicyRuleViolationError("#/password", text.NewErrorValidationPasswordReused(size))
+		}
+	}
+
+	return nil
+}
+
+// history returns the current and the previous password hashes, limited to the given size.
+func (c *CredentialsConfig) history(size int) []string {
+	var hashes []string
+	if len(c.HashedPassword) > 0 {
+		hashes = append(hashes, c.HashedPassword)
+	}
+	hashes = append(hashes, c.PreviousHashedPasswords...)
+
+	if len(hashes) > size {
+		hashes = hashes[:size]
+	}
+	return hashes
+}
+
+// rollPasswordHistory returns the credentials config for the new password hash. The current password hash is
+// moved to the previous password hashes, which are pruned so that together with the new password hash at most
+// `history_size` hashes are kept.
+func (s *Strategy) rollPasswordHistory(ctx context.Context, current *CredentialsConfig, hpw []byte) *CredentialsConfig {
+	size := s.d.Config(ctx).PasswordPolicyConfig().HistorySize
+	if size <= 1 {
+		return &CredentialsConfig{HashedPassword: string(hpw)}
+	}
+
+	return &CredentialsConfig{
+		HashedPassword:          string(hpw),
+		PreviousHashedPasswords: current.history(size - 1),
+	}
+}
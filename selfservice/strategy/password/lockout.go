@@ -0,0 +1,132 @@
+package password
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/x"
+)
+
+// LoginAttempt is a failed login attempt using the password method.
+//
+// Attempts are tracked per identifier regardless of whether an identity with that identifier
+// exists, so that the lockout does not reveal which accounts exist.
+type LoginAttempt struct {
+	// ID represents the attempt's unique ID.
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// Identifier is the (lowercased) identifier which was used in the login attempt.
+	Identifier string `json:"identifier" db:"identifier"`
+
+	// IPAddress is the IP address from which the login attempt was made.
+	IPAddress string `json:"ip_address" db:"ip_address"`
+
+	// CreatedAt is the time (UTC) when the login attempt was made.
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+}
+
+func (LoginAttempt) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "selfservice_login_attempts")
+}
+
+func NewLoginAttempt(identifier, ip string) *LoginAttempt {
+	return &LoginAttempt{
+		ID:         x.NewUUID(),
+		Identifier: normalizeIdentifier(identifier),
+		IPAddress:  ip,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+func normalizeIdentifier(identifier string) string {
+	return strings.ToLower(identifier)
+}
+
+// checkLockout returns an error if login attempts for the given identifier or from the given IP address
+// are currently blocked because of too many failed login attempts.
+func (s *Strategy) checkLockout(ctx context.Context, identifier, ip string) error {
+	c := s.d.Config(ctx).PasswordLockoutConfig()
+	if !c.Enabled {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	since := now.Add(-c.Duration)
+
+	attempts, err := s.d.LoginAttemptPersister().ListLoginAttemptsByIdentifier(ctx, normalizeIdentifier(identifier), since, c.MaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	if len(attempts) > 0 {
+		last := attempts[0].CreatedAt
+		if len(attempts) >= c.MaxAttempts {
+			return errors.WithStack(schema.NewLoginLockedOutError(last.Add(c.Duration)))
+		}
+
+		if c.BaseDelay > 0 {
+			if until := last.Add(loginDelay(c.BaseDelay, c.Duration, len(attempts))); until.After(now) {
+				return errors.WithStack(schema.NewLoginLockedOutError(until))
+			}
+		}
+	}
+
+	if c.MaxAttemptsPerIP > 0 {
+		attempts, err := s.d.LoginAttemptPersister().ListLoginAttemptsByIPAddress(ctx, ip, since, c.MaxAttemptsPerIP)
+		if err != nil {
+			return err
+		}
+
+		if len(attempts) >= c.MaxAttemptsPerIP {
+			return errors.WithStack(schema.NewLoginLockedOutError(attempts[0].CreatedAt.Add(c.Duration)))
+		}
+	}
+
+	return nil
+}
+
+// loginDelay returns the delay after the given number of failed attempts. The delay doubles with
+// every failed attempt (base, 2*base, 4*base, ...) but never exceeds max.
+func loginDelay(base, max time.Duration, failed int) time.Duration {
+	delay := base
+	for i := 1; i < failed && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// registerFailedLogin records a failed login attempt for the given identifier and IP address.
+func (s *Strategy) registerFailedLogin(ctx context.Context, identifier, ip string) error {
+	if !s.d.Config(ctx).PasswordLockoutConfig().Enabled {
+		return nil
+	}
+
+	return s.d.LoginAttemptPersister().CreateLoginAttempt(ctx, NewLoginAttempt(identifier, ip))
+}
+
+// resetLockout forgets all failed login attempts for the given identifiers. Failed attempts per IP address
+// are kept as otherwise an attacker could reset the IP address lockout by signing into their own account.
+func (s *Strategy) resetLockout(ctx context.Context, identifiers ...string) error {
+	if !s.d.Config(ctx).PasswordLockoutConfig().Enabled || len(identifiers) == 0 {
+		return nil
+	}
+
+	normalized := make([]string, len(identifiers))
+	for k, identifier := range identifiers {
+		normalized[k] = normalizeIdentifier(identifier)
+	}
+
+	return s.d.LoginAttemptPersister().DeleteLoginAttemptsByIdentifier(ctx, normalized...)
+}
@@ -0,0 +1,76 @@
+package password
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteAdminLockout = "/identities/:id/lockout"
+)
+
+func (s *Strategy) RegisterAdminLoginRoutes(admin *x.RouterAdmin) {
+	wrappedUnlockIdentity := strategy.IsDisabled(s.d, s.ID().String(), s.unlockIdentity)
+	admin.DELETE(RouteAdminLockout, wrappedUnlockIdentity)
+
+	wrappedExpirePassword := strategy.IsDisabled(s.d, s.ID().String(), s.expirePassword)
+	admin.POST(RouteAdminExpirePassword, wrappedExpirePassword)
+}
+
+// swagger:parameters unlockIdentity
+// nolint:deadcode,unused
+type unlockIdentityParameters struct {
+	// ID is the identity's ID.
+	//
+	// required: true
+	// in: path
+	ID string `json:"id"`
+}
+
+// swagger:route DELETE /identities/{id}/lockout admin unlockIdentity
+//
+// Unlock an Identity
+//
+// Calling this endpoint forgets all failed password login attempts for the identifiers of the identity given its ID
+// which unlocks the identity if it was locked out because of too many failed login attempts. Failed login attempts
+// tracked per IP address are not affected.
+//
+// Learn how identities work in [ORY Kratos' User And Identity Model Documentation](https://www.ory.sh/docs/next/kratos/concepts/identity-user-model).
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Responses:
+//       204: emptyResponse
+//       404: genericError
+//       500: genericError
+func (s *Strategy) unlockIdentity(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), x.ParseUUID(ps.ByName("id")))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	if c, ok := i.GetCredentials(identity.CredentialsTypePassword); ok && len(c.Identifiers) > 0 {
+		if err := s.d.LoginAttemptPersister().DeleteLoginAttemptsByIdentifier(r.Context(), c.Identifiers...); err != nil {
+			s.d.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, i.ID, err))
+			s.d.Writer().WriteError(w, r, err)
+			return
+		}
+	}
+	s.d.AuditRecorder().Record(r.Context(), audit.NewAdminEvent(r, audit.EventTypeIdentityUpdated, i.ID, nil))
+
+	s.d.Audit().
+		WithField("identity_id", i.ID).
+		Info("The identity has been unlocked.")
+
+	w.WriteHeader(http.StatusNoContent)
+}
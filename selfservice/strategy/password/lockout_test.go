@@ -0,0 +1,27 @@
+package password
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginDelay(t *testing.T) {
+	for k, tc := range []struct {
+		failed   int
+		expected time.Duration
+	}{
+		{failed: 1, expected: time.Second},
+		{failed: 2, expected: 2 * time.Second},
+		{failed: 3, expected: 4 * time.Second},
+		{failed: 5, expected: 16 * time.Second},
+		{failed: 7, expected: time.Minute},
+		{failed: 1000, expected: time.Minute},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			assert.Equal(t, tc.expected, loginDelay(time.Second, time.Minute, tc.failed))
+		})
+	}
+}
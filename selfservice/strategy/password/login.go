@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gofrs/uuid"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 
@@ -13,12 +14,16 @@ import (
 	"github.com/ory/herodot"
 	"github.com/ory/x/urlx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -29,11 +34,26 @@ const (
 func (s *Strategy) RegisterLoginRoutes(r *x.RouterPublic) {
 	s.d.CSRFHandler().IgnorePath(RouteLogin)
 
-	wrappedHandleLogin := strategy.IsDisabled(s.d, s.ID().String(), s.handleLogin)
+	wrappedHandleLogin := s.d.RateLimiter().Handle(ratelimit.FlowLogin, s.ID().String(), strategy.IsDisabled(s.d, s.ID().String(), s.handleLogin))
 	r.POST(RouteLogin, wrappedHandleLogin)
 }
 
+// handleLoginError records the failed login attempt in the audit log and writes the error.
 func (s *Strategy) handleLoginError(w http.ResponseWriter, r *http.Request, rr *login.Flow, payload *CompleteSelfServiceLoginFlowWithPasswordMethod, err error) {
+	s.auditFailedLogin(r, rr, uuid.Nil, err)
+	s.writeLoginError(w, r, rr, payload, err)
+}
+
+// auditFailedLogin records a failed login attempt in the audit log. Requests without a login flow are not login
+// attempts, which is why they are not recorded.
+func (s *Strategy) auditFailedLogin(r *http.Request, rr *login.Flow, identityID uuid.UUID, err error) {
+	if rr == nil {
+		return
+	}
+	s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeLogin, s.ID().String(), identityID, err))
+}
+
+func (s *Strategy) writeLoginError(w http.ResponseWriter, r *http.Request, rr *login.Flow, payload *CompleteSelfServiceLoginFlowWithPasswordMethod, err error) {
 	if rr != nil {
 		if method, ok := rr.Methods[identity.CredentialsTypePassword]; ok {
 			method.Config.Reset()
@@ -41,6 +61,10 @@ func (s *Strategy) handleLoginError(w http.ResponseWriter, r *http.Request, rr *
 			if rr.Type == flow.TypeBrowser {
 				method.Config.SetCSRF(s.d.GenerateCSRFToken(r))
 			}
+			if err := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowLogin, method.Config); err != nil {
+				s.d.LoginFlowErrorHandler().WriteFlowError(w, r, identity.CredentialsTypePassword, rr, err)
+				return
+			}
 
 			rr.Methods[identity.CredentialsTypePassword] = method
 		}
@@ -49,6 +73,26 @@ func (s *Strategy) handleLoginError(w http.ResponseWriter, r *http.Request, rr *
 	s.d.LoginFlowErrorHandler().WriteFlowError(w, r, identity.CredentialsTypePassword, rr, err)
 }
 
+// handleFailedLogin records the failed login attempt and responds with an invalid credentials error. The response
+// is the same regardless of whether the identifier exists or not. The identity ID is recorded in the audit log if
+// the identifier exists.
+func (s *Strategy) handleFailedLogin(w http.ResponseWriter, r *http.Request, rr *login.Flow, payload *CompleteSelfServiceLoginFlowWithPasswordMethod, ip string, identityID uuid.UUID) {
+	invalid := errors.WithStack(schema.NewInvalidCredentialsError())
+	s.auditFailedLogin(r, rr, identityID, invalid)
+
+	if err := s.registerFailedLogin(r.Context(), payload.Identifier, ip); err != nil {
+		s.writeLoginError(w, r, rr, payload, err)
+		return
+	}
+
+	if err := s.d.CaptchaManager().RegisterFailure(r, captcha.FlowLogin); err != nil {
+		s.writeLoginError(w, r, rr, payload, err)
+		return
+	}
+
+	s.writeLoginError(w, r, rr, payload, invalid)
+}
+
 // nolint:deadcode,unused
 // swagger:parameters completeSelfServiceLoginFlowWithPasswordMethod
 type completeSelfServiceLoginFlowWithPasswordMethodParameters struct {
@@ -133,9 +177,20 @@ func (s *Strategy) handleLogin(w http.ResponseWriter, r *http.Request, _ httprou
 		return
 	}
 
+	if err := s.d.CaptchaManager().Verify(r, captcha.FlowLogin, p.CaptchaToken); err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	ip := x.ClientIP(r)
+	if err := s.checkLockout(r.Context(), p.Identifier, ip); err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
 	i, c, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), s.ID(), p.Identifier)
 	if err != nil {
-		s.handleLoginError(w, r, ar, &p, errors.WithStack(schema.NewInvalidCredentialsError()))
+		s.handleFailedLogin(w, r, ar, &p, ip, uuid.Nil)
 		return
 	}
 
@@ -147,11 +202,32 @@ func (s *Strategy) handleLogin(w http.ResponseWriter, r *http.Request, _ httprou
 	}
 
 	if err := s.d.Hasher().Compare(r.Context(), []byte(p.Password), []byte(o.HashedPassword)); err != nil {
-		s.handleLoginError(w, r, ar, &p, errors.WithStack(schema.NewInvalidCredentialsError()))
+		s.handleFailedLogin(w, r, ar, &p, ip, i.ID)
 		return
 	}
 
-	if err := s.d.LoginHookExecutor().PostLoginHook(w, r, identity.CredentialsTypePassword, ar, i); err != nil {
+	if !i.IsActive() {
+		err := errors.WithStack(schema.NewIdentityInactiveError())
+		s.auditFailedLogin(r, ar, i.ID, err)
+		s.writeLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	if err := s.resetLockout(r.Context(), p.Identifier); err != nil {
+		s.handleLoginError(w, r, ar, &p, err)
+		return
+	}
+
+	var opts []session.Option
+	if o.expired(s.d.Config(r.Context()).PasswordPolicyConfig().MaxAge) {
+		s.d.Audit().
+			WithRequest(r).
+			WithField("identity_id", i.ID).
+			Info("The identity signed in with an expired password and must change it.")
+		opts = append(opts, session.WithPasswordChangeRequired())
+	}
+
+	if err := s.d.LoginHookExecutor().PostLoginHook(w, r, identity.CredentialsTypePassword, ar, i, opts...); err != nil {
 		s.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
 		return
 	}
@@ -188,6 +264,9 @@ func (s *Strategy) PopulateLoginMethod(r *http.Request, sr *login.Flow) error {
 			Required: true,
 		}}}
 	f.SetCSRF(s.d.GenerateCSRFToken(r))
+	if err := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowLogin, f); err != nil {
+		return err
+	}
 
 	sr.Methods[identity.CredentialsTypePassword] = &login.FlowMethod{
 		Method: identity.CredentialsTypePassword,
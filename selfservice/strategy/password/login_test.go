@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
@@ -25,6 +26,7 @@ import (
 	"github.com/ory/x/pointerx"
 
 	"github.com/ory/kratos-client-go/models"
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -32,6 +34,7 @@ import (
 	"github.com/ory/kratos/schema"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 )
@@ -40,7 +43,7 @@ func TestCompleteLogin(t *testing.T) {
 	conf, reg := internal.NewFastRegistryWithMocks(t)
 	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypePassword),
 		map[string]interface{}{"enabled": true})
-	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
 
 	errTS := testhelpers.NewErrorTestServer(t, reg)
 	uiTS := testhelpers.NewLoginUIFlowEchoServer(t, reg)
@@ -59,9 +62,9 @@ func TestCompleteLogin(t *testing.T) {
 			"csrf_token")
 	}
 
-	createIdentity := func(identifier, password string) {
+	createIdentity := func(identifier, password string) *identity.Identity {
 		p, _ := reg.Hasher().Generate(context.Background(), []byte(password))
-		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &identity.Identity{
+		i := &identity.Identity{
 			ID:     x.NewUUID(),
 			Traits: identity.Traits(fmt.Sprintf(`{"subject":"%s"}`, identifier)),
 			Credentials: map[identity.CredentialsType]identity.Credentials{
@@ -71,7 +74,9 @@ func TestCompleteLogin(t *testing.T) {
 					Config:      sqlxx.JSONRawMessage(`{"hashed_password":"` + string(p) + `"}`),
 				},
 			},
-		}))
+		}
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+		return i
 	}
 
 	apiClient := testhelpers.NewDebugClient(t)
@@ -248,6 +253,39 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 
+	t.Run("should record failed logins in the audit log", func(t *testing.T) {
+		var login = func(identifier, pwd string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("password", pwd)
+			}
+		}
+
+		t.Run("case=wrong password", func(t *testing.T) {
+			identifier := x.NewUUID().String()
+			i := createIdentity(identifier, "password")
+			expectValidationError(t, true, false, login(identifier, "not-the-password"))
+
+			events, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{IdentityID: i.ID}, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.EventTypeLogin, events[0].Type)
+			assert.Equal(t, audit.OutcomeFailure, events[0].Outcome)
+			assert.Equal(t, identity.CredentialsTypePassword.String(), events[0].Method)
+			assert.NotEmpty(t, events[0].Error)
+		})
+
+		t.Run("case=unknown identifier", func(t *testing.T) {
+			expectValidationError(t, true, false, login(x.NewUUID().String(), "password"))
+
+			events, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{Type: audit.EventTypeLogin}, 0, 1)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.OutcomeFailure, events[0].Outcome)
+			assert.False(t, events[0].IdentityID.Valid)
+		})
+	})
+
 	t.Run("should return an error because no identifier is set", func(t *testing.T) {
 		var check = func(t *testing.T, body string) {
 			assert.NotEmpty(t, gjson.Get(body, "id").String(), "%s", body)
@@ -364,6 +402,31 @@ func TestCompleteLogin(t *testing.T) {
 		})
 	})
 
+	t.Run("should return an error because the identity is inactive", func(t *testing.T) {
+		var check = func(t *testing.T, body string) {
+			assert.NotEmpty(t, gjson.Get(body, "id").String(), "%s", body)
+			assert.Equal(t, text.NewErrorValidationIdentityInactive().Text, gjson.Get(body, "methods.password.config.messages.0.text").String(), "%s", body)
+		}
+
+		identifier, pwd := x.NewUUID().String(), "password"
+		i := createIdentity(identifier, pwd)
+		i.State = identity.StateInactive
+		require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), i))
+
+		var values = func(v url.Values) {
+			v.Set("identifier", identifier)
+			v.Set("password", pwd)
+		}
+
+		t.Run("type=browser", func(t *testing.T) {
+			check(t, expectValidationError(t, false, false, values))
+		})
+
+		t.Run("type=api", func(t *testing.T) {
+			check(t, expectValidationError(t, true, false, values))
+		})
+	})
+
 	t.Run("should pass with real request", func(t *testing.T) {
 		identifier, pwd := x.NewUUID().String(), "password"
 		createIdentity(identifier, pwd)
@@ -541,4 +604,357 @@ func TestCompleteLogin(t *testing.T) {
 
 		assert.Equal(t, identifier, gjson.Get(body2, "identity.traits.subject").String(), "%s", body2)
 	})
+
+	t.Run("suite=lockout", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyPasswordLockoutEnabled, true)
+		conf.MustSet(config.ViperKeyPasswordLockoutMaxAttempts, 3)
+		conf.MustSet(config.ViperKeyPasswordLockoutMaxAttemptsPerIP, 0)
+		conf.MustSet(config.ViperKeyPasswordLockoutDuration, "1h")
+		conf.MustSet(config.ViperKeyPasswordLockoutBaseDelay, "0s")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPasswordLockoutEnabled, false)
+		})
+
+		var credentials = func(identifier, pwd string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("password", pwd)
+			}
+		}
+
+		var expectMessage = func(t *testing.T, isAPI bool, values func(url.Values), expected text.ID) {
+			body := expectValidationError(t, isAPI, false, values)
+			assert.EqualValues(t, expected, gjson.Get(body, "methods.password.config.messages.0.id").Int(), "%s", body)
+		}
+
+		var expectSuccess = func(t *testing.T, values func(url.Values)) {
+			body := testhelpers.SubmitLoginForm(t, false, testhelpers.NewClientWithCookies(t), publicTS, values,
+				identity.CredentialsTypePassword, false, http.StatusOK, redirTS.URL)
+			assert.NotEmpty(t, gjson.Get(body, "identity.id").String(), "%s", body)
+		}
+
+		t.Run("case=should lock out identifiers regardless of whether they exist", func(t *testing.T) {
+			existing, pwd := x.NewUUID().String(), "password"
+			createIdentity(existing, pwd)
+			unknown := x.NewUUID().String()
+
+			for _, identifier := range []string{existing, unknown} {
+				for k := 0; k < 3; k++ {
+					expectMessage(t, k%2 == 0, credentials(identifier, "not-password"), text.ErrorValidationInvalidCredentials)
+				}
+				expectMessage(t, false, credentials(identifier, pwd), text.ErrorValidationLoginLockedOut)
+				expectMessage(t, true, credentials(identifier, pwd), text.ErrorValidationLoginLockedOut)
+			}
+		})
+
+		t.Run("case=should ignore the identifier's capitalization", func(t *testing.T) {
+			identifier := x.NewUUID().String()
+			for k := 0; k < 3; k++ {
+				expectMessage(t, false, credentials(strings.ToUpper(identifier), "not-password"), text.ErrorValidationInvalidCredentials)
+			}
+			expectMessage(t, false, credentials(identifier, "not-password"), text.ErrorValidationLoginLockedOut)
+		})
+
+		t.Run("case=should reset failed attempts after a successful login", func(t *testing.T) {
+			identifier, pwd := x.NewUUID().String(), "password"
+			createIdentity(identifier, pwd)
+
+			for k := 0; k < 2; k++ {
+				expectMessage(t, false, credentials(identifier, "not-password"), text.ErrorValidationInvalidCredentials)
+			}
+			expectSuccess(t, credentials(identifier, pwd))
+			for k := 0; k < 2; k++ {
+				expectMessage(t, false, credentials(identifier, "not-password"), text.ErrorValidationInvalidCredentials)
+			}
+			expectSuccess(t, credentials(identifier, pwd))
+		})
+
+		t.Run("case=should be unlocked by an admin", func(t *testing.T) {
+			identifier, pwd := x.NewUUID().String(), "password"
+			i := createIdentity(identifier, pwd)
+
+			for k := 0; k < 3; k++ {
+				expectMessage(t, false, credentials(identifier, "not-password"), text.ErrorValidationInvalidCredentials)
+			}
+			expectMessage(t, false, credentials(identifier, pwd), text.ErrorValidationLoginLockedOut)
+
+			req, err := http.NewRequest("DELETE", adminTS.URL+"/identities/"+i.ID.String()+"/lockout", nil)
+			require.NoError(t, err)
+			res, err := adminTS.Client().Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+			events, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{IdentityID: i.ID, Type: audit.EventTypeIdentityUpdated}, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActorAdmin, events[0].Actor)
+			assert.Equal(t, audit.OutcomeSuccess, events[0].Outcome)
+
+			expectSuccess(t, credentials(identifier, pwd))
+		})
+
+		t.Run("case=should not unlock unknown identities", func(t *testing.T) {
+			req, err := http.NewRequest("DELETE", adminTS.URL+"/identities/"+x.NewUUID().String()+"/lockout", nil)
+			require.NoError(t, err)
+			res, err := adminTS.Client().Do(req)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		})
+
+		t.Run("case=should delay attempts exponentially", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyPasswordLockoutBaseDelay, "1h")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeyPasswordLockoutBaseDelay, "0s")
+			})
+
+			identifier, pwd := x.NewUUID().String(), "password"
+			createIdentity(identifier, pwd)
+
+			expectMessage(t, false, credentials(identifier, "not-password"), text.ErrorValidationInvalidCredentials)
+			expectMessage(t, false, credentials(identifier, pwd), text.ErrorValidationLoginLockedOut)
+		})
+
+		t.Run("case=should lock out IP addresses", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyPasswordLockoutMaxAttemptsPerIP, 1)
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeyPasswordLockoutMaxAttemptsPerIP, 0)
+			})
+
+			identifier, pwd := x.NewUUID().String(), "password"
+			createIdentity(identifier, pwd)
+
+			// Previous test cases already failed to sign in from this IP address.
+			expectMessage(t, false, credentials(identifier, pwd), text.ErrorValidationLoginLockedOut)
+		})
+	})
+
+	t.Run("suite=expiry", func(t *testing.T) {
+		settingsTS := testhelpers.NewSettingsUIFlowEchoServer(t, reg)
+
+		var credentials = func(identifier, pwd string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("password", pwd)
+			}
+		}
+
+		var whoami = func(t *testing.T, hc *http.Client) int {
+			res, err := hc.Get(publicTS.URL + session.RouteWhoami)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			return res.StatusCode
+		}
+
+		var expectExpired = func(t *testing.T, identifier, pwd string) {
+			t.Run("type=browser", func(t *testing.T) {
+				browserClient := testhelpers.NewClientWithCookies(t)
+				body := testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, credentials(identifier, pwd),
+					identity.CredentialsTypePassword, false, http.StatusOK, settingsTS.URL+"/settings-ts")
+				assert.Equal(t, identifier, gjson.Get(body, "identity.traits.subject").String(), "%s", body)
+				assert.Equal(t, http.StatusForbidden, whoami(t, browserClient))
+			})
+
+			t.Run("type=api", func(t *testing.T) {
+				body := testhelpers.SubmitLoginForm(t, true, nil, publicTS, credentials(identifier, pwd),
+					identity.CredentialsTypePassword, false, http.StatusOK, publicTS.URL+password.RouteLogin)
+				assert.True(t, gjson.Get(body, "session.password_change_required").Bool(), "%s", body)
+
+				apiClient := &http.Client{Transport: x.NewTransportWithHeader(http.Header{
+					"Authorization": {"Bearer " + gjson.Get(body, "session_token").String()}})}
+				assert.Equal(t, http.StatusForbidden, whoami(t, apiClient))
+
+				rs := testhelpers.InitializeSettingsFlowViaAPI(t, apiClient, publicTS)
+				f := testhelpers.GetSettingsFlowMethodConfig(t, rs.Payload, identity.CredentialsTypePassword.String())
+				values := testhelpers.SDKFormFieldsToURLValues(f.Fields)
+				values.Set("password", x.NewUUID().String())
+				actual, res := testhelpers.SettingsMakeRequest(t, true, f, apiClient, testhelpers.EncodeFormAsJSON(t, true, values))
+				assert.Equal(t, http.StatusOK, res.StatusCode, "%s", actual)
+				assert.Equal(t, http.StatusOK, whoami(t, apiClient))
+			})
+		}
+
+		t.Run("case=should not expire passwords by default", func(t *testing.T) {
+			identifier, pwd := x.NewUUID().String(), "password"
+			createIdentity(identifier, pwd)
+
+			body := testhelpers.SubmitLoginForm(t, true, nil, publicTS, credentials(identifier, pwd),
+				identity.CredentialsTypePassword, false, http.StatusOK, publicTS.URL+password.RouteLogin)
+			assert.False(t, gjson.Get(body, "session.password_change_required").Bool(), "%s", body)
+		})
+
+		t.Run("case=should require changing a password which is too old", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyPasswordMaxAge, "1h")
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeyPasswordMaxAge, "0s")
+			})
+
+			identifier, pwd := x.NewUUID().String(), "password"
+			i := createIdentity(identifier, pwd)
+			c, ok := i.GetCredentials(identity.CredentialsTypePassword)
+			require.True(t, ok)
+			c.Config = sqlxx.JSONRawMessage(`{"hashed_password":"` + gjson.GetBytes(c.Config, "hashed_password").String() +
+				`","changed_at":"` + time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339) + `"}`)
+			i.SetCredentials(identity.CredentialsTypePassword, *c)
+			require.NoError(t, reg.PrivilegedIdentityPool().UpdateIdentity(context.Background(), i))
+
+			expectExpired(t, identifier, pwd)
+		})
+
+		t.Run("case=should require changing a password expired by an admin", func(t *testing.T) {
+			identifier, pwd := x.NewUUID().String(), "password"
+			i := createIdentity(identifier, pwd)
+
+			browserClient := testhelpers.NewClientWithCookies(t)
+			testhelpers.SubmitLoginForm(t, false, browserClient, publicTS, credentials(identifier, pwd),
+				identity.CredentialsTypePassword, false, http.StatusOK, redirTS.URL)
+
+			res, err := adminTS.Client().Post(adminTS.URL+"/identities/"+i.ID.String()+"/password/expire", "application/json", nil)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+			events, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{IdentityID: i.ID, Type: audit.EventTypeIdentityUpdated}, 0, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, audit.ActorAdmin, events[0].Actor)
+			assert.Equal(t, audit.OutcomeSuccess, events[0].Outcome)
+
+			// Existing sessions are not affected.
+			assert.Equal(t, http.StatusOK, whoami(t, browserClient))
+
+			expectExpired(t, identifier, pwd)
+		})
+
+		t.Run("case=should not expire the password of unknown identities", func(t *testing.T) {
+			res, err := adminTS.Client().Post(adminTS.URL+"/identities/"+x.NewUUID().String()+"/password/expire", "application/json", nil)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+			assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		})
+	})
+
+	t.Run("suite=captcha", func(t *testing.T) {
+		siteVerifyTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"success":%t}`, r.PostForm.Get("response") == "valid")))
+		}))
+		t.Cleanup(siteVerifyTS.Close)
+
+		conf.MustSet(config.ViperKeySelfServiceCaptchaConfig, map[string]interface{}{
+			"verify_url": siteVerifyTS.URL,
+			"site_key":   "site-key",
+			"secret":     "secret",
+		})
+		conf.MustSet("selfservice.flows.login.captcha.enabled", true)
+		t.Cleanup(func() {
+			conf.MustSet("selfservice.flows.login.captcha.enabled", false)
+			conf.MustSet("selfservice.flows.login.captcha.after_failures", 0)
+		})
+
+		identifier, pwd := x.NewUUID().String(), "password"
+		createIdentity(identifier, pwd)
+
+		var credentials = func(token string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("password", pwd)
+				v.Set("captcha_token", token)
+			}
+		}
+
+		t.Run("case=should show the challenge", func(t *testing.T) {
+			f := testhelpers.InitializeLoginFlowViaAPI(t, new(http.Client), publicTS, false).Payload
+			body, err := json.Marshal(f)
+			require.NoError(t, err)
+			assert.Equal(t, "captcha", gjson.GetBytes(body, "methods.password.config.fields.#(name==captcha_token).type").String(), "%s", body)
+			assert.Equal(t, "site-key", gjson.GetBytes(body, "methods.password.config.fields.#(name==captcha_token).messages.0.context.site_key").String(), "%s", body)
+		})
+
+		for _, token := range []string{"", "invalid"} {
+			t.Run("case=should reject an invalid token/token="+token, func(t *testing.T) {
+				for _, isAPI := range []bool{true, false} {
+					body := expectValidationError(t, isAPI, false, credentials(token))
+					assert.EqualValues(t, text.ErrorValidationCaptchaInvalid, gjson.Get(body, "methods.password.config.fields.#(name==captcha_token).messages.1.id").Int(), "%s", body)
+				}
+			})
+		}
+
+		t.Run("case=should sign in with a valid token", func(t *testing.T) {
+			body := testhelpers.SubmitLoginForm(t, true, nil, publicTS, credentials("valid"),
+				identity.CredentialsTypePassword, false, http.StatusOK, publicTS.URL+password.RouteLogin)
+			assert.Equal(t, identifier, gjson.Get(body, "session.identity.traits.subject").String(), "%s", body)
+		})
+
+		t.Run("case=should only challenge after failures", func(t *testing.T) {
+			conf.MustSet("selfservice.flows.login.captcha.after_failures", 2)
+
+			f := testhelpers.InitializeLoginFlowViaAPI(t, new(http.Client), publicTS, false).Payload
+			body, err := json.Marshal(f)
+			require.NoError(t, err)
+			assert.False(t, gjson.GetBytes(body, "methods.password.config.fields.#(name==captcha_token)").Exists(), "%s", body)
+
+			for k := 0; k < 2; k++ {
+				expectValidationError(t, true, false, func(v url.Values) {
+					v.Set("identifier", identifier)
+					v.Set("password", "not-password")
+				})
+			}
+
+			res := expectValidationError(t, true, false, credentials(""))
+			assert.EqualValues(t, text.ErrorValidationCaptchaInvalid, gjson.Get(res, "methods.password.config.fields.#(name==captcha_token).messages.1.id").Int(), "%s", res)
+		})
+	})
+
+	t.Run("suite=rate limit", func(t *testing.T) {
+		conf.MustSet("selfservice.flows.login.rate_limit.enabled", true)
+		conf.MustSet("selfservice.flows.login.rate_limit.per_ip.limit", 0)
+		conf.MustSet("selfservice.flows.login.rate_limit.per_identifier.limit", 2)
+		conf.MustSet("selfservice.flows.login.rate_limit.per_identifier.window", "1h")
+		t.Cleanup(func() {
+			conf.MustSet("selfservice.flows.login.rate_limit.enabled", false)
+		})
+
+		var expectRateLimited = func(t *testing.T, isAPI bool, values func(url.Values)) {
+			body := testhelpers.SubmitLoginForm(t, isAPI, nil, publicTS, values,
+				identity.CredentialsTypePassword, false,
+				testhelpers.ExpectStatusCode(isAPI, http.StatusTooManyRequests, http.StatusOK),
+				testhelpers.ExpectURL(isAPI, publicTS.URL+password.RouteLogin, conf.SelfServiceFlowLoginUI().String()))
+			assert.EqualValues(t, text.ErrorValidationRateLimitExceeded, gjson.Get(body, "methods.password.config.messages.0.id").Int(), "%s", body)
+		}
+
+		identifier, pwd := x.NewUUID().String(), "password"
+		createIdentity(identifier, pwd)
+
+		var credentials = func(identifier, pwd string) func(v url.Values) {
+			return func(v url.Values) {
+				v.Set("identifier", identifier)
+				v.Set("password", pwd)
+			}
+		}
+
+		t.Run("case=should limit requests per identifier", func(t *testing.T) {
+			expectValidationError(t, true, false, credentials(identifier, "not-password"))
+			expectValidationError(t, false, false, credentials(strings.ToUpper(identifier), "not-password"))
+
+			expectRateLimited(t, true, credentials(identifier, pwd))
+			expectRateLimited(t, false, credentials(identifier, pwd))
+		})
+
+		t.Run("case=should not limit other identifiers", func(t *testing.T) {
+			body := expectValidationError(t, true, false, credentials(x.NewUUID().String(), "not-password"))
+			assert.EqualValues(t, text.ErrorValidationInvalidCredentials, gjson.Get(body, "methods.password.config.messages.0.id").Int(), "%s", body)
+		})
+
+		t.Run("case=should limit requests per IP address", func(t *testing.T) {
+			conf.MustSet("selfservice.flows.login.rate_limit.methods.password.per_ip.limit", 1)
+			t.Cleanup(func() {
+				conf.MustSet("selfservice.flows.login.rate_limit.methods.password.per_ip.limit", 0)
+			})
+
+			expectValidationError(t, true, false, credentials(x.NewUUID().String(), "not-password"))
+			expectRateLimited(t, true, credentials(x.NewUUID().String(), "not-password"))
+		})
+	})
 }
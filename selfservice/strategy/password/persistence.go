@@ -0,0 +1,83 @@
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/x"
+)
+
+type (
+	LoginAttemptPersister interface {
+		CreateLoginAttempt(ctx context.Context, attempt *LoginAttempt) error
+		ListLoginAttemptsByIdentifier(ctx context.Context, identifier string, since time.Time, limit int) ([]LoginAttempt, error)
+		ListLoginAttemptsByIPAddress(ctx context.Context, ip string, since time.Time, limit int) ([]LoginAttempt, error)
+		DeleteLoginAttemptsByIdentifier(ctx context.Context, identifiers ...string) error
+	}
+
+	LoginAttemptPersistenceProvider interface {
+		LoginAttemptPersister() LoginAttemptPersister
+	}
+)
+
+func TestPersister(ctx context.Context, p LoginAttemptPersister) func(t *testing.T) {
+	var createAttempt = func(t *testing.T, identifier, ip string, ago time.Duration) *LoginAttempt {
+		a := NewLoginAttempt(identifier, ip)
+		a.CreatedAt = a.CreatedAt.Add(-ago).Truncate(time.Second)
+		require.NoError(t, p.CreateLoginAttempt(ctx, a))
+		return a
+	}
+
+	return func(t *testing.T) {
+		t.Run("case=list by identifier", func(t *testing.T) {
+			identifier := x.NewUUID().String() + "@ory.sh"
+			createAttempt(t, identifier, "127.0.0.1", time.Hour)
+			second := createAttempt(t, identifier, "127.0.0.2", time.Minute*2)
+			third := createAttempt(t, identifier, "127.0.0.3", time.Minute)
+			createAttempt(t, x.NewUUID().String(), "127.0.0.1", 0)
+
+			actual, err := p.ListLoginAttemptsByIdentifier(ctx, identifier, time.Now().Add(-time.Minute*30), 10)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			assert.Equal(t, third.ID, actual[0].ID)
+			assert.Equal(t, second.ID, actual[1].ID)
+
+			actual, err = p.ListLoginAttemptsByIdentifier(ctx, identifier, time.Now().Add(-time.Hour*2), 1)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, third.ID, actual[0].ID)
+		})
+
+		t.Run("case=list by ip address", func(t *testing.T) {
+			ip := x.NewUUID().String()
+			createAttempt(t, x.NewUUID().String(), ip, time.Hour)
+			expected := createAttempt(t, x.NewUUID().String(), ip, time.Minute)
+
+			actual, err := p.ListLoginAttemptsByIPAddress(ctx, ip, time.Now().Add(-time.Minute*30), 10)
+			require.NoError(t, err)
+			require.Len(t, actual, 1)
+			assert.Equal(t, expected.ID, actual[0].ID)
+			assert.Equal(t, ip, actual[0].IPAddress)
+		})
+
+		t.Run("case=delete by identifier", func(t *testing.T) {
+			a, b, c := x.NewUUID().String(), x.NewUUID().String(), x.NewUUID().String()
+			for _, identifier := range []string{a, b, c} {
+				createAttempt(t, identifier, "127.0.0.1", 0)
+			}
+
+			require.NoError(t, p.DeleteLoginAttemptsByIdentifier(ctx, a, b))
+			require.NoError(t, p.DeleteLoginAttemptsByIdentifier(ctx))
+
+			for identifier, expected := range map[string]int{a: 0, b: 0, c: 1} {
+				actual, err := p.ListLoginAttemptsByIdentifier(ctx, identifier, time.Now().Add(-time.Hour), 10)
+				require.NoError(t, err)
+				assert.Len(t, actual, expected, identifier)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/session"
 
 	"github.com/ory/herodot"
@@ -22,6 +24,7 @@ import (
 
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/schema"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/form"
 	"github.com/ory/kratos/selfservice/strategy"
@@ -36,12 +39,15 @@ type RegistrationFormPayload struct {
 	Password  string          `json:"password"`
 	Traits    json.RawMessage `json:"traits"`
 	CSRFToken string          `json:"csrf_token"`
+
+	// The CAPTCHA response token is only required if the registration flow asks for a CAPTCHA challenge.
+	CaptchaToken string `json:"captcha_token"`
 }
 
 func (s *Strategy) RegisterRegistrationRoutes(public *x.RouterPublic) {
 	s.d.CSRFHandler().IgnorePath(RouteRegistration)
 
-	wrappedHandleRegistration := strategy.IsDisabled(s.d, s.ID().String(), s.handleRegistration)
+	wrappedHandleRegistration := s.d.RateLimiter().Handle(ratelimit.FlowRegistration, s.ID().String(), strategy.IsDisabled(s.d, s.ID().String(), s.handleRegistration))
 	public.POST(RouteRegistration, s.d.SessionHandler().IsNotAuthenticated(wrappedHandleRegistration, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		handler := session.RedirectOnAuthenticated(s.d)
 		if x.IsJSONRequest(r) {
@@ -65,6 +71,11 @@ func (s *Strategy) handleRegistrationError(w http.ResponseWriter, r *http.Reques
 			}
 
 			method.Config.SetCSRF(s.d.GenerateCSRFToken(r))
+			if errCaptcha := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowRegistration, method.Config); errCaptcha != nil {
+				s.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, identity.CredentialsTypePassword, rr, errCaptcha)
+				return
+			}
+
 			rr.Methods[identity.CredentialsTypePassword] = method
 			if errSec := method.Config.SortFields(s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String()); errSec != nil {
 				s.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, identity.CredentialsTypePassword, rr, errors.Wrap(err, errSec.Error()))
@@ -165,6 +176,11 @@ func (s *Strategy) handleRegistration(w http.ResponseWriter, r *http.Request, _
 		return
 	}
 
+	if err := s.d.CaptchaManager().Verify(r, captcha.FlowRegistration, p.CaptchaToken); err != nil {
+		s.handleRegistrationError(w, r, ar, &p, err)
+		return
+	}
+
 	if len(p.Password) == 0 {
 		s.handleRegistrationError(w, r, ar, &p, schema.NewRequiredError("#/password", "password"))
 		return
@@ -180,7 +196,8 @@ func (s *Strategy) handleRegistration(w http.ResponseWriter, r *http.Request, _
 		return
 	}
 
-	co, err := json.Marshal(&CredentialsConfig{HashedPassword: string(hpw)})
+	changedAt := time.Now().UTC()
+	co, err := json.Marshal(&CredentialsConfig{HashedPassword: string(hpw), ChangedAt: &changedAt})
 	if err != nil {
 		s.handleRegistrationError(w, r, ar, &p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode password options to JSON: %s", err)))
 		return
@@ -191,6 +208,11 @@ func (s *Strategy) handleRegistration(w http.ResponseWriter, r *http.Request, _
 	i.SetCredentials(s.ID(), identity.Credentials{Type: s.ID(), Identifiers: []string{}, Config: co})
 
 	if err := s.validateCredentials(r.Context(), i, p.Password); err != nil {
+		if errCaptcha := s.d.CaptchaManager().RegisterFailure(r, captcha.FlowRegistration); errCaptcha != nil {
+			s.handleRegistrationError(w, r, ar, &p, errCaptcha)
+			return
+		}
+
 		s.handleRegistrationError(w, r, ar, &p, err)
 		return
 	}
@@ -219,6 +241,11 @@ func (s *Strategy) validateCredentials(ctx context.Context, i *identity.Identity
 			if _, ok := errorsx.Cause(err).(*herodot.DefaultError); ok {
 				return err
 			}
+
+			var violation *PolicyViolationError
+			if errors.As(err, &violation) {
+				return schema.NewPasswordPolicyRuleViolationError("#/password", violation.Message)
+			}
 			return schema.NewPasswordPolicyViolationError("#/password", err.Error())
 		}
 	}
@@ -242,6 +269,10 @@ func (s *Strategy) PopulateRegistrationMethod(r *http.Request, sr *registration.
 		return err
 	}
 
+	if err := s.d.CaptchaManager().PopulateChallenge(r, captcha.FlowRegistration, htmlf); err != nil {
+		return err
+	}
+
 	sr.Methods[identity.CredentialsTypePassword] = &registration.FlowMethod{
 		Method: identity.CredentialsTypePassword,
 		Config: &registration.FlowMethodConfig{FlowMethodConfigurator: &FlowMethod{HTMLForm: htmlf}},
@@ -19,7 +19,9 @@ import (
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
 
@@ -30,9 +32,9 @@ const (
 func (s *Strategy) RegisterSettingsRoutes(router *x.RouterPublic) {
 	s.d.CSRFHandler().IgnorePath(RouteSettings)
 
-	wrappedSubmmitSettingsFlow := strategy.IsDisabled(s.d, s.SettingsStrategyID(), s.submitSettingsFlow)
-	router.POST(RouteSettings, wrappedSubmmitSettingsFlow)
-	router.GET(RouteSettings, wrappedSubmmitSettingsFlow)
+	wrappedSubmmitSettingsFlow := s.d.RateLimiter().Handle(ratelimit.FlowSettings, s.SettingsStrategyID(), strategy.IsDisabled(s.d, s.SettingsStrategyID(), s.submitSettingsFlow))
+	router.POST(RouteSettings, session.AllowPasswordChange(wrappedSubmmitSettingsFlow))
+	router.GET(RouteSettings, session.AllowPasswordChange(wrappedSubmmitSettingsFlow))
 }
 
 func (s *Strategy) SettingsStrategyID() string {
@@ -170,29 +172,44 @@ func (s *Strategy) continueSettingsFlow(
 		return
 	}
 
-	hpw, err := s.d.Hasher().Generate(r.Context(), []byte(p.Password))
+	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
 	if err != nil {
 		s.handleSettingsError(w, r, ctxUpdate, p, err)
 		return
 	}
 
-	co, err := json.Marshal(&CredentialsConfig{HashedPassword: string(hpw)})
-	if err != nil {
-		s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode password options to JSON: %s", err)))
+	var current CredentialsConfig
+	c, ok := i.GetCredentials(s.ID())
+	if !ok {
+		c = &identity.Credentials{Type: s.ID(),
+			// We need to insert a random identifier now...
+			Identifiers: []string{x.NewUUID().String()}}
+	} else if len(c.Config) > 0 {
+		if err := json.Unmarshal(c.Config, &current); err != nil {
+			s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode password options from JSON: %s", err)))
+			return
+		}
+	}
+
+	if err := s.validatePasswordHistory(r.Context(), &current, p.Password); err != nil {
+		s.handleSettingsError(w, r, ctxUpdate, p, err)
 		return
 	}
 
-	i, err := s.d.PrivilegedIdentityPool().GetIdentityConfidential(r.Context(), ctxUpdate.Session.Identity.ID)
+	hpw, err := s.d.Hasher().Generate(r.Context(), []byte(p.Password))
 	if err != nil {
 		s.handleSettingsError(w, r, ctxUpdate, p, err)
 		return
 	}
 
-	c, ok := i.GetCredentials(s.ID())
-	if !ok {
-		c = &identity.Credentials{Type: s.ID(),
-			// We need to insert a random identifier now...
-			Identifiers: []string{x.NewUUID().String()}}
+	changedAt := time.Now().UTC()
+	cc := s.rollPasswordHistory(r.Context(), &current, hpw)
+	cc.ChangedAt = &changedAt
+
+	co, err := json.Marshal(cc)
+	if err != nil {
+		s.handleSettingsError(w, r, ctxUpdate, p, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to encode password options to JSON: %s", err)))
+		return
 	}
 
 	c.Config = co
@@ -203,7 +220,9 @@ func (s *Strategy) continueSettingsFlow(
 	}
 
 	if err := s.d.SettingsHookExecutor().PostSettingsHook(w, r,
-		s.SettingsStrategyID(), ctxUpdate, i); err != nil {
+		s.SettingsStrategyID(), ctxUpdate, i, settings.WithCallback(func(ctxUpdate *settings.UpdateContext) error {
+			return s.clearPasswordChangeRequired(r.Context(), ctxUpdate)
+		})); err != nil {
 		s.handleSettingsError(w, r, ctxUpdate, p, err)
 		return
 	}
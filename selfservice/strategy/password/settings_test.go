@@ -24,6 +24,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/strategy/password"
 	"github.com/ory/kratos/selfservice/strategy/profile"
+	"github.com/ory/kratos/text"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/assertx"
 	"github.com/ory/x/httpx"
@@ -333,4 +334,46 @@ func TestSettings(t *testing.T) {
 			run(t, form, false, browserUser1, browserIdentity1)
 		})
 	})
+
+	t.Run("description=should not allow reusing one of the last passwords", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyPasswordHistorySize, 3)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPasswordHistorySize, nil)
+		})
+
+		id := newIdentityWithPassword("john-history@doe.com")
+		hc := testhelpers.NewHTTPClientWithIdentitySessionToken(t, reg, id)
+
+		var set = func(pw string) func(url.Values) {
+			return func(v url.Values) {
+				v.Set("password", pw)
+			}
+		}
+
+		var history = func(t *testing.T) []gjson.Result {
+			actual, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id.ID)
+			require.NoError(t, err)
+			return gjson.GetBytes(actual.Credentials[identity.CredentialsTypePassword].Config, "previous_hashed_passwords").Array()
+		}
+
+		passwords := make([]string, 4)
+		for k := range passwords {
+			passwords[k] = randx.MustString(16, randx.AlphaNum)
+		}
+
+		for _, pw := range passwords[:3] {
+			expectSuccess(t, true, hc, set(pw))
+		}
+		assert.Len(t, history(t), 2)
+
+		for _, pw := range passwords[:3] {
+			actual := expectValidationError(t, true, hc, set(pw))
+			assert.EqualValues(t, text.ErrorValidationPasswordReused, gjson.Get(actual, "methods.password.config.fields.#(name==password).messages.0.id").Int(), "%s", actual)
+		}
+
+		expectSuccess(t, true, hc, set(passwords[3]))
+		assert.Len(t, history(t), 2, "the history should be pruned")
+
+		expectSuccess(t, true, hc, set(passwords[0]))
+	})
 }
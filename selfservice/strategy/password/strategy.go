@@ -9,14 +9,17 @@ import (
 
 	"github.com/ory/x/decoderx"
 
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/continuity"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/hash"
 	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/captcha"
 	"github.com/ory/kratos/selfservice/errorx"
 	"github.com/ory/kratos/selfservice/flow/login"
 	"github.com/ory/kratos/selfservice/flow/registration"
 	"github.com/ory/kratos/selfservice/flow/settings"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 )
@@ -59,8 +62,16 @@ type registrationStrategyDependencies interface {
 	identity.PrivilegedPoolProvider
 	identity.ValidationProvider
 
+	LoginAttemptPersistenceProvider
+
+	captcha.ManagementProvider
+	ratelimit.ManagementProvider
+
 	session.HandlerProvider
 	session.ManagementProvider
+	session.PersistenceProvider
+
+	audit.RecorderProvider
 }
 
 type Strategy struct {
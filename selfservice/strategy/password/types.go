@@ -1,12 +1,27 @@
 package password
 
-import "github.com/ory/kratos/selfservice/form"
+import (
+	"time"
+
+	"github.com/ory/kratos/selfservice/form"
+)
 
 type (
 	// CredentialsConfig is the struct that is being used as part of the identity credentials.
 	CredentialsConfig struct {
 		// HashedPassword is a hash-representation of the password.
 		HashedPassword string `json:"hashed_password"`
+
+		// PreviousHashedPasswords are the hash-representations of the previous passwords, most recent first. They
+		// are only kept if the password history is enabled.
+		PreviousHashedPasswords []string `json:"previous_hashed_passwords,omitempty"`
+
+		// ChangedAt is the time when the password was set. Passwords which were set before password expiry
+		// was introduced do not have this field and do not expire because of their age.
+		ChangedAt *time.Time `json:"changed_at,omitempty"`
+
+		// ChangeRequired is true if an administrator expired the password.
+		ChangeRequired bool `json:"change_required,omitempty"`
 	}
 
 	// CompleteSelfServiceLoginFlowWithPasswordMethod is used to decode the login form payload.
@@ -19,6 +34,9 @@ type (
 
 		// Sending the anti-csrf token is only required for browser login flows.
 		CSRFToken string `form:"csrf_token" json:"csrf_token"`
+
+		// The CAPTCHA response token is only required if the login flow asks for a CAPTCHA challenge.
+		CaptchaToken string `form:"captcha_token" json:"captcha_token,omitempty"`
 	}
 )
 
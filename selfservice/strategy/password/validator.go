@@ -13,15 +13,21 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/arbovm/levenshtein"
+	"github.com/nbutton23/zxcvbn-go"
 
 	"github.com/ory/x/httpx"
+	"github.com/ory/x/urlx"
 
 	"github.com/pkg/errors"
 
 	"github.com/ory/herodot"
 	"github.com/ory/x/stringsx"
+
+	"github.com/ory/kratos/text"
 )
 
 // Validator implements a validation strategy for passwords. One example is that the password
@@ -41,8 +47,34 @@ var _ Validator = new(DefaultPasswordValidator)
 var ErrNetworkFailure = errors.New("unable to check if password has been leaked because an unexpected network error occurred")
 var ErrUnexpectedStatusCode = errors.New("unexpected status code")
 
-// DefaultPasswordValidator implements Validator. It is based on best
-// practices as defined in the following blog posts:
+const (
+	CharacterClassLowercase = "lowercase"
+	CharacterClassUppercase = "uppercase"
+	CharacterClassNumber    = "number"
+	CharacterClassSymbol    = "symbol"
+)
+
+// maxStrengthInputLength limits the number of characters which are used for estimating the password's strength
+// because the estimation becomes slow for long passwords. Long passwords are strong anyways.
+const maxStrengthInputLength = 100
+
+// PolicyViolationError is returned by the DefaultPasswordValidator if the password violates a rule of the
+// password policy. Its message explains which rule was violated.
+type PolicyViolationError struct {
+	Message *text.Message
+}
+
+func (e *PolicyViolationError) Error() string {
+	return e.Message.Text
+}
+
+func newPolicyViolationError(m *text.Message) error {
+	return errors.WithStack(&PolicyViolationError{Message: m})
+}
+
+// DefaultPasswordValidator implements Validator. It enforces the password policy
+// set in the configuration and is based on best practices as defined in the
+// following blog posts:
 //
 // - https://www.troyhunt.com/passwords-evolved-authentication-guidance-for-the-modern-era/
 // - https://www.microsoft.com/en-us/research/wp-content/uploads/2016/06/Microsoft_Password_Guidance-1.pdf
@@ -55,9 +87,6 @@ type DefaultPasswordValidator struct {
 	reg    validatorDependencies
 	Client *http.Client
 	hashes map[string]int64
-
-	minIdentifierPasswordDist            int
-	maxIdentifierPasswordSubstrThreshold float32
 }
 
 type validatorDependencies interface {
@@ -66,10 +95,10 @@ type validatorDependencies interface {
 
 func NewDefaultPasswordValidatorStrategy(reg validatorDependencies) *DefaultPasswordValidator {
 	return &DefaultPasswordValidator{
-		Client:                    httpx.NewResilientClientLatencyToleranceMedium(nil),
-		reg:                       reg,
-		hashes:                    map[string]int64{},
-		minIdentifierPasswordDist: 5, maxIdentifierPasswordSubstrThreshold: 0.5}
+		Client: httpx.NewResilientClientLatencyToleranceMedium(nil),
+		reg:    reg,
+		hashes: map[string]int64{},
+	}
 }
 
 func b20(src []byte) string {
@@ -98,9 +127,9 @@ func lcsLength(a, b string) int {
 	return greatestLength
 }
 
-func (s *DefaultPasswordValidator) fetch(hpw []byte) error {
-	prefix := fmt.Sprintf("%X", hpw)[0:5]
-	loc := fmt.Sprintf("https://api.pwnedpasswords.com/range/%s", prefix)
+func (s *DefaultPasswordValidator) fetch(ctx context.Context, hpw []byte) error {
+	prefix := fmt.Sprintf("%X", hpw)[0:breachedDatasetPrefixLength]
+	loc := urlx.AppendPaths(s.reg.Config(ctx).PasswordPolicyConfig().HaveIBeenPwnedURL, prefix).String()
 	res, err := s.Client.Get(loc)
 	if err != nil {
 		return errors.Wrapf(ErrNetworkFailure, "%s", err)
@@ -141,18 +170,87 @@ func (s *DefaultPasswordValidator) fetch(hpw []byte) error {
 	return nil
 }
 
-func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, password string) error {
-	if len(password) < 6 {
-		return errors.Errorf("password length must be at least 6 characters but only got %d", len(password))
+func hasCharacterClass(password, class string) bool {
+	for _, r := range password {
+		switch class {
+		case CharacterClassLowercase:
+			if unicode.IsLower(r) {
+				return true
+			}
+		case CharacterClassUppercase:
+			if unicode.IsUpper(r) {
+				return true
+			}
+		case CharacterClassNumber:
+			if unicode.IsNumber(r) {
+				return true
+			}
+		case CharacterClassSymbol:
+			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsSpace(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func strengthScore(identifier, password string) int {
+	if utf8.RuneCountInString(password) > maxStrengthInputLength {
+		password = string([]rune(password)[:maxStrengthInputLength])
+	}
+	return zxcvbn.PasswordStrength(password, []string{identifier}).Score
+}
+
+func (s *DefaultPasswordValidator) validatePolicy(ctx context.Context, identifier, password string) error {
+	policy := s.reg.Config(ctx).PasswordPolicyConfig()
+
+	length := utf8.RuneCountInString(password)
+	if length < policy.MinLength {
+		return newPolicyViolationError(text.NewErrorValidationPasswordMinLength(policy.MinLength, length))
+	} else if policy.MaxLength > 0 && length > policy.MaxLength {
+		return newPolicyViolationError(text.NewErrorValidationPasswordMaxLength(policy.MaxLength, length))
+	}
+
+	for _, class := range policy.RequiredCharacterClasses {
+		if !hasCharacterClass(password, class) {
+			return newPolicyViolationError(text.NewErrorValidationPasswordCharacterClass(class))
+		}
 	}
 
-	compIdentifier, compPassword := strings.ToLower(identifier), strings.ToLower(password)
-	dist := levenshtein.Distance(compIdentifier, compPassword)
-	lcs := float32(lcsLength(compIdentifier, compPassword)) / float32(len(compPassword))
-	if dist < s.minIdentifierPasswordDist || lcs > s.maxIdentifierPasswordSubstrThreshold {
-		return errors.Errorf("the password is too similar to the user identifier")
+	for _, denied := range policy.DenyList {
+		if strings.EqualFold(password, denied) {
+			return newPolicyViolationError(text.NewErrorValidationPasswordDenied())
+		}
+	}
+
+	if policy.IdentifierSimilarityCheckEnabled {
+		compIdentifier, compPassword := strings.ToLower(identifier), strings.ToLower(password)
+		dist := levenshtein.Distance(compIdentifier, compPassword)
+		lcs := float64(lcsLength(compIdentifier, compPassword)) / float64(len(compPassword))
+		if dist < policy.MinIdentifierDistance || lcs > policy.MaxIdentifierSubstringRatio {
+			return newPolicyViolationError(text.NewErrorValidationPasswordIdentifierSimilarity())
+		}
+	}
+
+	if policy.MinStrengthScore > 0 {
+		if score := strengthScore(identifier, password); score < policy.MinStrengthScore {
+			return newPolicyViolationError(text.NewErrorValidationPasswordTooWeak(policy.MinStrengthScore, score))
+		}
+	}
+
+	return nil
+}
+
+func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, password string) error {
+	if err := s.validatePolicy(ctx, identifier, password); err != nil {
+		return err
 	}
 
+	return s.validateBreaches(ctx, password)
+}
+
+func (s *DefaultPasswordValidator) validateBreaches(ctx context.Context, password string) error {
+
 	/* #nosec G401 sha1 is used for k-anonymity */
 	h := sha1.New()
 	if _, err := h.Write([]byte(password)); err != nil {
@@ -160,23 +258,35 @@ func (s *DefaultPasswordValidator) Validate(ctx context.Context, identifier, pas
 	}
 	hpw := h.Sum(nil)
 
+	if dataset := s.reg.Config(ctx).PasswordPolicyConfig().HaveIBeenPwnedDataset; len(dataset) > 0 {
+		c, err := lookupBreachedDataset(dataset, b20(hpw))
+		if err != nil {
+			return err
+		}
+		return s.checkBreaches(ctx, c)
+	}
+
 	s.RLock()
 	c, ok := s.hashes[b20(hpw)]
 	s.RUnlock()
 
 	if !ok {
-		err := s.fetch(hpw)
+		err := s.fetch(ctx, hpw)
 		if (errors.Is(err, ErrNetworkFailure) || errors.Is(err, ErrUnexpectedStatusCode)) && s.reg.Config(ctx).PasswordPolicyConfig().IgnoreNetworkErrors {
 			return nil
 		} else if err != nil {
 			return err
 		}
 
-		return s.Validate(ctx, identifier, password)
+		return s.validateBreaches(ctx, password)
 	}
 
-	if c > int64(s.reg.Config(ctx).PasswordPolicyConfig().MaxBreaches) {
-		return errors.New("the password has been found in data breaches and must no longer be used.")
+	return s.checkBreaches(ctx, c)
+}
+
+func (s *DefaultPasswordValidator) checkBreaches(ctx context.Context, count int64) error {
+	if count > int64(s.reg.Config(ctx).PasswordPolicyConfig().MaxBreaches) {
+		return newPolicyViolationError(text.NewErrorValidationPasswordBreached())
 	}
 
 	return nil
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/internal"
 	"github.com/ory/kratos/selfservice/strategy/password"
+	"github.com/ory/kratos/text"
 )
 
 func TestDefaultPasswordValidationStrategy(t *testing.T) {
@@ -75,6 +78,18 @@ func TestDefaultPasswordValidationStrategy(t *testing.T) {
 		require.Contains(t, fakeClient.RequestedURLs(), "https://api.pwnedpasswords.com/range/BCBA9")
 	})
 
+	t.Run("case=should send request to the configured range API", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
+		conf.MustSet(config.ViperKeyPasswordHaveIBeenPwnedURL, "https://pwned-passwords.internal/range")
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyPasswordHaveIBeenPwnedURL, nil)
+		})
+
+		fakeClient.RespondWith(http.StatusOK, "")
+		require.NoError(t, s.Validate(context.Background(), "", "vokzuzjudo"))
+		require.Contains(t, fakeClient.RequestedURLs(), "https://pwned-passwords.internal/range/"+password.BreachedPasswordHash("vokzuzjudo")[:5])
+	})
+
 	t.Run("case=should fail if request fails and ignoreNetworkErrors is not set", func(t *testing.T) {
 		conf.MustSet(config.ViperKeyIgnoreNetworkErrors, false)
 		fakeClient.RespondWithError("Network request failed")
@@ -159,6 +174,73 @@ func TestDefaultPasswordValidationStrategy(t *testing.T) {
 	}
 }
 
+func TestDefaultPasswordValidationPolicy(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+
+	s := password.NewDefaultPasswordValidatorStrategy(reg)
+	fakeClient := NewFakeHTTPClient()
+	fakeClient.RespondWith(http.StatusOK, "")
+	s.Client = &fakeClient.Client
+
+	for k, tc := range []struct {
+		config   map[string]interface{}
+		id       string
+		pw       string
+		expected text.ID
+	}{
+		{pw: "a8Kd3", expected: text.ErrorValidationPasswordMinLength},
+		{pw: "a8Kd3m"},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinLength: 12}, pw: "a8Kd3mz0Pq", expected: text.ErrorValidationPasswordMinLength},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinLength: 12}, pw: "a8Kd3mz0Pq7x"},
+		{config: map[string]interface{}{config.ViperKeyPasswordMaxLength: 10}, pw: "a8Kd3mz0Pq7", expected: text.ErrorValidationPasswordMaxLength},
+		{config: map[string]interface{}{config.ViperKeyPasswordMaxLength: 10}, pw: "a8Kd3mz0Pq"},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinLength: 6}, pw: "äöüßéè"},
+		{config: map[string]interface{}{config.ViperKeyPasswordRequiredCharacterClasses: []string{"lowercase", "uppercase", "number", "symbol"}}, pw: "A8KD3MZ!", expected: text.ErrorValidationPasswordCharacterClass},
+		{config: map[string]interface{}{config.ViperKeyPasswordRequiredCharacterClasses: []string{"lowercase", "uppercase", "number", "symbol"}}, pw: "a8kd3mz!", expected: text.ErrorValidationPasswordCharacterClass},
+		{config: map[string]interface{}{config.ViperKeyPasswordRequiredCharacterClasses: []string{"lowercase", "uppercase", "number", "symbol"}}, pw: "aBkdXmz!", expected: text.ErrorValidationPasswordCharacterClass},
+		{config: map[string]interface{}{config.ViperKeyPasswordRequiredCharacterClasses: []string{"lowercase", "uppercase", "number", "symbol"}}, pw: "a8Kd3mzP", expected: text.ErrorValidationPasswordCharacterClass},
+		{config: map[string]interface{}{config.ViperKeyPasswordRequiredCharacterClasses: []string{"lowercase", "uppercase", "number", "symbol"}}, pw: "a8Kd3mz!"},
+		{config: map[string]interface{}{config.ViperKeyPasswordDenyList: []string{"acme2021"}}, pw: "ACME2021", expected: text.ErrorValidationPasswordDenied},
+		{config: map[string]interface{}{config.ViperKeyPasswordDenyList: []string{"acme2021"}}, pw: "acme2021x"},
+		{id: "hello@example.com", pw: "hello@example.com12345", expected: text.ErrorValidationPasswordIdentifierSimilarity},
+		{config: map[string]interface{}{config.ViperKeyPasswordIdentifierSimilarityCheckEnabled: false}, id: "hello@example.com", pw: "hello@example.com12345"},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinIdentifierDistance: 0, config.ViperKeyPasswordMaxIdentifierSubstringRatio: 1}, id: "hello@example.com", pw: "hello@example.com12345"},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinStrengthScore: 3}, pw: "abcdefgh", expected: text.ErrorValidationPasswordTooWeak},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinStrengthScore: 3}, pw: "correct horse battery staple"},
+		{config: map[string]interface{}{config.ViperKeyPasswordMinStrengthScore: 4}, pw: strings.Repeat("l3f9toh1uaf81n21", 20)},
+	} {
+		t.Run(fmt.Sprintf("case=%d", k), func(t *testing.T) {
+			for key, value := range tc.config {
+				conf.MustSet(key, value)
+			}
+			t.Cleanup(func() {
+				for key := range tc.config {
+					conf.MustSet(key, nil)
+				}
+			})
+
+			err := s.Validate(context.Background(), tc.id, tc.pw)
+			if tc.expected == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var violation *password.PolicyViolationError
+			require.True(t, errors.As(err, &violation), "%+v", err)
+			assert.Equal(t, tc.expected, violation.Message.ID, "%s", violation.Message.Text)
+		})
+	}
+
+	t.Run("case=should report breached passwords", func(t *testing.T) {
+		fakeClient.RespondWith(http.StatusOK, "280915F3B572F94217D86F1D63BED53F66A:1")
+		err := s.Validate(context.Background(), "", "tafpabdopa")
+
+		var violation *password.PolicyViolationError
+		require.True(t, errors.As(err, &violation), "%+v", err)
+		assert.Equal(t, text.ErrorValidationPasswordBreached, violation.Message.ID)
+	})
+}
+
 type fakeHttpClient struct {
 	http.Client
 
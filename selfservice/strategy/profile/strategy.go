@@ -24,6 +24,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow"
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/ratelimit"
 	"github.com/ory/kratos/selfservice/strategy"
 	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
@@ -62,6 +63,8 @@ type (
 		settings.HooksProvider
 
 		schema.IdentityTraitsProvider
+
+		ratelimit.ManagementProvider
 	}
 	Strategy struct {
 		d  strategyDependencies
@@ -85,7 +88,7 @@ func (s *Strategy) SettingsStrategyID() string {
 func (s *Strategy) RegisterSettingsRoutes(public *x.RouterPublic) {
 	s.d.CSRFHandler().IgnorePath(RouteSettings)
 
-	wrappedHandleSubmit := strategy.IsDisabled(s.d, s.SettingsStrategyID(), s.handleSubmit)
+	wrappedHandleSubmit := s.d.RateLimiter().Handle(ratelimit.FlowSettings, s.SettingsStrategyID(), strategy.IsDisabled(s.d, s.SettingsStrategyID(), s.handleSubmit))
 	public.POST(RouteSettings, s.d.SessionHandler().IsAuthenticated(wrappedHandleSubmit, settings.OnUnauthenticated(s.d)))
 	public.GET(RouteSettings, s.d.SessionHandler().IsAuthenticated(wrappedHandleSubmit, settings.OnUnauthenticated(s.d)))
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/ory/kratos/selfservice/flow/settings"
 	"github.com/ory/kratos/selfservice/form"
 	"github.com/ory/kratos/selfservice/strategy/profile"
+	"github.com/ory/kratos/session"
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/assertx"
 	"github.com/ory/x/httpx"
@@ -252,6 +253,26 @@ func TestStrategyTraits(t *testing.T) {
 		})
 	})
 
+	t.Run("description=should reject sessions which require a password change", func(t *testing.T) {
+		id := newIdentityWithPassword("john-expired@doe.com")
+		s := session.NewActiveSession(id, testhelpers.NewSessionLifespanProvider(time.Hour), time.Now())
+		session.WithPasswordChangeRequired()(s)
+		hc := testhelpers.NewHTTPClientWithSessionToken(t, reg, s)
+
+		rs := testhelpers.InitializeSettingsFlowViaAPI(t, hc, publicTS)
+		f := testhelpers.GetSettingsFlowMethodConfig(t, rs.Payload, settings.StrategyProfile)
+		values := testhelpers.SDKFormFieldsToURLValues(f.Fields)
+		values.Set("traits.stringy", "changed")
+
+		actual, res := testhelpers.SettingsMakeRequest(t, true, f, hc, testhelpers.EncodeFormAsJSON(t, true, values))
+		assert.Equal(t, http.StatusForbidden, res.StatusCode, "%s", actual)
+		assert.True(t, gjson.Get(actual, "error.details.password_change_required").Bool(), "%s", actual)
+
+		actualIdentity, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(context.Background(), id.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "foobar", gjson.GetBytes(actualIdentity.Traits, "stringy").String())
+	})
+
 	t.Run("description=should end up at the login endpoint if trying to update protected field without sudo mode", func(t *testing.T) {
 		var run = func(t *testing.T, config *models.SettingsFlowMethodConfig, isAPI bool, c *http.Client) *http.Response {
 			time.Sleep(time.Millisecond)
@@ -0,0 +1,5 @@
+package saml
+
+const (
+	sessionName = "ory_kratos_saml_auth_session"
+)
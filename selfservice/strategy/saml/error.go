@@ -0,0 +1,11 @@
+package saml
+
+import "github.com/ory/herodot"
+
+var (
+	ErrAPIFlowNotSupported = herodot.ErrBadRequest.WithError("API-based flows are not supported for this method").
+				WithReasonf("SAML Single Sign-On is only supported for flows initiated using the Browser endpoint.")
+
+	ErrInvalidResponse = herodot.ErrBadRequest.WithError("the SAML response is invalid").
+				WithReasonf("Unable to complete SAML flow because the Identity Provider returned an invalid SAML response.")
+)
@@ -0,0 +1,22 @@
+package saml
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tidwall/sjson"
+
+	"github.com/ory/x/decoderx"
+)
+
+func decoderRegistration(ref string) (decoderx.HTTPDecoderOption, error) {
+	raw, err := sjson.SetBytes([]byte(registrationFormPayloadSchema), "properties.traits.$ref", ref+"#/properties/traits")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	o, err := decoderx.HTTPRawJSONSchemaCompiler(raw)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return o, nil
+}
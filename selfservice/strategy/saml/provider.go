@@ -0,0 +1,163 @@
+package saml
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"strings"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	"github.com/pkg/errors"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/urlx"
+)
+
+// Claims contains the data of a SAML assertion which is made available to the Jsonnet mapper.
+type Claims struct {
+	// Issuer is the Identity Provider's entity ID.
+	Issuer string `json:"iss"`
+
+	// Subject is the value of the assertion's NameID.
+	Subject string `json:"sub"`
+
+	// Attributes contains the assertion's attribute values indexed by their name and, if set, by their
+	// friendly name.
+	Attributes map[string][]string `json:"attributes"`
+}
+
+func newClaims(assertion *saml.Assertion) (*Claims, error) {
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("Unable to complete SAML flow because the SAML assertion does not contain a subject."))
+	}
+
+	claims := &Claims{
+		Issuer:     assertion.Issuer.Value,
+		Subject:    assertion.Subject.NameID.Value,
+		Attributes: map[string][]string{},
+	}
+
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			var values []string
+			for _, value := range attribute.Values {
+				values = append(values, value.Value)
+			}
+
+			claims.Attributes[attribute.Name] = append(claims.Attributes[attribute.Name], values...)
+			if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+				claims.Attributes[attribute.FriendlyName] = append(claims.Attributes[attribute.FriendlyName], values...)
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+func (s *Strategy) serviceProvider(ctx context.Context, r *http.Request, id string) (*Configuration, *saml.ServiceProvider, error) {
+	c, err := s.Config(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider, err := c.Provider(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.KeyURL == "" || c.CertificateURL == "" {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The SAML Service Provider certificate and private key are not configured."))
+	}
+
+	key, err := s.privateKey(c.KeyURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := s.certificate(c.CertificateURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	public := s.d.Config(ctx).SelfPublicURL(r)
+	return provider, &saml.ServiceProvider{
+		Key:               key,
+		Certificate:       cert,
+		MetadataURL:       *urlx.AppendPaths(urlx.Copy(public), strings.Replace(RouteMetadata, ":provider", provider.ID, 1)),
+		AcsURL:            *urlx.AppendPaths(urlx.Copy(public), RouteACS),
+		AuthnNameIDFormat: saml.PersistentNameIDFormat,
+		SignatureMethod:   dsig.RSASHA256SignatureMethod,
+	}, nil
+}
+
+// serviceProviderWithMetadata returns the Service Provider with the metadata of the Identity Provider, which is
+// required to send authentication requests and to validate responses.
+func (s *Strategy) serviceProviderWithMetadata(ctx context.Context, r *http.Request, id string) (*Configuration, *saml.ServiceProvider, error) {
+	provider, sp, err := s.serviceProvider(ctx, r, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := s.f.Fetch(provider.MetadataURL)
+	if err != nil {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf(`Unable to fetch the metadata of SAML Identity Provider "%s".`, id).WithDebug(err.Error()))
+	}
+
+	sp.IDPMetadata, err = samlsp.ParseMetadata(raw.Bytes())
+	if err != nil {
+		return nil, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf(`Unable to parse the metadata of SAML Identity Provider "%s".`, id).WithDebug(err.Error()))
+	}
+
+	return provider, sp, nil
+}
+
+func (s *Strategy) privateKey(location string) (*rsa.PrivateKey, error) {
+	raw, err := s.f.Fetch(location)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to fetch the SAML Service Provider private key.").WithDebug(err.Error()))
+	}
+
+	block, _ := pem.Decode(raw.Bytes())
+	if block == nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The SAML Service Provider private key is not PEM encoded."))
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to parse the SAML Service Provider private key.").WithDebug(err.Error()))
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The SAML Service Provider private key must be a RSA key."))
+	}
+
+	return rsaKey, nil
+}
+
+func (s *Strategy) certificate(location string) (*x509.Certificate, error) {
+	raw, err := s.f.Fetch(location)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to fetch the SAML Service Provider certificate.").WithDebug(err.Error()))
+	}
+
+	block, _ := pem.Decode(raw.Bytes())
+	if block == nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("The SAML Service Provider certificate is not PEM encoded."))
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReason("Unable to parse the SAML Service Provider certificate.").WithDebug(err.Error()))
+	}
+
+	return cert, nil
+}
@@ -0,0 +1,44 @@
+package saml
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+type Configuration struct {
+	// ID is the provider's ID. It is part of the Service Provider's metadata URL which is also used as the
+	// Service Provider's entity ID.
+	ID string `json:"id"`
+
+	// MetadataURL is the location of the Identity Provider's SAML 2.0 metadata.
+	//
+	// It can be a URL (file://, http(s)://, base64://).
+	MetadataURL string `json:"metadata_url"`
+
+	// Mapper specifies the JSONNet code snippet which uses the SAML assertion's subject and attributes to hydrate
+	// the identity's data.
+	//
+	// It can be either a URL (file://, http(s)://, base64://) or an inline JSONNet code snippet.
+	Mapper string `json:"mapper_url"`
+}
+
+type ConfigurationCollection struct {
+	// CertificateURL is the location of the Service Provider's PEM encoded X.509 certificate.
+	CertificateURL string `json:"certificate_url"`
+
+	// KeyURL is the location of the Service Provider's PEM encoded RSA private key which is used to sign
+	// authentication requests and to decrypt assertions.
+	KeyURL string `json:"key_url"`
+
+	Providers []Configuration `json:"providers"`
+}
+
+func (c ConfigurationCollection) Provider(id string) (*Configuration, error) {
+	for k := range c.Providers {
+		if c.Providers[k].ID == id {
+			return &c.Providers[k], nil
+		}
+	}
+	return nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(`SAML Identity Provider "%s" is unknown or has not been configured`, id))
+}
@@ -0,0 +1,448 @@
+package saml
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/gofrs/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/fetcher"
+	"github.com/ory/x/jsonx"
+	"github.com/ory/x/urlx"
+
+	"github.com/ory/kratos/audit"
+	"github.com/ory/kratos/continuity"
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/errorx"
+	"github.com/ory/kratos/selfservice/flow"
+	"github.com/ory/kratos/selfservice/flow/login"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/form"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	RouteBase = "/self-service/methods/saml"
+
+	RouteAuth     = RouteBase + "/auth/:flow"
+	RouteACS      = RouteBase + "/acs"
+	RouteMetadata = RouteBase + "/metadata/:provider"
+)
+
+var _ identity.ActiveCredentialsCounter = new(Strategy)
+
+type dependencies interface {
+	errorx.ManagementProvider
+
+	config.Provider
+
+	x.LoggingProvider
+	x.CSRFProvider
+	x.CSRFTokenGeneratorProvider
+	x.WriterProvider
+
+	identity.ValidationProvider
+	identity.PrivilegedPoolProvider
+
+	session.ManagementProvider
+
+	login.HookExecutorProvider
+	login.FlowPersistenceProvider
+	login.HandlerProvider
+	login.ErrorHandlerProvider
+
+	registration.HookExecutorProvider
+	registration.FlowPersistenceProvider
+	registration.HandlerProvider
+	registration.ErrorHandlerProvider
+
+	continuity.ManagementProvider
+
+	audit.RecorderProvider
+}
+
+// resubmitTemplate posts the SAML response to the Assertion Consumer Service again. Browsers do not send
+// SameSite=Lax cookies along with the cross-site POST request of the Identity Provider, but they do for
+// this same-site request.
+var resubmitTemplate = template.Must(template.New("saml-resubmit").Parse(`<html><body>` +
+	`<form method="post" action="{{.URL}}" id="SAMLResponseForm">` +
+	`<input type="hidden" name="SAMLResponse" value="{{.SAMLResponse}}" />` +
+	`<input type="hidden" name="RelayState" value="{{.RelayState}}" />` +
+	`<input type="hidden" name="resubmitted" value="true" />` +
+	`<noscript><input type="submit" value="Continue" /></noscript>` +
+	`</form>` +
+	`<script>document.getElementById('SAMLResponseForm').submit();</script>` +
+	`</body></html>`))
+
+// Strategy implements selfservice.LoginStrategy and selfservice.RegistrationStrategy. It supports login and
+// registration via SAML 2.0 Identity Providers.
+type Strategy struct {
+	d dependencies
+	f *fetcher.Fetcher
+}
+
+func NewStrategy(d dependencies) *Strategy {
+	return &Strategy{
+		d: d,
+		f: fetcher.NewFetcher(),
+	}
+}
+
+func (s *Strategy) ID() identity.CredentialsType {
+	return identity.CredentialsTypeSAML
+}
+
+func (s *Strategy) CountActiveCredentials(cc map[identity.CredentialsType]identity.Credentials) (count int, err error) {
+	for _, c := range cc {
+		if c.Type == s.ID() && gjson.ValidBytes(c.Config) {
+			var conf CredentialsConfig
+			if err = json.Unmarshal(c.Config, &conf); err != nil {
+				return 0, errors.WithStack(err)
+			}
+
+			for _, ider := range c.Identifiers {
+				for _, prov := range conf.Providers {
+					if ider == uid(prov.Provider, prov.Subject) && len(prov.Subject) > 0 && len(prov.Provider) > 0 {
+						count++
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+func (s *Strategy) setRoutes(r *x.RouterPublic) {
+	wrappedHandleAuth := strategy.IsDisabled(s.d, s.ID().String(), s.handleAuth)
+	if handle, _, _ := r.Lookup("POST", RouteAuth); handle == nil {
+		r.POST(RouteAuth, wrappedHandleAuth)
+	}
+
+	if handle, _, _ := r.Lookup("GET", RouteAuth); handle == nil {
+		r.GET(RouteAuth, wrappedHandleAuth)
+	}
+
+	if handle, _, _ := r.Lookup("POST", RouteACS); handle == nil {
+		// The Identity Provider posts the SAML response without a CSRF token.
+		s.d.CSRFHandler().IgnorePath(RouteACS)
+		r.POST(RouteACS, strategy.IsDisabled(s.d, s.ID().String(), s.handleACS))
+	}
+
+	if handle, _, _ := r.Lookup("GET", RouteMetadata); handle == nil {
+		r.GET(RouteMetadata, strategy.IsDisabled(s.d, s.ID().String(), s.handleMetadata))
+	}
+}
+
+func (s *Strategy) handleAuth(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	rid := x.ParseUUID(ps.ByName("flow"))
+	if err := r.ParseForm(); err != nil {
+		s.handleError(w, r, rid, "", nil, errors.WithStack(herodot.ErrBadRequest.WithDebug(err.Error()).WithReasonf("Unable to parse HTTP form request: %s", err.Error())))
+		return
+	}
+
+	var pid = r.Form.Get("provider") // this can come from both url query and post body
+	if pid == "" {
+		s.handleError(w, r, rid, pid, nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`The HTTP request did not contain the required "provider" form field`)))
+		return
+	}
+
+	req, err := s.validateFlow(r.Context(), r, rid)
+	if err != nil {
+		s.handleError(w, r, rid, pid, nil, err)
+		return
+	}
+
+	if s.alreadyAuthenticated(w, r, req) {
+		return
+	}
+
+	_, sp, err := s.serviceProviderWithMetadata(r.Context(), r, pid)
+	if err != nil {
+		s.handleError(w, r, rid, pid, nil, err)
+		return
+	}
+
+	binding := saml.HTTPRedirectBinding
+	location := sp.GetSSOBindingLocation(binding)
+	if location == "" {
+		binding = saml.HTTPPostBinding
+		location = sp.GetSSOBindingLocation(binding)
+	}
+
+	if location == "" {
+		s.handleError(w, r, rid, pid, nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf(`SAML Identity Provider "%s" does not support the HTTP-Redirect or HTTP-POST binding.`, pid)))
+		return
+	}
+
+	authnRequest, err := sp.MakeAuthenticationRequest(location)
+	if err != nil {
+		s.handleError(w, r, rid, pid, nil, errors.WithStack(err))
+		return
+	}
+
+	state := x.NewUUID().String()
+	if err := s.d.ContinuityManager().Pause(r.Context(), w, r, sessionName,
+		continuity.WithPayload(&authContainer{
+			FlowID:    rid.String(),
+			Provider:  pid,
+			RequestID: authnRequest.ID,
+			State:     state,
+			Form:      r.PostForm,
+		}),
+		continuity.WithLifespan(time.Minute*30)); err != nil {
+		s.handleError(w, r, rid, pid, nil, err)
+		return
+	}
+
+	if binding == saml.HTTPRedirectBinding {
+		http.Redirect(w, r, authnRequest.Redirect(state).String(), http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(authnRequest.Post(state))
+}
+
+func (s *Strategy) handleACS(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if err := r.ParseForm(); err != nil {
+		s.handleError(w, r, x.EmptyUUID, "", nil, errors.WithStack(herodot.ErrBadRequest.WithDebug(err.Error()).WithReasonf("Unable to parse HTTP form request: %s", err.Error())))
+		return
+	}
+
+	var container authContainer
+	if _, err := s.d.ContinuityManager().Continue(r.Context(), w, r, sessionName, continuity.WithPayload(&container)); err != nil {
+		if errors.Is(err, &continuity.ErrNotResumable) && r.PostForm.Get("resubmitted") == "" {
+			s.resubmit(w, r)
+			return
+		}
+
+		s.handleError(w, r, x.EmptyUUID, "", nil, err)
+		return
+	}
+
+	if r.PostForm.Get("RelayState") != container.State {
+		s.handleError(w, r, x.EmptyUUID, container.Provider, nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf(`Unable to complete SAML flow because the RelayState parameter does not match the state from the session cookie.`)))
+		return
+	}
+
+	req, err := s.validateFlow(r.Context(), r, x.ParseUUID(container.FlowID))
+	if err != nil {
+		s.handleError(w, r, x.ParseUUID(container.FlowID), container.Provider, nil, err)
+		return
+	}
+
+	if s.alreadyAuthenticated(w, r, req) {
+		return
+	}
+
+	provider, sp, err := s.serviceProviderWithMetadata(r.Context(), r, container.Provider)
+	if err != nil {
+		s.handleError(w, r, req.GetID(), container.Provider, nil, err)
+		return
+	}
+
+	assertion, err := sp.ParseResponse(r, []string{container.RequestID})
+	if err != nil {
+		var ire *saml.InvalidResponseError
+		if errors.As(err, &ire) && ire.PrivateErr != nil {
+			err = ErrInvalidResponse.WithDebug(ire.PrivateErr.Error())
+		}
+		s.handleError(w, r, req.GetID(), provider.ID, nil, errors.WithStack(err))
+		return
+	}
+
+	claims, err := newClaims(assertion)
+	if err != nil {
+		s.handleError(w, r, req.GetID(), provider.ID, nil, err)
+		return
+	}
+
+	switch a := req.(type) {
+	case *login.Flow:
+		s.processLogin(w, r, a, claims, provider, &container)
+		return
+	case *registration.Flow:
+		s.processRegistration(w, r, a, claims, provider, &container)
+		return
+	default:
+		s.handleError(w, r, req.GetID(), provider.ID, nil, errors.WithStack(x.PseudoPanic.
+			WithDetailf("cause", "Unexpected type in SAML flow: %T", a)))
+		return
+	}
+}
+
+func (s *Strategy) resubmit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := resubmitTemplate.Execute(w, struct {
+		URL          string
+		SAMLResponse string
+		RelayState   string
+	}{
+		URL:          urlx.AppendPaths(urlx.Copy(s.d.Config(r.Context()).SelfPublicURL(r)), RouteACS).String(),
+		SAMLResponse: r.PostForm.Get("SAMLResponse"),
+		RelayState:   r.PostForm.Get("RelayState"),
+	}); err != nil {
+		s.d.Logger().WithRequest(r).WithError(err).Error("Unable to render the SAML response form.")
+	}
+}
+
+func (s *Strategy) handleMetadata(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	_, sp, err := s.serviceProvider(r.Context(), r, ps.ByName("provider"))
+	if err != nil {
+		s.d.Writer().WriteError(w, r, err)
+		return
+	}
+
+	metadata := sp.Metadata()
+	for k := range metadata.SPSSODescriptors {
+		// Single Logout is not supported.
+		metadata.SPSSODescriptors[k].SingleLogoutServices = nil
+	}
+
+	out, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		s.d.Writer().WriteError(w, r, errors.WithStack(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(out)
+}
+
+func (s *Strategy) validateFlow(ctx context.Context, r *http.Request, rid uuid.UUID) (ider, error) {
+	if x.IsZeroUUID(rid) {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReason("The session cookie contains invalid values and the flow could not be executed. Please try again."))
+	}
+
+	if ar, err := s.d.RegistrationFlowPersister().GetRegistrationFlow(ctx, rid); err == nil {
+		if ar.Type != flow.TypeBrowser {
+			return ar, ErrAPIFlowNotSupported
+		}
+
+		if err := ar.Valid(); err != nil {
+			return ar, err
+		}
+		return ar, nil
+	}
+
+	ar, err := s.d.LoginFlowPersister().GetLoginFlow(ctx, rid)
+	if err != nil {
+		return nil, err
+	}
+
+	if ar.Type != flow.TypeBrowser {
+		return ar, ErrAPIFlowNotSupported
+	}
+
+	if err := ar.Valid(); err != nil {
+		return ar, err
+	}
+	return ar, nil
+}
+
+func (s *Strategy) alreadyAuthenticated(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	// we assume an error means the user has no session
+	if _, err := s.d.SessionManager().FetchFromRequest(r.Context(), r); err == nil {
+		if f, ok := req.(interface{ IsForced() bool }); !ok || !f.IsForced() {
+			http.Redirect(w, r, s.d.Config(r.Context()).SelfServiceBrowserDefaultReturnTo().String(), http.StatusFound)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Strategy) authURL(ctx context.Context, r *http.Request, flowID uuid.UUID) string {
+	return urlx.AppendPaths(
+		urlx.Copy(s.d.Config(ctx).SelfPublicURL(r)),
+		strings.Replace(
+			RouteAuth, ":flow", flowID.String(), 1,
+		),
+	).String()
+}
+
+func (s *Strategy) populateMethod(r *http.Request, flowID uuid.UUID) (*FlowMethod, error) {
+	conf, err := s.Config(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	f := form.NewHTMLForm(s.authURL(r.Context(), r, flowID))
+	f.SetCSRF(s.d.GenerateCSRFToken(r))
+	// does not need sorting because there is only one field
+
+	return NewFlowMethod(f).AddProviders(conf.Providers), nil
+}
+
+func (s *Strategy) Config(ctx context.Context) (*ConfigurationCollection, error) {
+	var c ConfigurationCollection
+
+	conf := s.d.Config(ctx).SelfServiceStrategy(string(s.ID())).Config
+	if err := jsonx.
+		NewStrictDecoder(bytes.NewBuffer(conf)).
+		Decode(&c); err != nil {
+		s.d.Logger().WithError(err).WithField("config", conf)
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("Unable to decode SAML Identity Provider configuration: %s", err))
+	}
+
+	return &c, nil
+}
+
+func (s *Strategy) handleError(w http.ResponseWriter, r *http.Request, rid uuid.UUID, provider string, traits []byte, err error) {
+	if x.IsZeroUUID(rid) {
+		s.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
+		return
+	}
+
+	if lr, rerr := s.d.LoginFlowPersister().GetLoginFlow(r.Context(), rid); rerr == nil {
+		s.d.AuditRecorder().Record(r.Context(), audit.NewSelfServiceEvent(r, audit.EventTypeLogin, s.ID().String(), uuid.Nil, err))
+		s.d.LoginFlowErrorHandler().WriteFlowError(w, r, s.ID(), lr, err)
+		return
+	} else if rr, rerr := s.d.RegistrationFlowPersister().GetRegistrationFlow(r.Context(), rid); rerr == nil {
+		if method, ok := rr.Methods[s.ID()]; ok {
+			method.Config.UnsetField("provider")
+			method.Config.Reset()
+
+			if traits != nil {
+				for _, field := range form.NewHTMLFormFromJSON("", traits, "traits").Fields {
+					method.Config.SetField(field)
+				}
+			}
+
+			if errSec := method.Config.ParseError(err); errSec != nil {
+				s.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, s.ID(), rr, errSec)
+				return
+			}
+			method.Config.ResetMessages()
+
+			method.Config.SetCSRF(s.d.GenerateCSRFToken(r))
+			if errSec := method.Config.SortFields(s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String()); errSec != nil {
+				s.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, s.ID(), rr, errors.Wrap(err, errSec.Error()))
+				return
+			}
+
+			method.Config.UnsetField("provider")
+			method.Config.SetField(form.Field{Name: "provider", Value: provider, Type: "submit"})
+			rr.Methods[s.ID()] = method
+		}
+
+		s.d.RegistrationFlowErrorHandler().WriteFlowError(w, r, s.ID(), rr, err)
+		return
+	}
+
+	s.d.SelfServiceErrorManager().Forward(r.Context(), w, r, err)
+}
@@ -0,0 +1,182 @@
+package saml_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"html"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/logger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/x"
+)
+
+func newKeyPair(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kratos.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	return key, cert
+}
+
+func pemURL(block *pem.Block) string {
+	return "base64://" + base64.StdEncoding.EncodeToString(pem.EncodeToMemory(block))
+}
+
+func newServiceProviderKeyPair(t *testing.T) (keyURL, certificateURL string) {
+	key, cert := newKeyPair(t)
+	return pemURL(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		pemURL(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+// identityProvider is a SAML 2.0 Identity Provider which signs in the configured session without prompting.
+type identityProvider struct {
+	*saml.IdentityProvider
+	ts *httptest.Server
+
+	sync.Mutex
+	session *saml.Session
+	// encrypt toggles whether assertions are encrypted with the Service Provider's certificate.
+	encrypt bool
+}
+
+func (p *identityProvider) GetSession(_ http.ResponseWriter, _ *http.Request, _ *saml.IdpAuthnRequest) *saml.Session {
+	p.Lock()
+	defer p.Unlock()
+	return p.session
+}
+
+func (p *identityProvider) GetServiceProvider(_ *http.Request, serviceProviderID string) (*saml.EntityDescriptor, error) {
+	res, err := http.Get(serviceProviderID)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata saml.EntityDescriptor
+	if err := xml.Unmarshal(body, &metadata); err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if !p.encrypt {
+		for k := range metadata.SPSSODescriptors {
+			metadata.SPSSODescriptors[k].KeyDescriptors = nil
+		}
+	}
+
+	return &metadata, nil
+}
+
+func (p *identityProvider) signIn(nameID, email string) {
+	p.Lock()
+	defer p.Unlock()
+	p.session = &saml.Session{
+		ID:         x.NewUUID().String(),
+		CreateTime: time.Now(),
+		ExpireTime: time.Now().Add(time.Hour),
+		NameID:     nameID,
+		UserEmail:  email,
+	}
+}
+
+func (p *identityProvider) setEncrypt(encrypt bool) {
+	p.Lock()
+	defer p.Unlock()
+	p.encrypt = encrypt
+}
+
+func (p *identityProvider) metadataURL() string {
+	return p.ts.URL + "/metadata"
+}
+
+func newIdentityProvider(t *testing.T) *identityProvider {
+	key, cert := newKeyPair(t)
+	p := &identityProvider{encrypt: true}
+	p.ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.Handler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(p.ts.Close)
+
+	metadataURL, err := url.Parse(p.ts.URL + "/metadata")
+	require.NoError(t, err)
+	ssoURL, err := url.Parse(p.ts.URL + "/sso")
+	require.NoError(t, err)
+
+	p.IdentityProvider = &saml.IdentityProvider{
+		Key:                     key,
+		Certificate:             cert,
+		Logger:                  logger.DefaultLogger,
+		MetadataURL:             *metadataURL,
+		SSOURL:                  *ssoURL,
+		ServiceProviderProvider: p,
+		SessionProvider:         p,
+	}
+	return p
+}
+
+var samlFormValue = func(name string) *regexp.Regexp {
+	return regexp.MustCompile(`name="` + name + `" value="([^"]*)"`)
+}
+
+// submitSAMLResponse extracts the SAML response from the auto-submitting HTML form of the Identity Provider and
+// submits it to the Assertion Consumer Service.
+func submitSAMLResponse(t *testing.T, client *http.Client, body []byte) (*http.Response, []byte) {
+	action := regexp.MustCompile(`action="([^"]*)"`).FindSubmatch(body)
+	require.Len(t, action, 2, "%s", body)
+
+	values := url.Values{}
+	for _, name := range []string{"SAMLResponse", "RelayState"} {
+		match := samlFormValue(name).FindSubmatch(body)
+		require.Len(t, match, 2, "%s", body)
+		values.Set(name, html.UnescapeString(string(match[1])))
+	}
+
+	res, err := client.PostForm(html.UnescapeString(string(action[1])), values)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	out, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, out
+}
+
+func newClient(t *testing.T) *http.Client {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	return &http.Client{Jar: jar}
+}
@@ -0,0 +1,126 @@
+package saml
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/selfservice/flow/registration"
+	"github.com/ory/kratos/selfservice/strategy"
+	"github.com/ory/kratos/x"
+)
+
+const (
+	registrationFormPayloadSchema = `{
+  "$id": "https://schemas.ory.sh/kratos/selfservice/saml/registration/config.schema.json",
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "traits": {}
+  }
+}`
+)
+
+var _ registration.Strategy = new(Strategy)
+
+func (s *Strategy) RegisterRegistrationRoutes(r *x.RouterPublic) {
+	s.setRoutes(r)
+}
+
+func (s *Strategy) PopulateRegistrationMethod(r *http.Request, sr *registration.Flow) error {
+	config, err := s.populateMethod(r, sr.ID)
+	if err != nil {
+		return err
+	}
+	sr.Methods[s.ID()] = &registration.FlowMethod{
+		Method: s.ID(),
+		Config: &registration.FlowMethodConfig{FlowMethodConfigurator: config},
+	}
+	return nil
+}
+
+func (s *Strategy) processRegistration(w http.ResponseWriter, r *http.Request, a *registration.Flow, claims *Claims, provider *Configuration, container *authContainer) {
+	if _, _, err := s.d.PrivilegedIdentityPool().FindByCredentialsIdentifier(r.Context(), identity.CredentialsTypeSAML, uid(provider.ID, claims.Subject)); err == nil {
+		// If the identity already exists, we perform the login flow instead.
+		s.d.Logger().WithRequest(r).WithField("provider", provider.ID).
+			WithField("subject", claims.Subject).
+			Debug("Received successful SAML response but user is already registered. Re-initializing login flow now.")
+
+		ar, err := s.d.LoginHandler().NewLoginFlow(w, r, a.Type)
+		if err != nil {
+			s.handleError(w, r, a.GetID(), provider.ID, nil, err)
+			return
+		}
+
+		s.processLogin(w, r, ar, claims, provider, container)
+		return
+	}
+
+	i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+
+	evaluated, err := strategy.EvaluateMapper(s.f, provider.Mapper, claims)
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.ID, nil, err)
+		return
+	} else if traits := gjson.Get(evaluated, "identity.traits"); !traits.IsObject() {
+		i.Traits = []byte{'{', '}'}
+		s.d.Logger().
+			WithRequest(r).
+			WithField("saml_provider", provider.ID).
+			WithSensitiveField("saml_claims", claims).
+			WithField("mapper_jsonnet_output", evaluated).
+			WithField("mapper_jsonnet_url", provider.Mapper).
+			Error("SAML Jsonnet mapper did not return an object for key identity.traits. Please check your Jsonnet code!")
+	} else {
+		i.Traits = []byte(traits.Raw)
+	}
+
+	if metadata := gjson.Get(evaluated, "identity.metadata_public"); metadata.Exists() {
+		i.MetadataPublic = []byte(metadata.Raw)
+	}
+
+	if metadata := gjson.Get(evaluated, "identity.metadata_admin"); metadata.Exists() {
+		i.MetadataAdmin = []byte(metadata.Raw)
+	}
+
+	s.d.Logger().
+		WithRequest(r).
+		WithField("saml_provider", provider.ID).
+		WithSensitiveField("saml_claims", claims).
+		WithField("mapper_jsonnet_output", evaluated).
+		WithField("mapper_jsonnet_url", provider.Mapper).
+		Debug("SAML Jsonnet mapper completed.")
+
+	option, err := decoderRegistration(s.d.Config(r.Context()).DefaultIdentityTraitsSchemaURL().String())
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.ID, nil, err)
+		return
+	}
+
+	i.Traits, err = strategy.MergeFormTraits(container.Form.Encode(), json.RawMessage(i.Traits), option)
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.ID, nil, err)
+		return
+	}
+
+	// Validate the identity itself
+	if err := s.d.IdentityValidator().Validate(r.Context(), i); err != nil {
+		s.handleError(w, r, a.GetID(), provider.ID, i.Traits, err)
+		return
+	}
+
+	creds, err := NewCredentials(provider.ID, claims.Subject)
+	if err != nil {
+		s.handleError(w, r, a.GetID(), provider.ID, i.Traits, err)
+		return
+	}
+
+	i.SetCredentials(s.ID(), *creds)
+	if err := s.d.RegistrationExecutor().PostRegistrationHook(w, r, identity.CredentialsTypeSAML, a, i); err != nil {
+		s.handleError(w, r, a.GetID(), provider.ID, i.Traits, err)
+		return
+	}
+}
@@ -0,0 +1,243 @@
+package saml_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	samlstrategy "github.com/ory/kratos/selfservice/strategy/saml"
+	"github.com/ory/kratos/x"
+)
+
+func TestStrategy(t *testing.T) {
+	ctx := context.Background()
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	idp := newIdentityProvider(t)
+	keyURL, certificateURL := newServiceProviderKeyPair(t)
+
+	conf.MustSet(config.ViperKeySelfServiceStrategyConfig+"."+string(identity.CredentialsTypeSAML),
+		map[string]interface{}{"enabled": true, "config": &samlstrategy.ConfigurationCollection{
+			KeyURL:         keyURL,
+			CertificateURL: certificateURL,
+			Providers: []samlstrategy.Configuration{{
+				ID:          "idp",
+				MetadataURL: idp.metadataURL(),
+				Mapper:      "file://./stub/saml.jsonnet",
+			}},
+		}})
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/registration.schema.json")
+	conf.MustSet(config.HookStrategyKey(config.ViperKeySelfServiceRegistrationAfter,
+		identity.CredentialsTypeSAML.String()), []config.SelfServiceHook{{Name: "session"}})
+
+	uiTS := testhelpers.NewUIFlowEchoServer(t, reg)
+	returnTS := testhelpers.NewRedirSessionEchoTS(t, reg)
+	errTS := testhelpers.NewErrorTestServer(t, reg)
+	publicTS, _ := testhelpers.NewKratosServer(t, reg)
+
+	metadataURL := publicTS.URL + strings.Replace(samlstrategy.RouteMetadata, ":provider", "idp", 1)
+	acsURL := publicTS.URL + samlstrategy.RouteACS
+
+	var authenticate = func(t *testing.T, client *http.Client, flowID string) []byte {
+		res, err := client.PostForm(publicTS.URL+strings.Replace(samlstrategy.RouteAuth, ":flow", flowID, 1), url.Values{"provider": {"idp"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Contains(t, res.Request.URL.String(), idp.ts.URL, "%s", body)
+		return body
+	}
+
+	var login = func(t *testing.T, client *http.Client) []byte {
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false).Payload
+		return authenticate(t, client, string(*f.ID))
+	}
+
+	var register = func(t *testing.T, client *http.Client) []byte {
+		f := testhelpers.InitializeRegistrationFlowViaBrowser(t, client, publicTS).Payload
+		return authenticate(t, client, string(*f.ID))
+	}
+
+	var assertIdentity = func(t *testing.T, res *http.Response, body []byte, email string) string {
+		require.Contains(t, res.Request.URL.String(), returnTS.URL, "%s", body)
+		assert.Equal(t, email, gjson.GetBytes(body, "identity.traits.email").String(), "%s", body)
+		return gjson.GetBytes(body, "identity.id").String()
+	}
+
+	var assertFlowError = func(t *testing.T, res *http.Response, body []byte, reason string) {
+		require.Contains(t, res.Request.URL.String(), uiTS.URL+"/login", "%s", body)
+		assert.Contains(t, gjson.GetBytes(body, "methods.saml.config.messages.0.text").String(), reason, "%s", body)
+	}
+
+	t.Run("case=should serve the service provider metadata", func(t *testing.T) {
+		res, err := http.Get(metadataURL)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/samlmetadata+xml", res.Header.Get("Content-Type"))
+
+		var metadata saml.EntityDescriptor
+		require.NoError(t, xml.NewDecoder(res.Body).Decode(&metadata))
+		assert.Equal(t, metadataURL, metadata.EntityID)
+		require.Len(t, metadata.SPSSODescriptors, 1)
+
+		sp := metadata.SPSSODescriptors[0]
+		require.NotNil(t, sp.AuthnRequestsSigned)
+		assert.True(t, *sp.AuthnRequestsSigned)
+		require.NotNil(t, sp.WantAssertionsSigned)
+		assert.True(t, *sp.WantAssertionsSigned)
+		require.Len(t, sp.AssertionConsumerServices, 1)
+		assert.Equal(t, acsURL, sp.AssertionConsumerServices[0].Location)
+		assert.Equal(t, saml.HTTPPostBinding, sp.AssertionConsumerServices[0].Binding)
+		assert.Empty(t, sp.SingleLogoutServices)
+	})
+
+	t.Run("case=should return 404 for unknown providers", func(t *testing.T) {
+		res, err := http.Get(publicTS.URL + strings.Replace(samlstrategy.RouteMetadata, ":provider", "unknown", 1))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("case=should send a signed authentication request", func(t *testing.T) {
+		client := newClient(t)
+		f := testhelpers.InitializeLoginFlowViaBrowser(t, client, publicTS, false).Payload
+
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		res, err := client.PostForm(publicTS.URL+strings.Replace(samlstrategy.RouteAuth, ":flow", string(*f.ID), 1), url.Values{"provider": {"idp"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusFound, res.StatusCode)
+
+		location, err := url.Parse(res.Header.Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, idp.ts.URL+"/sso", location.Scheme+"://"+location.Host+location.Path)
+		assert.NotEmpty(t, location.Query().Get("RelayState"))
+
+		req, err := saml.NewIdpAuthnRequest(idp.IdentityProvider, &http.Request{Method: "GET", URL: location})
+		require.NoError(t, err)
+		require.NoError(t, xml.Unmarshal(req.RequestBuffer, &req.Request))
+		assert.Equal(t, metadataURL, req.Request.Issuer.Value)
+		assert.Equal(t, acsURL, req.Request.AssertionConsumerServiceURL)
+		assert.NotNil(t, req.Request.Signature)
+	})
+
+	subject := x.NewUUID().String()
+	email := subject + "@ory.sh"
+
+	var id string
+	t.Run("case=should register an unknown user when signing in", func(t *testing.T) {
+		idp.signIn(subject, email)
+		client := newClient(t)
+		res, body := submitSAMLResponse(t, client, login(t, client))
+		id = assertIdentity(t, res, body, email)
+
+		i, err := reg.PrivilegedIdentityPool().GetIdentityConfidential(ctx, x.ParseUUID(id))
+		require.NoError(t, err)
+		creds, ok := i.GetCredentials(identity.CredentialsTypeSAML)
+		require.True(t, ok)
+		assert.Equal(t, []string{"idp:" + subject}, creds.Identifiers)
+	})
+
+	t.Run("case=should sign in an existing user", func(t *testing.T) {
+		idp.signIn(subject, email)
+		client := newClient(t)
+		res, body := submitSAMLResponse(t, client, login(t, client))
+		assert.Equal(t, id, assertIdentity(t, res, body, email))
+	})
+
+	t.Run("case=should sign in an existing user when registering", func(t *testing.T) {
+		idp.signIn(subject, email)
+		client := newClient(t)
+		res, body := submitSAMLResponse(t, client, register(t, client))
+		assert.Equal(t, id, assertIdentity(t, res, body, email))
+	})
+
+	t.Run("case=should accept unencrypted assertions", func(t *testing.T) {
+		idp.setEncrypt(false)
+		t.Cleanup(func() { idp.setEncrypt(true) })
+
+		idp.signIn(subject, email)
+		client := newClient(t)
+		res, body := submitSAMLResponse(t, client, login(t, client))
+		assert.Equal(t, id, assertIdentity(t, res, body, email))
+	})
+
+	t.Run("case=should reject assertions with an invalid signature", func(t *testing.T) {
+		idp.setEncrypt(false)
+		t.Cleanup(func() { idp.setEncrypt(true) })
+
+		idp.signIn(subject, email)
+		client := newClient(t)
+		form := login(t, client)
+
+		match := samlFormValue("SAMLResponse").FindSubmatch(form)
+		require.Len(t, match, 2)
+		response, err := base64.StdEncoding.DecodeString(string(match[1]))
+		require.NoError(t, err)
+		require.Contains(t, string(response), subject)
+
+		tampered := bytes.ReplaceAll(response, []byte(subject), []byte(x.NewUUID().String()))
+		form = bytes.Replace(form, match[1], []byte(base64.StdEncoding.EncodeToString(tampered)), 1)
+
+		res, body := submitSAMLResponse(t, client, form)
+		assertFlowError(t, res, body, "invalid SAML response")
+	})
+
+	t.Run("case=should reject responses to other authentication requests", func(t *testing.T) {
+		idp.signIn(subject, email)
+		client := newClient(t)
+		previous := login(t, client)
+
+		// Start a new authentication request which replaces the previous one.
+		_ = login(t, client)
+
+		res, body := submitSAMLResponse(t, client, previous)
+		require.Contains(t, res.Request.URL.String(), errTS.URL, "%s", body)
+		assert.Contains(t, gjson.GetBytes(body, "0.reason").String(), "RelayState", "%s", body)
+	})
+
+	t.Run("case=should resubmit the response if the session cookie is missing", func(t *testing.T) {
+		idp.signIn(subject, email)
+		client := newClient(t)
+		form := login(t, client)
+
+		res, body := submitSAMLResponse(t, &http.Client{}, form)
+		require.Equal(t, http.StatusOK, res.StatusCode, "%s", body)
+		assert.Contains(t, string(body), `name="resubmitted" value="true"`)
+		assert.Contains(t, string(body), `action="`+acsURL+`"`)
+
+		res, body = submitSAMLResponse(t, client, body)
+		assert.Equal(t, id, assertIdentity(t, res, body, email))
+	})
+
+	t.Run("case=should fail if the session cookie is missing after resubmitting", func(t *testing.T) {
+		idp.signIn(subject, email)
+		client := newClient(t)
+		form := login(t, client)
+
+		match := samlFormValue("SAMLResponse").FindSubmatch(form)
+		require.Len(t, match, 2)
+		res, err := http.PostForm(acsURL, url.Values{"SAMLResponse": {string(match[1])}, "resubmitted": {"true"}})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Contains(t, res.Request.URL.String(), errTS.URL)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ory/herodot"
 
 	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
 )
 
@@ -23,6 +24,7 @@ type (
 		x.WriterProvider
 		x.LoggingProvider
 		x.CSRFProvider
+		identity.ExporterProvider
 	}
 	HandlerProvider interface {
 		SessionHandler() *Handler
@@ -43,8 +45,9 @@ func NewHandler(
 }
 
 const (
-	RouteWhoami = "/sessions/whoami"
-	RouteRevoke = "/sessions"
+	RouteWhoami       = "/sessions/whoami"
+	RouteWhoamiExport = RouteWhoami + "/export"
+	RouteRevoke       = "/sessions"
 	// SessionsWhoisPath  = "/sessions/whois"
 )
 
@@ -57,6 +60,7 @@ func (h *Handler) RegisterPublicRoutes(public *x.RouterPublic) {
 		public.Handle(m, RouteWhoami, h.whoami)
 	}
 
+	public.GET(RouteWhoamiExport, h.export)
 	public.DELETE(RouteRevoke, h.revoke)
 }
 
@@ -137,6 +141,9 @@ type whoamiParameters struct {
 // Returns a session object in the body or 401 if the credentials are invalid or no credentials were sent.
 // Additionally when the request it successful it adds the user ID to the 'X-Kratos-Authenticated-Identity-Id' header in the response.
 //
+// If the identity signed in with an expired password, this endpoint returns 403 until the password was changed
+// using the settings flow.
+//
 // This endpoint is useful for reverse proxies and API Gateways.
 //
 //     Produces:
@@ -150,17 +157,17 @@ type whoamiParameters struct {
 //     Responses:
 //       200: session
 //       401: genericError
+//       403: genericError
 //       500: genericError
 func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	s, err := h.fetchUsableSession(r)
 	if err != nil {
-		h.r.Audit().WithRequest(r).WithError(err).Info("No valid session cookie found.")
-		h.r.Writer().WriteError(w, r, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found."))
+		h.r.Writer().WriteError(w, r, err)
 		return
 	}
 
 	// s.Devices = nil
-	s.Identity = s.Identity.CopyWithoutCredentials()
+	s.Identity = s.Identity.Declassify()
 
 	// Set userId as the X-Kratos-Authenticated-Identity-Id header.
 	w.Header().Set("X-Kratos-Authenticated-Identity-Id", s.Identity.ID.String())
@@ -168,9 +175,81 @@ func (h *Handler) whoami(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	h.r.Writer().Write(w, r, s)
 }
 
+// fetchUsableSession returns the session of the request, or an error if there is none or if it can not be used
+// until the identity's password was changed.
+func (h *Handler) fetchUsableSession(r *http.Request) (*Session, error) {
+	s, err := h.r.SessionManager().FetchFromRequest(r.Context(), r)
+	if e := new(ErrPasswordChangeRequired); errors.As(err, &e) {
+		h.r.Audit().WithRequest(r).Info("The session requires a password change.")
+		return nil, err
+	} else if err != nil {
+		h.r.Audit().WithRequest(r).WithError(err).Info("No valid session cookie found.")
+		return nil, herodot.ErrUnauthorized.WithWrap(err).WithReasonf("No valid session cookie found.")
+	}
+
+	return s, nil
+}
+
+// nolint:deadcode,unused
+// swagger:parameters exportWhoami
+type exportWhoamiParameters struct {
+	// in: header
+	Cookie string `json:"Cookie"`
+
+	// in: authorization
+	Authorization string `json:"Authorization"`
+}
+
+// swagger:route GET /sessions/whoami/export public exportWhoami
+//
+// Export the Personal Data of the Current HTTP Session's Identity
+//
+// Uses the HTTP Headers in the GET request to determine who is authenticated, and returns all data stored about
+// that identity in one JSON document: its traits and addresses, the OpenID Connect providers it is linked with,
+// its sessions including the devices they were issued to, and its recent audit events. Credentials and the
+// identity's admin metadata are never exported.
+//
+// If the identity signed in with an expired password, this endpoint returns 403 until the password was changed
+// using the settings flow.
+//
+//     Produces:
+//     - application/json
+//
+//     Schemes: http, https
+//
+//     Security:
+//       sessionToken:
+//
+//     Responses:
+//       200: identityExportResponse
+//       401: genericError
+//       403: genericError
+//       500: genericError
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s, err := h.fetchUsableSession(r)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	e, err := h.r.IdentityExporter().Export(r.Context(), s.IdentityID)
+	if err != nil {
+		h.r.Writer().WriteError(w, r, err)
+		return
+	}
+
+	h.r.Audit().WithRequest(r).WithField("identity_id", s.IdentityID).Info("An identity exported its personal data.")
+	h.r.Writer().Write(w, r, e.Declassify())
+}
+
 func (h *Handler) IsAuthenticated(wrap httprouter.Handle, onUnauthenticated httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		if _, err := h.r.SessionManager().FetchFromRequest(r.Context(), r); err != nil {
+			if e := new(ErrPasswordChangeRequired); errors.As(err, &e) {
+				h.r.Writer().WriteError(w, r, err)
+				return
+			}
+
 			if onUnauthenticated != nil {
 				onUnauthenticated(w, r, ps)
 				return
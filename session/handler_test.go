@@ -3,8 +3,10 @@ package session_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 
 	"github.com/ory/x/pointerx"
 
@@ -20,6 +23,7 @@ import (
 
 	"github.com/ory/kratos-client-go/client/public"
 	"github.com/ory/kratos-client-go/models"
+	"github.com/ory/kratos/audit"
 	"github.com/ory/kratos/driver/config"
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/internal"
@@ -80,6 +84,122 @@ func TestSessionWhoAmI(t *testing.T) {
 				assert.NotEmpty(t, res.Header.Get("X-Kratos-Authenticated-Identity-Id"))
 			})
 		}
+
+		t.Run("case=should not expose admin metadata", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://stub/identity.schema.json")
+			i := &identity.Identity{
+				Traits:         identity.Traits(`{}`),
+				MetadataPublic: []byte(`{"plan":"premium"}`),
+				MetadataAdmin:  []byte(`{"crm_id":"foo"}`),
+			}
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+			sess := NewActiveSession(i, conf, time.Now())
+			require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), sess))
+
+			req, err := http.NewRequest("GET", ts.URL+RouteWhoami, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+sess.Token)
+
+			res, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			require.EqualValues(t, http.StatusOK, res.StatusCode, "%s", body)
+			assert.EqualValues(t, "premium", gjson.GetBytes(body, "identity.metadata_public.plan").String(), "%s", body)
+			assert.False(t, gjson.GetBytes(body, "identity.metadata_admin").Exists(), "%s", body)
+		})
+	})
+}
+
+func TestSessionWhoAmIExport(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	publicTS, adminTS := testhelpers.NewKratosServer(t, reg)
+	conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://stub/identity.schema.json")
+
+	i := &identity.Identity{
+		Traits:        identity.Traits(`{"baz":"export"}`),
+		MetadataAdmin: []byte(`{"crm_id":"foo"}`),
+		Credentials: map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{x.NewUUID().String()},
+				Config:      []byte(`{"hashed_password":"secret"}`),
+			},
+		},
+	}
+	require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), i))
+	sess := NewActiveSession(i, conf, time.Now())
+	sess.UserAgent = "Mozilla/5.0"
+	require.NoError(t, reg.SessionPersister().CreateSession(context.Background(), sess))
+
+	var export = func(t *testing.T, token string, expectCode int) gjson.Result {
+		req, err := http.NewRequest("GET", publicTS.URL+RouteWhoamiExport, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := publicTS.Client().Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		require.EqualValues(t, expectCode, res.StatusCode, "%s", body)
+		return gjson.ParseBytes(body)
+	}
+
+	t.Run("case=should require a session", func(t *testing.T) {
+		export(t, "", http.StatusUnauthorized)
+	})
+
+	t.Run("case=should export the session's identity", func(t *testing.T) {
+		res := export(t, sess.Token, http.StatusOK)
+		assert.Equal(t, i.ID.String(), res.Get("identity.id").String(), "%s", res.Raw)
+		assert.Equal(t, "export", res.Get("identity.traits.baz").String(), "%s", res.Raw)
+		assert.False(t, res.Get("identity.credentials").Exists(), "%s", res.Raw)
+		assert.False(t, res.Get("identity.metadata_admin").Exists(), "%s", res.Raw)
+		assert.NotContains(t, res.Raw, "secret")
+		assert.NotContains(t, res.Raw, sess.Token)
+
+		require.Len(t, res.Get("sessions").Array(), 1, "%s", res.Raw)
+		assert.Equal(t, sess.ID.String(), res.Get("sessions.0.id").String(), "%s", res.Raw)
+		assert.Equal(t, "Mozilla/5.0", res.Get("sessions.0.user_agent").String(), "%s", res.Raw)
+		assert.Empty(t, res.Get("oidc_providers").Array(), "%s", res.Raw)
+		assert.True(t, res.Get("audit_events").IsArray(), "%s", res.Raw)
+	})
+
+	t.Run("case=should not export the client details of admins", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyAuditLogEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyAuditLogEnabled, false)
+		})
+
+		req, err := http.NewRequest("PATCH", adminTS.URL+"/identities/"+i.ID.String(),
+			strings.NewReader(`[{"op":"replace","path":"/traits/baz","value":"patched"}]`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "admin-client/1.0")
+		res, err := adminTS.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.EqualValues(t, http.StatusOK, res.StatusCode)
+
+		actual, err := reg.AuditEventPersister().ListAuditEvents(context.Background(), audit.Filter{IdentityID: i.ID}, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, actual, 1)
+		require.Equal(t, audit.ActorAdmin, actual[0].Actor)
+		require.Equal(t, "admin-client/1.0", actual[0].UserAgent)
+
+		exported := export(t, sess.Token, http.StatusOK)
+		events := exported.Get("audit_events").Array()
+		require.Len(t, events, 1, "%s", exported.Raw)
+		assert.Equal(t, string(audit.ActorAdmin), events[0].Get("actor").String(), "%s", exported.Raw)
+		assert.Empty(t, events[0].Get("ip_address").String(), "%s", exported.Raw)
+		assert.Empty(t, events[0].Get("user_agent").String(), "%s", exported.Raw)
+		assert.NotContains(t, exported.Raw, "admin-client/1.0")
 	})
 }
 
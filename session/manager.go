@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/julienschmidt/httprouter"
+
 	"github.com/ory/herodot"
 )
 
@@ -12,6 +14,39 @@ var (
 	ErrNoActiveSessionFound = herodot.ErrUnauthorized.WithError("request does not have a valid authentication session").WithReason("No active session was found in this request.")
 )
 
+// ErrPasswordChangeRequired is returned when a session which was issued for an expired password is used for
+// anything but changing the password.
+type ErrPasswordChangeRequired struct {
+	*herodot.DefaultError
+}
+
+func NewErrPasswordChangeRequired() *ErrPasswordChangeRequired {
+	return &ErrPasswordChangeRequired{DefaultError: herodot.ErrForbidden.
+		WithReason("The password has expired and must be changed using the settings flow before the session can be used.").
+		WithDetail("password_change_required", true)}
+}
+
+type passwordChangeAllowedContextKey struct{}
+
+// WithPasswordChangeAllowed returns a context in which sessions that require a password change are accepted. It
+// must only be used by the requests which are needed to change the password.
+func WithPasswordChangeAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, passwordChangeAllowedContextKey{}, true)
+}
+
+// PasswordChangeAllowed returns true if sessions that require a password change are accepted in this context.
+func PasswordChangeAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(passwordChangeAllowedContextKey{}).(bool)
+	return allowed
+}
+
+// AllowPasswordChange wraps a handler which accepts sessions that require a password change.
+func AllowPasswordChange(wrap httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		wrap(w, r.WithContext(WithPasswordChangeAllowed(r.Context())), ps)
+	}
+}
+
 // Manager handles identity sessions.
 type Manager interface {
 	// CreateAndIssueCookie stores a session in the database and issues a cookie by calling IssueCookie.
@@ -25,6 +60,9 @@ type Manager interface {
 	IssueCookie(context.Context, http.ResponseWriter, *http.Request, *Session) error
 
 	// FetchFromRequest creates an HTTP session using cookies.
+	//
+	// Returns ErrPasswordChangeRequired if the session requires a password change, unless the context
+	// allows it (see WithPasswordChangeAllowed).
 	FetchFromRequest(context.Context, *http.Request) (*Session, error)
 
 	// PurgeFromRequest removes an HTTP session.
@@ -131,6 +131,15 @@ func (s *ManagerHTTP) FetchFromRequest(ctx context.Context, r *http.Request) (*S
 		return nil, errors.WithStack(ErrNoActiveSessionFound)
 	}
 
+	// Sessions of inactive identities must not be used even if they have not expired yet.
+	if !se.Identity.IsActive() {
+		return nil, errors.WithStack(ErrNoActiveSessionFound)
+	}
+
+	if se.PasswordChangeRequired && !PasswordChangeAllowed(ctx) {
+		return nil, errors.WithStack(NewErrPasswordChangeRequired())
+	}
+
 	se.Identity = se.Identity.CopyWithoutCredentials()
 	return se, nil
 }
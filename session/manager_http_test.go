@@ -29,6 +29,9 @@ type mockCSRFHandler struct {
 func (f *mockCSRFHandler) ExemptPath(s string) {
 }
 
+func (f *mockCSRFHandler) ExemptGlob(s string) {
+}
+
 func (f *mockCSRFHandler) IgnorePath(s string) {
 }
 
@@ -135,5 +138,25 @@ func TestManagerHTTP(t *testing.T) {
 			require.NoError(t, err)
 			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
 		})
+
+		t.Run("case=identity inactive", func(t *testing.T) {
+			i := identity.Identity{Traits: []byte("{}")}
+			require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(context.Background(), &i))
+			s = session.NewActiveSession(&i, conf, time.Now())
+
+			c := testhelpers.NewClientWithCookies(t)
+			testhelpers.MockHydrateCookieClient(t, c, pts.URL+"/session/set")
+
+			res, err := c.Get(pts.URL + "/session/get")
+			require.NoError(t, err)
+			assert.EqualValues(t, http.StatusOK, res.StatusCode)
+
+			i.State = identity.StateInactive
+			require.NoError(t, reg.IdentityManager().Update(context.Background(), &i, identity.ManagerAllowWriteProtectedTraits))
+
+			res, err = c.Get(pts.URL + "/session/get")
+			require.NoError(t, err)
+			assert.EqualValues(t, http.StatusUnauthorized, res.StatusCode)
+		})
 	})
 }
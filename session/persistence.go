@@ -45,6 +45,21 @@ type Persister interface {
 
 	// RevokeSessionByToken marks a session inactive with the given token.
 	RevokeSessionByToken(ctx context.Context, token string) error
+
+	// RevokeSessionsByUpstream marks all sessions inactive which were created from the upstream identity provider's
+	// session. At least one of subject and sessionID must be set; empty values match all sessions.
+	RevokeSessionsByUpstream(ctx context.Context, provider, subject, sessionID string) error
+
+	// ClearPasswordChangeRequired marks all sessions of the identity as no longer requiring a password change.
+	ClearPasswordChangeRequired(ctx context.Context, identity uuid.UUID) error
+
+	// ListSessionsByIdentity returns all sessions, including inactive ones, of the given identity ordered by
+	// creation date, newest first. The sessions' identity is not populated.
+	ListSessionsByIdentity(ctx context.Context, identity uuid.UUID) ([]Session, error)
+
+	// HasSessionWithUserAgent returns true if the identity has a session, other than the excluded one, which was
+	// issued to the given user agent. An empty user agent matches every session with a recorded user agent.
+	HasSessionWithUserAgent(ctx context.Context, identity, exclude uuid.UUID, userAgent string) (bool, error)
 }
 
 func TestPersister(ctx context.Context, conf *config.Config, p interface {
@@ -131,6 +146,158 @@ func TestPersister(ctx context.Context, conf *config.Config, p interface {
 			assert.False(t, actual.Active)
 		})
 
+		t.Run("case=revoke sessions by upstream", func(t *testing.T) {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+
+			create := func(provider, subject, sessionID string) *Session {
+				var s Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity, s.IdentityID, s.Active = &i, i.ID, true
+				WithUpstream(provider, subject, sessionID, "encrypted-id-token")(&s)
+				require.NoError(t, p.CreateSession(ctx, &s))
+				return &s
+			}
+
+			isActive := func(s *Session) bool {
+				actual, err := p.GetSession(ctx, s.ID)
+				require.NoError(t, err)
+				return actual.Active
+			}
+
+			subject := x.NewUUID().String()
+			first := create("provider", subject, "sid-1")
+			second := create("provider", subject, "sid-2")
+			other := create("other-provider", subject, "sid-1")
+			unrelated := create("", "", "")
+
+			actual, err := p.GetSession(ctx, first.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "provider", actual.UpstreamProvider)
+			assert.Equal(t, subject, actual.UpstreamSubject)
+			assert.Equal(t, "sid-1", actual.UpstreamSessionID)
+			assert.EqualValues(t, "encrypted-id-token", actual.UpstreamIDToken)
+
+			require.Error(t, p.RevokeSessionsByUpstream(ctx, "provider", "", ""))
+
+			require.NoError(t, p.RevokeSessionsByUpstream(ctx, "provider", "", "sid-1"))
+			assert.False(t, isActive(first))
+			assert.True(t, isActive(second))
+			assert.True(t, isActive(other))
+
+			require.NoError(t, p.RevokeSessionsByUpstream(ctx, "provider", subject, ""))
+			assert.False(t, isActive(second))
+			assert.True(t, isActive(other))
+			assert.True(t, isActive(unrelated))
+		})
+
+		t.Run("case=clear password change required", func(t *testing.T) {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+
+			create := func(identity *identity.Identity) *Session {
+				var s Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity, s.IdentityID, s.Active = identity, identity.ID, true
+				WithPasswordChangeRequired()(&s)
+				require.NoError(t, p.CreateSession(ctx, &s))
+				return &s
+			}
+
+			isRequired := func(s *Session) bool {
+				actual, err := p.GetSession(ctx, s.ID)
+				require.NoError(t, err)
+				return actual.PasswordChangeRequired
+			}
+
+			first, second := create(&i), create(&i)
+			var other identity.Identity
+			require.NoError(t, faker.FakeData(&other))
+			require.NoError(t, p.CreateIdentity(ctx, &other))
+			unrelated := create(&other)
+
+			assert.True(t, isRequired(first))
+			require.NoError(t, p.ClearPasswordChangeRequired(ctx, i.ID))
+			assert.False(t, isRequired(first))
+			assert.False(t, isRequired(second))
+			assert.True(t, isRequired(unrelated))
+		})
+
+		t.Run("case=list sessions by identity", func(t *testing.T) {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+
+			actual, err := p.ListSessionsByIdentity(ctx, i.ID)
+			require.NoError(t, err)
+			assert.Len(t, actual, 0)
+
+			var expected []uuid.UUID
+			for k := 0; k < 2; k++ {
+				var s Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity, s.IdentityID = &i, i.ID
+				s.IPAddress, s.UserAgent = "192.0.2.1", "Mozilla/5.0"
+				require.NoError(t, p.CreateSession(ctx, &s))
+				expected = append(expected, s.ID)
+			}
+
+			var other Session
+			require.NoError(t, faker.FakeData(&other))
+			require.NoError(t, p.CreateIdentity(ctx, other.Identity))
+			require.NoError(t, p.CreateSession(ctx, &other))
+
+			actual, err = p.ListSessionsByIdentity(ctx, i.ID)
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			assert.ElementsMatch(t, expected, []uuid.UUID{actual[0].ID, actual[1].ID})
+			assert.Equal(t, "192.0.2.1", actual[0].IPAddress)
+			assert.Equal(t, "Mozilla/5.0", actual[0].UserAgent)
+		})
+
+		t.Run("case=has session with user agent", func(t *testing.T) {
+			var i identity.Identity
+			require.NoError(t, faker.FakeData(&i))
+			require.NoError(t, p.CreateIdentity(ctx, &i))
+
+			var create = func(userAgent string) *Session {
+				var s Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity, s.IdentityID, s.UserAgent = &i, i.ID, userAgent
+				require.NoError(t, p.CreateSession(ctx, &s))
+				return &s
+			}
+
+			current := create("Mozilla/5.0")
+			has, err := p.HasSessionWithUserAgent(ctx, i.ID, current.ID, "")
+			require.NoError(t, err)
+			assert.False(t, has, "the excluded session must not match")
+
+			create("")
+			has, err = p.HasSessionWithUserAgent(ctx, i.ID, current.ID, "")
+			require.NoError(t, err)
+			assert.False(t, has, "sessions without a user agent must not match")
+
+			create("curl/7.64.1")
+			has, err = p.HasSessionWithUserAgent(ctx, i.ID, current.ID, "")
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			has, err = p.HasSessionWithUserAgent(ctx, i.ID, current.ID, "Mozilla/5.0")
+			require.NoError(t, err)
+			assert.False(t, has)
+
+			has, err = p.HasSessionWithUserAgent(ctx, i.ID, current.ID, "curl/7.64.1")
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			has, err = p.HasSessionWithUserAgent(ctx, x.NewUUID(), current.ID, "curl/7.64.1")
+			require.NoError(t, err)
+			assert.False(t, has, "sessions of other identities must not match")
+		})
+
 		t.Run("case=delete session for", func(t *testing.T) {
 			var expected1 Session
 			var expected2 Session
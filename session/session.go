@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/ory/kratos/corp"
@@ -9,6 +10,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/ory/x/randx"
+	"github.com/ory/x/sqlxx"
 
 	"github.com/ory/kratos/identity"
 	"github.com/ory/kratos/x"
@@ -33,6 +35,10 @@ type Session struct {
 	// required: true
 	Identity *identity.Identity `json:"identity" faker:"identity" db:"-" belongs_to:"identities" fk_id:"IdentityID"`
 
+	// PasswordChangeRequired is true if the identity signed in with an expired password. The session can only be
+	// used for changing the password until the password was changed.
+	PasswordChangeRequired bool `json:"password_change_required" faker:"-" db:"password_change_required"`
+
 	// IdentityID is a helper struct field for gobuffalo.pop.
 	IdentityID uuid.UUID `json:"-" faker:"-" db:"identity_id"`
 	// CreatedAt is a helper struct field for gobuffalo.pop.
@@ -41,6 +47,54 @@ type Session struct {
 	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
 
 	Token string `json:"-" db:"token"`
+
+	// UpstreamProvider is the ID of the upstream identity provider, e.g. an OpenID Connect Provider, the session
+	// was created from. It is empty if the session was not created from an upstream session.
+	UpstreamProvider string `json:"-" db:"upstream_provider"`
+
+	// UpstreamSubject is the subject of the identity at the upstream identity provider.
+	UpstreamSubject string `json:"-" db:"upstream_subject"`
+
+	// UpstreamSessionID is the upstream identity provider's session ID (the `sid` claim), if any.
+	UpstreamSessionID string `json:"-" db:"upstream_session_id"`
+
+	// UpstreamIDToken is the encrypted ID token which the upstream identity provider issued, used as a hint when
+	// ending the upstream session.
+	UpstreamIDToken sqlxx.NullString `json:"-" faker:"-" db:"upstream_id_token"`
+
+	// IPAddress is the IP address of the client which the session was issued to.
+	IPAddress string `json:"-" faker:"ipv4" db:"ip_address"`
+
+	// UserAgent is the user agent of the client which the session was issued to.
+	UserAgent string `json:"-" db:"user_agent"`
+}
+
+// Option modifies a session which is about to be issued.
+type Option func(s *Session)
+
+// WithUpstream remembers the upstream session the session was created from.
+func WithUpstream(provider, subject, sessionID, encryptedIDToken string) Option {
+	return func(s *Session) {
+		s.UpstreamProvider = provider
+		s.UpstreamSubject = subject
+		s.UpstreamSessionID = sessionID
+		s.UpstreamIDToken = sqlxx.NullString(encryptedIDToken)
+	}
+}
+
+// WithPasswordChangeRequired marks the session as requiring a password change because the password has expired.
+func WithPasswordChangeRequired() Option {
+	return func(s *Session) {
+		s.PasswordChangeRequired = true
+	}
+}
+
+// WithDevice remembers the IP address and user agent of the client the session is issued to.
+func WithDevice(r *http.Request) Option {
+	return func(s *Session) {
+		s.IPAddress = x.ClientIP(r)
+		s.UserAgent = r.UserAgent()
+	}
 }
 
 func (s Session) TableName(ctx context.Context) string {
@@ -68,7 +122,7 @@ type Device struct {
 }
 
 func (s *Session) Declassify() *Session {
-	s.Identity = s.Identity.CopyWithoutCredentials()
+	s.Identity = s.Identity.Declassify()
 	return s
 }
 
@@ -0,0 +1,110 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ory/x/sqlxx"
+
+	"github.com/ory/kratos/corp"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+// EventType is the type of an identity lifecycle event.
+type EventType string
+
+// EventStatus is the delivery status of an event in the outbox.
+type EventStatus string
+
+const (
+	EventTypeIdentityCreated EventType = "identity.created"
+	EventTypeIdentityUpdated EventType = "identity.updated"
+	EventTypeIdentityDeleted EventType = "identity.deleted"
+	EventTypeSessionCreated  EventType = "session.created"
+	EventTypeSessionRevoked  EventType = "session.revoked"
+
+	EventStatusPending   EventStatus = "pending"
+	EventStatusDelivered EventStatus = "delivered"
+	EventStatusFailed    EventStatus = "failed"
+)
+
+// Event is a change of an identity or session which is published to the event stream.
+type Event struct {
+	// ID is the event's unique ID. Events may be delivered more than once, consumers use the ID to
+	// detect duplicates.
+	ID uuid.UUID `json:"id" db:"id" faker:"-"`
+
+	// Type is the event's type.
+	Type EventType `json:"type" db:"type"`
+
+	// IdentityID is the ID of the identity which was changed or which the session belongs to.
+	IdentityID uuid.UUID `json:"identity_id" db:"identity_id" faker:"-"`
+
+	// SessionID is the ID of the session for session events.
+	SessionID uuid.NullUUID `json:"session_id,omitempty" db:"session_id" faker:"-"`
+
+	// Data is the identity (without credentials) or the session (without the identity) after the change.
+	Data sqlxx.JSONRawMessage `json:"data" db:"data" faker:"-"`
+
+	// Status is the event's delivery status.
+	Status EventStatus `json:"-" db:"status"`
+
+	// Attempts is the number of failed delivery attempts.
+	Attempts int `json:"-" db:"attempts"`
+
+	// CreatedAt is the time (UTC) when the change happened.
+	CreatedAt time.Time `json:"created_at" faker:"-" db:"created_at"`
+
+	// UpdatedAt is a helper struct field for gobuffalo.pop.
+	UpdatedAt time.Time `json:"-" faker:"-" db:"updated_at"`
+}
+
+func (Event) TableName(ctx context.Context) string {
+	return corp.ContextualizeTableName(ctx, "stream_events")
+}
+
+func newEvent(t EventType, identityID uuid.UUID, data interface{}) (*Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &Event{
+		ID:         x.NewUUID(),
+		Type:       t,
+		IdentityID: identityID,
+		Data:       raw,
+		Status:     EventStatusPending,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// NewIdentityEvent creates an event for the identity. Credentials are never part of the event.
+func NewIdentityEvent(t EventType, i *identity.Identity) (*Event, error) {
+	return newEvent(t, i.ID, i.CopyWithoutCredentials())
+}
+
+// NewIdentityDeletedEvent creates an event for the deletion of the identity.
+func NewIdentityDeletedEvent(id uuid.UUID) (*Event, error) {
+	return newEvent(EventTypeIdentityDeleted, id, map[string]interface{}{"id": id})
+}
+
+// NewSessionEvent creates an event for the session. The session's identity is not part of the event.
+func NewSessionEvent(t EventType, s *session.Session) (*Event, error) {
+	var ss = *s
+	ss.Identity = nil
+
+	e, err := newEvent(t, s.IdentityID, &ss)
+	if err != nil {
+		return nil, err
+	}
+
+	e.SessionID = uuid.NullUUID{UUID: s.ID, Valid: true}
+	return e, nil
+}
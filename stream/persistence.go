@@ -0,0 +1,213 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bxcodec/faker/v3"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/session"
+	"github.com/ory/kratos/x"
+)
+
+var ErrOutboxEmpty = errors.New("outbox is empty")
+
+type (
+	// OutboxPersister stores events in the outbox. Events are added by the identity and session persisters in the
+	// same transaction as the change itself, which is why there is no method for adding events.
+	OutboxPersister interface {
+		// NextStreamEvents returns the oldest pending events or ErrOutboxEmpty.
+		NextStreamEvents(ctx context.Context, limit uint8) ([]Event, error)
+
+		// SetStreamEventDelivered marks the event as delivered.
+		SetStreamEventDelivered(ctx context.Context, id uuid.UUID) error
+
+		// IncrementStreamEventAttempts records a failed delivery attempt. The event remains pending.
+		IncrementStreamEventAttempts(ctx context.Context, id uuid.UUID) error
+
+		// SetStreamEventFailed marks the event as failed. Failed events are no longer delivered.
+		SetStreamEventFailed(ctx context.Context, id uuid.UUID) error
+	}
+
+	OutboxPersistenceProvider interface {
+		StreamOutboxPersister() OutboxPersister
+	}
+)
+
+func TestPersister(ctx context.Context, conf *config.Config, p interface {
+	OutboxPersister
+	identity.PrivilegedPool
+	session.Persister
+}) func(t *testing.T) {
+	var drain = func(t *testing.T) []Event {
+		var result []Event
+		for {
+			events, err := p.NextStreamEvents(ctx, 10)
+			if errors.Is(err, ErrOutboxEmpty) {
+				return result
+			}
+			require.NoError(t, err)
+			for _, e := range events {
+				require.NoError(t, p.SetStreamEventDelivered(ctx, e.ID))
+			}
+			result = append(result, events...)
+		}
+	}
+
+	var types = func(events []Event) []EventType {
+		result := make([]EventType, len(events))
+		for k := range events {
+			result[k] = events[k].Type
+		}
+		return result
+	}
+
+	var newIdentity = func() *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+		i.Credentials = map[identity.CredentialsType]identity.Credentials{
+			identity.CredentialsTypePassword: {
+				Type:        identity.CredentialsTypePassword,
+				Identifiers: []string{x.NewUUID().String()},
+				Config:      []byte(`{"hashed_password":"secret"}`),
+			},
+		}
+		return i
+	}
+
+	return func(t *testing.T) {
+		conf.MustSet(config.ViperKeyDefaultIdentitySchemaURL, "file://./stub/identity.schema.json")
+		conf.MustSet(config.ViperKeyEventStreamEnabled, true)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyEventStreamEnabled, false)
+		})
+		_ = drain(t)
+
+		t.Run("case=should not add events if disabled", func(t *testing.T) {
+			conf.MustSet(config.ViperKeyEventStreamEnabled, false)
+			t.Cleanup(func() {
+				conf.MustSet(config.ViperKeyEventStreamEnabled, true)
+			})
+
+			require.NoError(t, p.CreateIdentity(ctx, newIdentity()))
+			assert.Empty(t, drain(t))
+		})
+
+		t.Run("case=should add identity events", func(t *testing.T) {
+			i := newIdentity()
+			require.NoError(t, p.CreateIdentity(ctx, i))
+			i.Traits = identity.Traits(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+			require.NoError(t, p.UpdateIdentity(ctx, i))
+			require.NoError(t, p.DeleteIdentity(ctx, i.ID))
+
+			actual := drain(t)
+			require.Len(t, actual, 3)
+			assert.ElementsMatch(t, []EventType{EventTypeIdentityCreated, EventTypeIdentityUpdated, EventTypeIdentityDeleted}, types(actual))
+			for _, e := range actual {
+				assert.Equal(t, i.ID, e.IdentityID)
+				assert.False(t, e.SessionID.Valid)
+				assert.Equal(t, i.ID.String(), gjson.GetBytes(e.Data, "id").String())
+				assert.False(t, gjson.GetBytes(e.Data, "credentials").Exists(), "%s", e.Data)
+			}
+		})
+
+		t.Run("case=should not add events if the change fails", func(t *testing.T) {
+			require.Error(t, p.DeleteIdentity(ctx, x.NewUUID()))
+
+			i := newIdentity()
+			i.ID = x.NewUUID()
+			require.Error(t, p.UpdateIdentity(ctx, i))
+
+			assert.Empty(t, drain(t))
+		})
+
+		t.Run("case=should add session events", func(t *testing.T) {
+			i := newIdentity()
+			require.NoError(t, p.CreateIdentity(ctx, i))
+
+			var sessions []session.Session
+			for k := 0; k < 3; k++ {
+				var s session.Session
+				require.NoError(t, faker.FakeData(&s))
+				s.Identity = i
+				s.IdentityID = i.ID
+				s.Active = true
+				s.UpstreamProvider = "provider"
+				s.UpstreamSessionID = x.NewUUID().String()
+				require.NoError(t, p.CreateSession(ctx, &s))
+				sessions = append(sessions, s)
+			}
+			_ = drain(t)
+
+			require.NoError(t, p.RevokeSessionByToken(ctx, sessions[0].Token))
+			require.NoError(t, p.RevokeSessionsByUpstream(ctx, "provider", "", sessions[1].UpstreamSessionID))
+			require.NoError(t, p.DeleteSession(ctx, sessions[2].ID))
+
+			actual := drain(t)
+			require.Len(t, actual, 3)
+			assert.Equal(t, []EventType{EventTypeSessionRevoked, EventTypeSessionRevoked, EventTypeSessionRevoked}, types(actual))
+
+			var ids []uuid.UUID
+			for _, e := range actual {
+				assert.Equal(t, i.ID, e.IdentityID)
+				assert.False(t, gjson.GetBytes(e.Data, "active").Bool(), "%s", e.Data)
+				assert.Equal(t, "null", gjson.GetBytes(e.Data, "identity").Raw, "%s", e.Data)
+				ids = append(ids, e.SessionID.UUID)
+			}
+			assert.ElementsMatch(t, []uuid.UUID{sessions[0].ID, sessions[1].ID, sessions[2].ID}, ids)
+
+			var s session.Session
+			require.NoError(t, faker.FakeData(&s))
+			s.Identity = i
+			s.IdentityID = i.ID
+			s.Active = true
+			require.NoError(t, p.CreateSession(ctx, &s))
+
+			actual = drain(t)
+			require.Len(t, actual, 1)
+			assert.Equal(t, EventTypeSessionCreated, actual[0].Type)
+			assert.Equal(t, s.ID, actual[0].SessionID.UUID)
+
+			require.NoError(t, p.DeleteSessionsByIdentity(ctx, i.ID))
+			assert.Equal(t, []EventType{EventTypeSessionRevoked}, types(drain(t)))
+		})
+
+		t.Run("case=should keep events pending until delivered", func(t *testing.T) {
+			require.NoError(t, p.CreateIdentity(ctx, newIdentity()))
+
+			first, err := p.NextStreamEvents(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, first, 1)
+			require.NoError(t, p.IncrementStreamEventAttempts(ctx, first[0].ID))
+
+			second, err := p.NextStreamEvents(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, second, 1)
+			assert.Equal(t, first[0].ID, second[0].ID)
+			assert.Equal(t, 1, second[0].Attempts)
+
+			require.NoError(t, p.SetStreamEventDelivered(ctx, second[0].ID))
+			_, err = p.NextStreamEvents(ctx, 10)
+			assert.True(t, errors.Is(err, ErrOutboxEmpty))
+		})
+
+		t.Run("case=should not return failed events", func(t *testing.T) {
+			require.NoError(t, p.CreateIdentity(ctx, newIdentity()))
+
+			events, err := p.NextStreamEvents(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+
+			require.NoError(t, p.SetStreamEventFailed(ctx, events[0].ID))
+			_, err = p.NextStreamEvents(ctx, 10)
+			assert.True(t, errors.Is(err, ErrOutboxEmpty))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/x"
+)
+
+type (
+	relayDependencies interface {
+		config.Provider
+		x.LoggingProvider
+		OutboxPersistenceProvider
+		SinkProvider
+	}
+
+	RelayProvider interface {
+		EventStreamRelay() *Relay
+	}
+
+	// Relay publishes the events in the outbox to the sink. Events are marked as delivered only after the sink
+	// accepted them, which means that every event is delivered at least once.
+	Relay struct {
+		d relayDependencies
+	}
+)
+
+func NewRelay(d relayDependencies) *Relay {
+	return &Relay{d: d}
+}
+
+// Work relays the outbox until the context is canceled.
+func (r *Relay) Work(ctx context.Context) error {
+	for {
+		if err := r.DispatchOutbox(ctx); err != nil {
+			r.d.Logger().WithError(err).Warn("Unable to relay the event stream outbox, will retry.")
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-time.After(r.d.Config(ctx).EventStreamRelayInterval()):
+		}
+	}
+}
+
+// DispatchOutbox publishes pending events, oldest first, until the outbox is empty. It stops at the first event
+// which could not be published so that events are delivered in order. Events which could not be published after
+// the configured number of attempts are marked as failed and skipped so that they do not block the stream.
+func (r *Relay) DispatchOutbox(ctx context.Context) error {
+	sink := r.d.EventStreamSink(ctx)
+	if sink == nil {
+		return nil
+	}
+
+	for {
+		events, err := r.d.StreamOutboxPersister().NextStreamEvents(ctx, 10)
+		if err != nil {
+			if errors.Is(err, ErrOutboxEmpty) {
+				return nil
+			}
+			return err
+		}
+
+		for k := range events {
+			e := events[k]
+			if err := sink.Publish(ctx, &e); err != nil {
+				if e.Attempts+1 >= r.d.Config(ctx).EventStreamRelayMaxAttempts() {
+					r.d.Logger().WithError(err).WithField("stream_event_id", e.ID).
+						WithField("stream_event_attempts", e.Attempts+1).
+						Error("Unable to deliver the event after the maximum number of attempts, marking it as failed.")
+					if err := r.d.StreamOutboxPersister().SetStreamEventFailed(ctx, e.ID); err != nil {
+						return err
+					}
+					continue
+				}
+
+				if err := r.d.StreamOutboxPersister().IncrementStreamEventAttempts(ctx, e.ID); err != nil {
+					r.d.Logger().WithError(err).WithField("stream_event_id", e.ID).
+						Error("Unable to record the failed delivery of the event.")
+				}
+				return err
+			}
+
+			if err := r.d.StreamOutboxPersister().SetStreamEventDelivered(ctx, e.ID); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ory/kratos/driver/config"
+	"github.com/ory/kratos/identity"
+	"github.com/ory/kratos/internal"
+	"github.com/ory/kratos/internal/testhelpers"
+	"github.com/ory/kratos/stream"
+	"github.com/ory/kratos/x"
+)
+
+func TestRelay(t *testing.T) {
+	conf, reg := internal.NewFastRegistryWithMocks(t)
+	testhelpers.SetDefaultIdentitySchema(t, conf, "file://./stub/identity.schema.json")
+	conf.MustSet(config.ViperKeyEventStreamEnabled, true)
+	ctx := context.Background()
+
+	var (
+		lock     sync.Mutex
+		received []stream.Event
+		status   = http.StatusNoContent
+	)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var e stream.Event
+		require.NoError(t, json.Unmarshal(body, &e))
+		received = append(received, e)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(sink.Close)
+
+	var setStatus = func(code int) {
+		lock.Lock()
+		defer lock.Unlock()
+		status = code
+		received = nil
+	}
+
+	var createIdentity = func(t *testing.T) *identity.Identity {
+		i := identity.NewIdentity(config.DefaultIdentityTraitsSchemaID)
+		i.Traits = identity.Traits(`{"email":"` + x.NewUUID().String() + `@ory.sh"}`)
+		require.NoError(t, reg.PrivilegedIdentityPool().CreateIdentity(ctx, i))
+		return i
+	}
+
+	t.Run("case=should keep events in the outbox if the sink type is outbox", func(t *testing.T) {
+		setStatus(http.StatusNoContent)
+		createIdentity(t)
+
+		assert.Nil(t, reg.EventStreamSink(ctx))
+		require.NoError(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+		assert.Empty(t, received)
+
+		events, err := reg.StreamOutboxPersister().NextStreamEvents(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, events, 1)
+	})
+
+	conf.MustSet(config.ViperKeyEventStreamSinkType, stream.SinkTypeHTTP)
+	conf.MustSet(config.ViperKeyEventStreamSinkURL, sink.URL)
+
+	t.Run("case=should publish pending events to the sink", func(t *testing.T) {
+		setStatus(http.StatusNoContent)
+		i := createIdentity(t)
+
+		require.NoError(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+		require.Len(t, received, 2)
+		assert.Equal(t, stream.EventTypeIdentityCreated, received[1].Type)
+		assert.Equal(t, i.ID, received[1].IdentityID)
+
+		require.NoError(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+		assert.Len(t, received, 2, "delivered events must not be published again")
+	})
+
+	t.Run("case=should retry events which the sink rejected", func(t *testing.T) {
+		setStatus(http.StatusBadGateway)
+		i := createIdentity(t)
+
+		require.Error(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+
+		setStatus(http.StatusNoContent)
+		require.NoError(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+		require.Len(t, received, 1)
+		assert.Equal(t, i.ID, received[0].IdentityID)
+	})
+
+	t.Run("case=should skip events which could not be delivered after the maximum attempts", func(t *testing.T) {
+		conf.MustSet(config.ViperKeyEventStreamRelayMaxAttempts, 2)
+		t.Cleanup(func() {
+			conf.MustSet(config.ViperKeyEventStreamRelayMaxAttempts, 10)
+		})
+
+		setStatus(http.StatusBadGateway)
+		createIdentity(t)
+
+		require.Error(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+		require.NoError(t, reg.EventStreamRelay().DispatchOutbox(ctx), "the event must be marked as failed after the second attempt")
+		_, err := reg.StreamOutboxPersister().NextStreamEvents(ctx, 10)
+		assert.True(t, errors.Is(err, stream.ErrOutboxEmpty))
+
+		setStatus(http.StatusNoContent)
+		i := createIdentity(t)
+		require.NoError(t, reg.EventStreamRelay().DispatchOutbox(ctx))
+		require.Len(t, received, 1, "the failed event must not block later events")
+		assert.Equal(t, i.ID, received[0].IdentityID)
+	})
+}
@@ -0,0 +1,71 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SinkTypeOutbox keeps events in the outbox table, from which they are read by the consumers, for example
+	// using change data capture.
+	SinkTypeOutbox = "outbox"
+
+	// SinkTypeHTTP relays the events from the outbox to an HTTP endpoint.
+	SinkTypeHTTP = "http"
+)
+
+type (
+	// Sink publishes events to their consumers. Sinks must be safe for concurrent use. Because events are
+	// delivered at least once, Publish may be called more than once for the same event.
+	Sink interface {
+		Publish(ctx context.Context, e *Event) error
+	}
+
+	SinkProvider interface {
+		// EventStreamSink returns the sink to which the outbox is relayed or nil if events remain in the outbox.
+		EventStreamSink(ctx context.Context) Sink
+	}
+
+	// HTTPSink sends each event as JSON using HTTP POST.
+	HTTPSink struct {
+		c *http.Client
+		u *url.URL
+	}
+)
+
+var _ Sink = new(HTTPSink)
+
+func NewHTTPSink(c *http.Client, u *url.URL) *HTTPSink {
+	return &HTTPSink{c: c, u: u}
+}
+
+// Publish sends the event and succeeds if the endpoint responds with a 2xx status code.
+func (s *HTTPSink) Publish(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.u.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.c.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("expected the event stream sink to respond with a 2xx status code but got: %d", res.StatusCode)
+	}
+
+	return nil
+}
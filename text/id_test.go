@@ -27,6 +27,10 @@ func TestIDs(t *testing.T) {
 	assert.Equal(t, 4000000, int(ErrorValidation))
 	assert.Equal(t, 4000001, int(ErrorValidationGeneric))
 	assert.Equal(t, 4000002, int(ErrorValidationRequired))
+	assert.Equal(t, 4000008, int(ErrorValidationIdentityInactive))
+	assert.Equal(t, 4000009, int(ErrorValidationPasswordMinLength))
+	assert.Equal(t, 4000015, int(ErrorValidationPasswordBreached))
+	assert.Equal(t, 4000016, int(ErrorValidationPasswordReused))
 
 	assert.Equal(t, 4010000, int(ErrorValidationLogin))
 	assert.Equal(t, 4010001, int(ErrorValidationLoginFlowExpired))
@@ -0,0 +1,32 @@
+package text
+
+const (
+	InfoSelfServiceCaptcha          ID = 1080000 + iota // 1080000
+	InfoSelfServiceCaptchaChallenge                     // 1080001
+)
+
+const (
+	ErrorValidationCaptcha        ID = 4080000 + iota // 4080000
+	ErrorValidationCaptchaInvalid                     // 4080001
+)
+
+func NewInfoSelfServiceCaptchaChallenge(provider, siteKey string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceCaptchaChallenge,
+		Text: "Please complete the CAPTCHA challenge.",
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"provider": provider,
+			"site_key": siteKey,
+		}),
+	}
+}
+
+func NewErrorValidationCaptchaInvalid() *Message {
+	return &Message{
+		ID:      ErrorValidationCaptchaInvalid,
+		Text:    "The CAPTCHA challenge was not completed or is invalid, please try again.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
@@ -6,14 +6,28 @@ import (
 )
 
 const (
-	InfoSelfServiceLogin ID = 1010000 + iota
+	InfoSelfServiceLogin                ID = 1010000 + iota // 1010000
+	InfoSelfServiceLoginLinkCredentials                     // 1010001
 )
 
 const (
 	ErrorValidationLogin            ID = 4010000 + iota // 4010000
 	ErrorValidationLoginFlowExpired                     // 4010001
+	ErrorValidationLoginLockedOut                       // 4010002
 )
 
+func NewInfoLoginLinkCredentials(provider, address string) *Message {
+	return &Message{
+		ID:   InfoSelfServiceLoginLinkCredentials,
+		Text: fmt.Sprintf("An account with the email address %s already exists. Sign in to link your %s account to it.", address, provider),
+		Type: Info,
+		Context: context(map[string]interface{}{
+			"provider": provider,
+			"address":  address,
+		}),
+	}
+}
+
 func NewErrorValidationLoginFlowExpired(ago time.Duration) *Message {
 	return &Message{
 		ID:   ErrorValidationLoginFlowExpired,
@@ -24,3 +38,14 @@ func NewErrorValidationLoginFlowExpired(ago time.Duration) *Message {
 		}),
 	}
 }
+
+func NewErrorValidationLoginLockedOut(until time.Time) *Message {
+	return &Message{
+		ID:   ErrorValidationLoginLockedOut,
+		Text: fmt.Sprintf("Too many failed login attempts, please try again in %.2f minutes.", time.Until(until).Minutes()),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"locked_until": until,
+		}),
+	}
+}
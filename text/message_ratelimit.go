@@ -0,0 +1,22 @@
+package text
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ErrorValidationRateLimit         ID = 4090000 + iota // 4090000
+	ErrorValidationRateLimitExceeded                     // 4090001
+)
+
+func NewErrorValidationRateLimitExceeded(until time.Time) *Message {
+	return &Message{
+		ID:   ErrorValidationRateLimitExceeded,
+		Text: fmt.Sprintf("Too many requests, please try again in %.2f minutes.", time.Until(until).Minutes()),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"retry_at": until,
+		}),
+	}
+}
@@ -13,6 +13,15 @@ const (
 	ErrorValidationPasswordPolicyViolation
 	ErrorValidationInvalidCredentials
 	ErrorValidationDuplicateCredentials
+	ErrorValidationIdentityInactive
+	ErrorValidationPasswordMinLength
+	ErrorValidationPasswordMaxLength
+	ErrorValidationPasswordCharacterClass
+	ErrorValidationPasswordTooWeak
+	ErrorValidationPasswordDenied
+	ErrorValidationPasswordIdentifierSimilarity
+	ErrorValidationPasswordBreached
+	ErrorValidationPasswordReused
 )
 
 func NewValidationErrorGeneric(reason string) *Message {
@@ -87,3 +96,97 @@ func NewErrorValidationDuplicateCredentials() *Message {
 		Context: context(nil),
 	}
 }
+
+func NewErrorValidationIdentityInactive() *Message {
+	return &Message{
+		ID:      ErrorValidationIdentityInactive,
+		Text:    "This account is inactive. Please contact the system administrator.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordMinLength(expected, actual int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMinLength,
+		Text: fmt.Sprintf("The password can not be used because it must have at least %d characters but only has %d.", expected, actual),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"expected_length": expected,
+			"actual_length":   actual,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordMaxLength(expected, actual int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordMaxLength,
+		Text: fmt.Sprintf("The password can not be used because it must have at most %d characters but has %d.", expected, actual),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"expected_length": expected,
+			"actual_length":   actual,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordCharacterClass(class string) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordCharacterClass,
+		Text: fmt.Sprintf("The password can not be used because it must contain at least one %s character.", class),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"character_class": class,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordTooWeak(expected, actual int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordTooWeak,
+		Text: "The password can not be used because it is too easy to guess.",
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"expected_score": expected,
+			"actual_score":   actual,
+		}),
+	}
+}
+
+func NewErrorValidationPasswordDenied() *Message {
+	return &Message{
+		ID:      ErrorValidationPasswordDenied,
+		Text:    "The password can not be used because it is not allowed.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordIdentifierSimilarity() *Message {
+	return &Message{
+		ID:      ErrorValidationPasswordIdentifierSimilarity,
+		Text:    "The password can not be used because it is too similar to the user identifier.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordBreached() *Message {
+	return &Message{
+		ID:      ErrorValidationPasswordBreached,
+		Text:    "The password can not be used because it has been found in data breaches and must no longer be used.",
+		Type:    Error,
+		Context: context(nil),
+	}
+}
+
+func NewErrorValidationPasswordReused(historySize int) *Message {
+	return &Message{
+		ID:   ErrorValidationPasswordReused,
+		Text: fmt.Sprintf("The password can not be used because it is one of your last %d passwords.", historySize),
+		Type: Error,
+		Context: context(map[string]interface{}{
+			"history_size": historySize,
+		}),
+	}
+}
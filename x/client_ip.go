@@ -0,0 +1,85 @@
+package x
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/urfave/negroni"
+
+	"github.com/ory/kratos/driver/config"
+)
+
+type clientIPContextKey struct{}
+
+// ClientIP returns the IP address of the client which sent the request, without the port.
+//
+// If the request passed through the middleware returned by NewClientIPMiddleware, the address is resolved
+// from the X-Forwarded-For header of trusted proxies. Otherwise, the address the request was sent from is
+// returned.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPContextKey{}).(string); ok {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ResolveClientIP returns the IP address of the client which sent the request.
+//
+// The X-Forwarded-For header is only used if the request was sent by one of the trusted proxies, as any client
+// can set it. The header is read from right to left and the first address which does not belong to a trusted
+// proxy is returned, because proxies append the address they received the request from.
+func ResolveClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	ip := remoteIP(r)
+	if !isTrustedProxy(ip, trustedProxies) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for k := len(forwarded) - 1; k >= 0; k-- {
+		candidate := strings.TrimSpace(forwarded[k])
+		if net.ParseIP(candidate) == nil {
+			// Anything in front of an invalid address can not be trusted.
+			return ip
+		}
+
+		ip = candidate
+		if !isTrustedProxy(ip, trustedProxies) {
+			return ip
+		}
+	}
+
+	return ip
+}
+
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewClientIPMiddleware resolves the IP address returned by ClientIP using the trusted proxies configured in
+// `serve.public.trusted_proxies`.
+func NewClientIPMiddleware(d config.Provider) negroni.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		ip := ResolveClientIP(r, d.Config(r.Context()).PublicTrustedProxies())
+		next(rw, r.WithContext(context.WithValue(r.Context(), clientIPContextKey{}, ip)))
+	}
+}
@@ -0,0 +1,52 @@
+package x
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	assert.EqualValues(t, "127.0.0.1", ClientIP(&http.Request{RemoteAddr: "127.0.0.1:4433"}))
+	assert.EqualValues(t, "::1", ClientIP(&http.Request{RemoteAddr: "[::1]:4433"}))
+	assert.EqualValues(t, "127.0.0.1", ClientIP(&http.Request{RemoteAddr: "127.0.0.1"}))
+	assert.EqualValues(t, "127.0.0.1", ClientIP(&http.Request{RemoteAddr: "127.0.0.1:4433",
+		Header: http.Header{"X-Forwarded-For": {"1.1.1.1"}}}), "the header must be ignored without the middleware")
+}
+
+func TestResolveClientIP(t *testing.T) {
+	var proxies []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "::1/128"} {
+		_, n, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		proxies = append(proxies, n)
+	}
+
+	for k, tc := range []struct {
+		remoteAddr string
+		forwarded  []string
+		trusted    []*net.IPNet
+		expected   string
+	}{
+		{remoteAddr: "10.0.0.1:4433", forwarded: []string{"1.1.1.1"}, expected: "10.0.0.1"},
+		{remoteAddr: "10.0.0.1:4433", forwarded: []string{"1.1.1.1"}, trusted: proxies, expected: "1.1.1.1"},
+		{remoteAddr: "[::1]:4433", forwarded: []string{"1.1.1.1"}, trusted: proxies, expected: "1.1.1.1"},
+		{remoteAddr: "2.2.2.2:4433", forwarded: []string{"1.1.1.1"}, trusted: proxies, expected: "2.2.2.2"},
+		{remoteAddr: "10.0.0.1:4433", trusted: proxies, expected: "10.0.0.1"},
+		{remoteAddr: "10.0.0.1:4433", forwarded: []string{"3.3.3.3, 1.1.1.1, 10.0.0.2"}, trusted: proxies, expected: "1.1.1.1"},
+		{remoteAddr: "10.0.0.1:4433", forwarded: []string{"3.3.3.3", "1.1.1.1"}, trusted: proxies, expected: "1.1.1.1"},
+		{remoteAddr: "10.0.0.1:4433", forwarded: []string{"10.0.0.3, 10.0.0.2"}, trusted: proxies, expected: "10.0.0.3"},
+		{remoteAddr: "10.0.0.1:4433", forwarded: []string{"1.1.1.1, not-an-ip"}, trusted: proxies, expected: "10.0.0.1"},
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tc.remoteAddr
+		for _, f := range tc.forwarded {
+			r.Header.Add("X-Forwarded-For", f)
+		}
+		assert.Equal(t, tc.expected, ResolveClientIP(r, tc.trusted), "case %d", k)
+	}
+}
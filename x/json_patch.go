@@ -0,0 +1,72 @@
+package x
+
+import (
+	"encoding/json"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+
+	"github.com/ory/herodot"
+)
+
+// JSONPatch is a single RFC 6902 JSON Patch operation.
+//
+// swagger:model jsonPatch
+type JSONPatch struct {
+	// The operation to be performed
+	//
+	// required: true
+	// example: "replace"
+	// enum: add,remove,replace,move,copy,test
+	Op string `json:"op"`
+
+	// A JSON-pointer
+	//
+	// required: true
+	// example: "/traits/email"
+	Path string `json:"path"`
+
+	// The value to be used within the operations
+	Value interface{} `json:"value,omitempty"`
+
+	// A JSON-pointer, only used by the move and copy operations
+	From string `json:"from,omitempty"`
+}
+
+// JSONPatchDocument is a list of RFC 6902 JSON Patch operations.
+//
+// swagger:model jsonPatchDocument
+type JSONPatchDocument []JSONPatch
+
+// ApplyJSONPatch applies the RFC 6902 JSON Patch p to the JSON document doc and returns the
+// patched document. Operations on any of the denied paths or their children are rejected.
+func ApplyJSONPatch(p json.RawMessage, doc []byte, denyPaths ...string) ([]byte, error) {
+	patch, err := jsonpatch.DecodePatch(p)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to decode JSON Patch: %s", err).WithWrap(err))
+	}
+
+	for _, op := range patch {
+		for _, get := range []func() (string, error){op.Path, op.From} {
+			path, err := get()
+			if err != nil {
+				// The "from" member is optional and only set for move and copy operations.
+				continue
+			}
+
+			for _, denied := range denyPaths {
+				if path == denied || strings.HasPrefix(path, denied+"/") {
+					return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("The JSON Patch operation %q on path %q is not allowed.", op.Kind(), path))
+				}
+			}
+		}
+	}
+
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrBadRequest.WithReasonf("Unable to apply JSON Patch: %s", err).WithWrap(err))
+	}
+
+	return patched, nil
+}
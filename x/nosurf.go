@@ -64,6 +64,9 @@ func (f *FakeCSRFHandler) ExemptPath(s string) {
 func (f *FakeCSRFHandler) IgnorePath(s string) {
 }
 
+func (f *FakeCSRFHandler) ExemptGlob(s string) {
+}
+
 func (f *FakeCSRFHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
@@ -79,6 +82,7 @@ type CSRFHandler interface {
 	http.Handler
 	RegenerateToken(w http.ResponseWriter, r *http.Request) string
 	ExemptPath(string)
+	ExemptGlob(string)
 	IgnorePath(string)
 }
 